@@ -0,0 +1,13 @@
+-- IF NOT EXISTS so databases created by hand before migrations existed
+-- are adopted as-is.
+CREATE TABLE IF NOT EXISTS categories (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS products (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	price INTEGER NOT NULL DEFAULT 0,
+	stock INTEGER NOT NULL DEFAULT 0
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS transactions (
+	id SERIAL PRIMARY KEY,
+	date TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	total_amount INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS transaction_details (
+	id SERIAL PRIMARY KEY,
+	transaction_id INTEGER REFERENCES transactions(id),
+	product_id INTEGER,
+	quantity INTEGER NOT NULL,
+	subtotal INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_date ON transactions (date);
+CREATE INDEX IF NOT EXISTS idx_transaction_details_transaction_id ON transaction_details (transaction_id);
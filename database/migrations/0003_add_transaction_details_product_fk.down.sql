@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transaction_details_product_id;
+ALTER TABLE transaction_details DROP CONSTRAINT IF EXISTS transaction_details_product_id_fkey;
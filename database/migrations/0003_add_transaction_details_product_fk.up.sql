@@ -0,0 +1,6 @@
+-- Sold products cannot be deleted, otherwise reports lose their names.
+ALTER TABLE transaction_details
+	ADD CONSTRAINT transaction_details_product_id_fkey
+	FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_transaction_details_product_id ON transaction_details (product_id);
@@ -0,0 +1,7 @@
+-- Products without a category are shown as uncategorized. Categories that
+-- still have products cannot be deleted until the products are reassigned.
+ALTER TABLE products
+	ADD COLUMN IF NOT EXISTS category_id INTEGER
+	REFERENCES categories(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_products_category_id ON products (category_id);
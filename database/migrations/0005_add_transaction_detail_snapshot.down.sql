@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_total_amount;
+ALTER TABLE transaction_details
+	DROP COLUMN IF EXISTS unit_price,
+	DROP COLUMN IF EXISTS product_name;
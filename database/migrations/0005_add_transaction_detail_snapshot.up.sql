@@ -0,0 +1,13 @@
+-- Snapshot of the product at time of sale, so receipts stay correct after
+-- the product is renamed or repriced.
+ALTER TABLE transaction_details
+	ADD COLUMN IF NOT EXISTS product_name VARCHAR(255) NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS unit_price INTEGER NOT NULL DEFAULT 0;
+
+UPDATE transaction_details td
+SET product_name = p.name,
+	unit_price = CASE WHEN td.quantity <> 0 THEN td.subtotal / td.quantity ELSE 0 END
+FROM products p
+WHERE p.id = td.product_id;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_total_amount ON transactions (total_amount);
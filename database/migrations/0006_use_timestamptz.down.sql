@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+	ALTER COLUMN date TYPE TIMESTAMP USING date::timestamp,
+	ALTER COLUMN date SET DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,5 @@
+-- Existing values are interpreted in the session TimeZone of the connection
+-- running the migration; set it to the zone the server used to run in.
+ALTER TABLE transactions
+	ALTER COLUMN date TYPE TIMESTAMPTZ USING date::timestamptz,
+	ALTER COLUMN date SET DEFAULT CURRENT_TIMESTAMP;
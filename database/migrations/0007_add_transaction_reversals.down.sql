@@ -0,0 +1,13 @@
+DELETE FROM transaction_details WHERE reference_detail_id IS NOT NULL;
+DELETE FROM transactions WHERE type <> 'sale';
+
+DROP INDEX IF EXISTS idx_transaction_details_reference_detail_id;
+DROP INDEX IF EXISTS uq_transactions_void_reference;
+DROP INDEX IF EXISTS idx_transactions_reference_id;
+
+ALTER TABLE transaction_details DROP COLUMN IF EXISTS reference_detail_id;
+ALTER TABLE transactions
+	DROP COLUMN IF EXISTS approved_by,
+	DROP COLUMN IF EXISTS reason,
+	DROP COLUMN IF EXISTS reference_id,
+	DROP COLUMN IF EXISTS type;
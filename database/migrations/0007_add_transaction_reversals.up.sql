@@ -0,0 +1,15 @@
+-- Voids and returns are stored as negative transactions linked to the sale
+-- they reverse, so summing total_amount and quantity nets them out.
+ALTER TABLE transactions
+	ADD COLUMN IF NOT EXISTS type VARCHAR(10) NOT NULL DEFAULT 'sale'
+		CHECK (type IN ('sale', 'void', 'return')),
+	ADD COLUMN IF NOT EXISTS reference_id INTEGER REFERENCES transactions(id),
+	ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS approved_by VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE transaction_details
+	ADD COLUMN IF NOT EXISTS reference_detail_id INTEGER REFERENCES transaction_details(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_reference_id ON transactions (reference_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_transactions_void_reference ON transactions (reference_id) WHERE type = 'void';
+CREATE INDEX IF NOT EXISTS idx_transaction_details_reference_detail_id ON transaction_details (reference_detail_id);
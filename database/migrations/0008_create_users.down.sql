@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_transactions_cashier_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS cashier_id;
+DROP TABLE IF EXISTS users;
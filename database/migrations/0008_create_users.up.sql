@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS users (
+	id SERIAL PRIMARY KEY,
+	username VARCHAR(100) NOT NULL UNIQUE,
+	name VARCHAR(255) NOT NULL DEFAULT '',
+	password_hash VARCHAR(255) NOT NULL,
+	role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'cashier')),
+	active BOOLEAN NOT NULL DEFAULT TRUE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- User who rang up the sale, or who performed the void/return.
+ALTER TABLE transactions
+	ADD COLUMN IF NOT EXISTS cashier_id INTEGER REFERENCES users(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_cashier_id ON transactions (cashier_id);
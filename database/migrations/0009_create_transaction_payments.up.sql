@@ -0,0 +1,13 @@
+-- amount is what the tender contributes to the total (negative for refunds);
+-- tendered and change_amount are only meaningful for cash.
+CREATE TABLE IF NOT EXISTS transaction_payments (
+	id SERIAL PRIMARY KEY,
+	transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+	method VARCHAR(20) NOT NULL CHECK (method IN ('cash', 'card', 'ewallet', 'qris')),
+	amount INTEGER NOT NULL,
+	tendered INTEGER NOT NULL DEFAULT 0,
+	change_amount INTEGER NOT NULL DEFAULT 0,
+	reference VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_payments_transaction_id ON transaction_payments (transaction_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS transaction_promotions;
+ALTER TABLE transaction_details DROP COLUMN IF EXISTS discount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS discount_amount;
+DROP TABLE IF EXISTS promotion_items;
+DROP TABLE IF EXISTS promotions;
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS promotions (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	type VARCHAR(20) NOT NULL CHECK (type IN ('percentage', 'fixed', 'buy_x_get_y', 'bundle')),
+	scope VARCHAR(20) NOT NULL DEFAULT 'cart' CHECK (scope IN ('cart', 'product', 'category')),
+	product_id INTEGER REFERENCES products(id) ON DELETE CASCADE,
+	category_id INTEGER REFERENCES categories(id) ON DELETE CASCADE,
+	value INTEGER NOT NULL DEFAULT 0,
+	buy_quantity INTEGER NOT NULL DEFAULT 0,
+	get_quantity INTEGER NOT NULL DEFAULT 0,
+	get_product_id INTEGER REFERENCES products(id) ON DELETE CASCADE,
+	min_subtotal INTEGER NOT NULL DEFAULT 0,
+	starts_at TIMESTAMPTZ,
+	ends_at TIMESTAMPTZ,
+	-- Bit 0 = Sunday ... bit 6 = Saturday; 0 means every day.
+	days_mask INTEGER NOT NULL DEFAULT 0,
+	-- Daily window in store time, e.g. 15:00-17:00 happy hour. May wrap past midnight.
+	start_time TIME,
+	end_time TIME,
+	active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE IF NOT EXISTS promotion_items (
+	promotion_id INTEGER NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+	product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	quantity INTEGER NOT NULL CHECK (quantity > 0),
+	PRIMARY KEY (promotion_id, product_id)
+);
+
+ALTER TABLE transactions
+	ADD COLUMN IF NOT EXISTS discount_amount INTEGER NOT NULL DEFAULT 0;
+
+-- subtotal stays the net amount of the line; discount is what was taken off
+-- unit_price * quantity, including its share of cart-level discounts.
+ALTER TABLE transaction_details
+	ADD COLUMN IF NOT EXISTS discount INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS transaction_promotions (
+	id SERIAL PRIMARY KEY,
+	transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+	promotion_id INTEGER REFERENCES promotions(id) ON DELETE SET NULL,
+	name VARCHAR(255) NOT NULL,
+	type VARCHAR(20) NOT NULL,
+	discount INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_promotions_transaction_id ON transaction_promotions (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_promotions_promotion_id ON transaction_promotions (promotion_id);
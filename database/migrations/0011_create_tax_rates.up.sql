@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS tax_rates (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	-- Percent, e.g. 11.00 for PPN 11%.
+	rate NUMERIC(5,2) NOT NULL CHECK (rate >= 0 AND rate < 100),
+	-- TRUE when product prices already include this tax.
+	inclusive BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- A product's own rate wins over its category's; neither means not taxed.
+ALTER TABLE categories
+	ADD COLUMN IF NOT EXISTS tax_rate_id INTEGER REFERENCES tax_rates(id) ON DELETE RESTRICT;
+
+ALTER TABLE products
+	ADD COLUMN IF NOT EXISTS tax_rate_id INTEGER REFERENCES tax_rates(id) ON DELETE RESTRICT;
+
+ALTER TABLE transactions
+	ADD COLUMN IF NOT EXISTS tax_amount INTEGER NOT NULL DEFAULT 0;
+
+-- The rate is copied onto each line so later rate changes don't rewrite history.
+-- For inclusive lines tax is part of subtotal, otherwise it is added on top.
+ALTER TABLE transaction_details
+	ADD COLUMN IF NOT EXISTS tax_rate_id INTEGER REFERENCES tax_rates(id) ON DELETE SET NULL,
+	ADD COLUMN IF NOT EXISTS tax_name VARCHAR(100),
+	ADD COLUMN IF NOT EXISTS tax_rate NUMERIC(5,2) NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+	ADD COLUMN IF NOT EXISTS tax INTEGER NOT NULL DEFAULT 0;
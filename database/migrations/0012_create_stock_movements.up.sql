@@ -0,0 +1,25 @@
+-- Append-only stock ledger. Every change to products.stock goes through a row
+-- here in the same database transaction, with the resulting balance.
+CREATE TABLE IF NOT EXISTS stock_movements (
+	id SERIAL PRIMARY KEY,
+	product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	type VARCHAR(20) NOT NULL CHECK (type IN ('opening', 'sale', 'void', 'return', 'purchase', 'adjustment', 'transfer', 'waste')),
+	quantity INTEGER NOT NULL,
+	balance_after INTEGER NOT NULL,
+	-- Source document, e.g. ('transaction', 42).
+	reference_type VARCHAR(30),
+	reference_id INTEGER,
+	note TEXT NOT NULL DEFAULT '',
+	user_id INTEGER REFERENCES users(id),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product_id ON stock_movements (product_id, id);
+CREATE INDEX IF NOT EXISTS idx_stock_movements_reference ON stock_movements (reference_type, reference_id);
+
+-- Existing stock becomes the opening balance of the ledger.
+INSERT INTO stock_movements (product_id, type, quantity, balance_after, note)
+SELECT p.id, 'opening', p.stock, p.stock, 'balance before stock ledger'
+FROM products p
+WHERE p.stock <> 0
+	AND NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = p.id);
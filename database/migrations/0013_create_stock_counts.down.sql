@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS stock_count_lines;
+DROP TABLE IF EXISTS stock_count_items;
+DROP TABLE IF EXISTS stock_counts;
+ALTER TABLE products DROP COLUMN IF EXISTS cost;
@@ -0,0 +1,42 @@
+-- Cost price per unit, used to value stock count variances.
+ALTER TABLE products
+	ADD COLUMN IF NOT EXISTS cost INTEGER NOT NULL DEFAULT 0 CHECK (cost >= 0);
+
+CREATE TABLE IF NOT EXISTS stock_counts (
+	id SERIAL PRIMARY KEY,
+	status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'posted', 'cancelled')),
+	note TEXT NOT NULL DEFAULT '',
+	opened_by INTEGER REFERENCES users(id),
+	opened_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	closed_by INTEGER REFERENCES users(id),
+	closed_at TIMESTAMPTZ
+);
+
+-- Only one count may be open at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_counts_open ON stock_counts (status) WHERE status = 'open';
+
+-- Counted quantity per product per device. A device re-sending a product
+-- replaces its own figure; the counted total is the sum over devices.
+CREATE TABLE IF NOT EXISTS stock_count_items (
+	count_id INTEGER NOT NULL REFERENCES stock_counts(id) ON DELETE CASCADE,
+	product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	device VARCHAR(100) NOT NULL DEFAULT '',
+	quantity INTEGER NOT NULL CHECK (quantity >= 0),
+	counted_by INTEGER REFERENCES users(id),
+	counted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (count_id, product_id, device)
+);
+
+-- Variances as posted, valued at the cost at posting time.
+CREATE TABLE IF NOT EXISTS stock_count_lines (
+	count_id INTEGER NOT NULL REFERENCES stock_counts(id) ON DELETE CASCADE,
+	product_id INTEGER REFERENCES products(id) ON DELETE SET NULL,
+	product_name VARCHAR(255) NOT NULL,
+	system_quantity INTEGER NOT NULL,
+	counted_quantity INTEGER NOT NULL,
+	variance INTEGER NOT NULL,
+	cost INTEGER NOT NULL,
+	variance_value INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_count_lines_count_id ON stock_count_lines (count_id);
@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS suppliers (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	phone VARCHAR(50) NOT NULL DEFAULT '',
+	email VARCHAR(255) NOT NULL DEFAULT '',
+	address TEXT NOT NULL DEFAULT '',
+	active BOOLEAN NOT NULL DEFAULT TRUE
+);
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+	id SERIAL PRIMARY KEY,
+	supplier_id INTEGER NOT NULL REFERENCES suppliers(id) ON DELETE RESTRICT,
+	status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'partial', 'received', 'cancelled')),
+	order_date TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	expected_date DATE,
+	note TEXT NOT NULL DEFAULT '',
+	total_amount INTEGER NOT NULL DEFAULT 0,
+	created_by INTEGER REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_status ON purchase_orders (status);
+
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+	id SERIAL PRIMARY KEY,
+	purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders(id) ON DELETE CASCADE,
+	product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+	quantity INTEGER NOT NULL CHECK (quantity > 0),
+	unit_cost INTEGER NOT NULL CHECK (unit_cost >= 0),
+	received_quantity INTEGER NOT NULL DEFAULT 0 CHECK (received_quantity >= 0 AND received_quantity <= quantity)
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_order_lines_po_id ON purchase_order_lines (purchase_order_id);
+
+CREATE TABLE IF NOT EXISTS goods_receipts (
+	id SERIAL PRIMARY KEY,
+	purchase_order_id INTEGER NOT NULL REFERENCES purchase_orders(id) ON DELETE RESTRICT,
+	received_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	received_by INTEGER REFERENCES users(id),
+	note TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_goods_receipts_po_id ON goods_receipts (purchase_order_id);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_lines (
+	id SERIAL PRIMARY KEY,
+	goods_receipt_id INTEGER NOT NULL REFERENCES goods_receipts(id) ON DELETE CASCADE,
+	purchase_order_line_id INTEGER NOT NULL REFERENCES purchase_order_lines(id) ON DELETE RESTRICT,
+	product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+	quantity INTEGER NOT NULL CHECK (quantity > 0),
+	unit_cost INTEGER NOT NULL CHECK (unit_cost >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_goods_receipt_lines_receipt_id ON goods_receipt_lines (goods_receipt_id);
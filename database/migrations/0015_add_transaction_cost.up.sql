@@ -0,0 +1,9 @@
+-- Unit cost (HPP) of each sold line, copied from products.cost at sale time so
+-- margins are not rewritten when purchase prices change later.
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit_cost INTEGER NOT NULL DEFAULT 0;
+
+-- Best effort for sales made before costs were recorded: use the current cost.
+UPDATE transaction_details td
+SET unit_cost = p.cost
+FROM products p
+WHERE p.id = td.product_id AND td.unit_cost = 0 AND p.cost <> 0;
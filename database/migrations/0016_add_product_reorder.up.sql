@@ -0,0 +1,3 @@
+-- A product is low on stock when stock < min_stock; 0 disables the alert.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS min_stock INTEGER NOT NULL DEFAULT 0 CHECK (min_stock >= 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_quantity INTEGER NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0);
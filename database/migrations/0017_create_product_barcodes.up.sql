@@ -0,0 +1,14 @@
+ALTER TABLE products ADD COLUMN IF NOT EXISTS sku VARCHAR(64);
+CREATE UNIQUE INDEX IF NOT EXISTS products_sku_key ON products (sku);
+
+-- A product can have several barcodes: manufacturer EAN-13/UPC-A/EAN-8 codes,
+-- internal codes, and the 5-digit PLU used in scale labels for weighed goods.
+CREATE TABLE IF NOT EXISTS product_barcodes (
+	id SERIAL PRIMARY KEY,
+	product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	code VARCHAR(32) NOT NULL,
+	type VARCHAR(10) NOT NULL CHECK (type IN ('ean13', 'upca', 'ean8', 'plu', 'internal')),
+	CONSTRAINT product_barcodes_code_key UNIQUE (code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_barcodes_product_id ON product_barcodes (product_id);
@@ -0,0 +1,6 @@
+ALTER TABLE transaction_details DROP COLUMN IF EXISTS parent_product_id;
+DROP TABLE IF EXISTS product_variant_options;
+DROP TABLE IF EXISTS product_option_values;
+DROP TABLE IF EXISTS product_option_groups;
+DROP INDEX IF EXISTS idx_products_parent_id;
+ALTER TABLE products DROP COLUMN IF EXISTS parent_id;
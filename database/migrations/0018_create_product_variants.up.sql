@@ -0,0 +1,28 @@
+-- A variant is a sellable product row with parent_id set; it has its own SKU,
+-- barcodes, price, cost and stock. The parent only groups its variants.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES products(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_products_parent_id ON products (parent_id);
+
+-- Option groups (Size, Temperature) and their values (Large, Iced) per parent.
+CREATE TABLE IF NOT EXISTS product_option_groups (
+	id SERIAL PRIMARY KEY,
+	product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	name VARCHAR(100) NOT NULL,
+	UNIQUE (product_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS product_option_values (
+	id SERIAL PRIMARY KEY,
+	group_id INTEGER NOT NULL REFERENCES product_option_groups(id) ON DELETE CASCADE,
+	value VARCHAR(100) NOT NULL,
+	UNIQUE (group_id, value)
+);
+
+CREATE TABLE IF NOT EXISTS product_variant_options (
+	variant_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	value_id INTEGER NOT NULL REFERENCES product_option_values(id) ON DELETE CASCADE,
+	PRIMARY KEY (variant_id, value_id)
+);
+
+-- Parent of the variant sold on each line, so reports can roll variants up.
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS parent_product_id INTEGER REFERENCES products(id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS transaction_detail_modifiers;
+DROP TABLE IF EXISTS product_modifier_groups;
+DROP TABLE IF EXISTS modifiers;
+DROP TABLE IF EXISTS modifier_groups;
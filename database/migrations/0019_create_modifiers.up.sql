@@ -0,0 +1,39 @@
+-- Modifier groups (Sugar level, Extra shot) are shared between products. A
+-- required group needs at least min_select choices; max_select 0 means no limit.
+CREATE TABLE IF NOT EXISTS modifier_groups (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	required BOOLEAN NOT NULL DEFAULT FALSE,
+	min_select INTEGER NOT NULL DEFAULT 0 CHECK (min_select >= 0),
+	max_select INTEGER NOT NULL DEFAULT 0 CHECK (max_select >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS modifiers (
+	id SERIAL PRIMARY KEY,
+	group_id INTEGER NOT NULL REFERENCES modifier_groups(id) ON DELETE CASCADE,
+	name VARCHAR(100) NOT NULL,
+	price INTEGER NOT NULL DEFAULT 0 CHECK (price >= 0),
+	active BOOLEAN NOT NULL DEFAULT TRUE,
+	sort_order INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_modifiers_group_id ON modifiers (group_id);
+
+-- Groups offered on a product; a parent's groups also apply to its variants.
+CREATE TABLE IF NOT EXISTS product_modifier_groups (
+	product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	group_id INTEGER NOT NULL REFERENCES modifier_groups(id) ON DELETE CASCADE,
+	sort_order INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (product_id, group_id)
+);
+
+-- Modifiers chosen on a sale line, with name and price copied at checkout.
+-- Each applies once per unit of the line; void and return lines copy them.
+CREATE TABLE IF NOT EXISTS transaction_detail_modifiers (
+	id SERIAL PRIMARY KEY,
+	detail_id INTEGER NOT NULL REFERENCES transaction_details(id) ON DELETE CASCADE,
+	modifier_id INTEGER REFERENCES modifiers(id) ON DELETE SET NULL,
+	group_name VARCHAR(100) NOT NULL,
+	name VARCHAR(100) NOT NULL,
+	price INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_transaction_detail_modifiers_detail_id ON transaction_detail_modifiers (detail_id);
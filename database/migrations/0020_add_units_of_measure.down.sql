@@ -0,0 +1,26 @@
+ALTER TABLE goods_receipt_lines ALTER COLUMN quantity TYPE INTEGER USING ROUND(quantity);
+ALTER TABLE purchase_order_lines
+	DROP COLUMN IF EXISTS unit_factor,
+	DROP COLUMN IF EXISTS unit,
+	ALTER COLUMN received_quantity TYPE INTEGER USING ROUND(received_quantity),
+	ALTER COLUMN quantity TYPE INTEGER USING ROUND(quantity);
+ALTER TABLE transaction_details
+	DROP COLUMN IF EXISTS unit_factor,
+	DROP COLUMN IF EXISTS unit,
+	ALTER COLUMN quantity TYPE INTEGER USING ROUND(quantity);
+DROP TABLE IF EXISTS product_units;
+ALTER TABLE products
+	DROP COLUMN IF EXISTS decimals,
+	DROP COLUMN IF EXISTS unit;
+ALTER TABLE stock_count_lines
+	ALTER COLUMN variance TYPE INTEGER USING ROUND(variance),
+	ALTER COLUMN counted_quantity TYPE INTEGER USING ROUND(counted_quantity),
+	ALTER COLUMN system_quantity TYPE INTEGER USING ROUND(system_quantity);
+ALTER TABLE stock_count_items ALTER COLUMN quantity TYPE INTEGER USING ROUND(quantity);
+ALTER TABLE stock_movements
+	ALTER COLUMN balance_after TYPE INTEGER USING ROUND(balance_after),
+	ALTER COLUMN quantity TYPE INTEGER USING ROUND(quantity);
+ALTER TABLE products
+	ALTER COLUMN reorder_quantity TYPE INTEGER USING ROUND(reorder_quantity),
+	ALTER COLUMN min_stock TYPE INTEGER USING ROUND(min_stock),
+	ALTER COLUMN stock TYPE INTEGER USING ROUND(stock);
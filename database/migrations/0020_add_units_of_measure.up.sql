@@ -0,0 +1,43 @@
+-- Quantities become decimals with three places (0.75 kg). Stock, ledger,
+-- counts and receipts are always in the product's base unit.
+ALTER TABLE products
+	ALTER COLUMN stock TYPE NUMERIC(14,3),
+	ALTER COLUMN min_stock TYPE NUMERIC(14,3),
+	ALTER COLUMN reorder_quantity TYPE NUMERIC(14,3);
+ALTER TABLE stock_movements
+	ALTER COLUMN quantity TYPE NUMERIC(14,3),
+	ALTER COLUMN balance_after TYPE NUMERIC(14,3);
+ALTER TABLE stock_count_items ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE stock_count_lines
+	ALTER COLUMN system_quantity TYPE NUMERIC(14,3),
+	ALTER COLUMN counted_quantity TYPE NUMERIC(14,3),
+	ALTER COLUMN variance TYPE NUMERIC(14,3);
+
+-- Base unit and how many decimals a quantity of it may have.
+ALTER TABLE products
+	ADD COLUMN IF NOT EXISTS unit VARCHAR(20) NOT NULL DEFAULT 'pcs',
+	ADD COLUMN IF NOT EXISTS decimals INTEGER NOT NULL DEFAULT 0 CHECK (decimals BETWEEN 0 AND 3);
+
+-- Other units a product is sold or bought in, e.g. a carton of 24 pcs. A NULL
+-- price means factor times the base price.
+CREATE TABLE IF NOT EXISTS product_units (
+	id SERIAL PRIMARY KEY,
+	product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+	name VARCHAR(20) NOT NULL,
+	factor NUMERIC(14,3) NOT NULL CHECK (factor > 0),
+	price INTEGER CHECK (price >= 0),
+	UNIQUE (product_id, name)
+);
+
+-- Sale and purchase lines keep the unit they were entered in; quantity,
+-- unit_price and unit_cost are per that unit and unit_factor converts to base.
+ALTER TABLE transaction_details
+	ALTER COLUMN quantity TYPE NUMERIC(14,3),
+	ADD COLUMN IF NOT EXISTS unit VARCHAR(20) NOT NULL DEFAULT 'pcs',
+	ADD COLUMN IF NOT EXISTS unit_factor NUMERIC(14,3) NOT NULL DEFAULT 1;
+ALTER TABLE purchase_order_lines
+	ALTER COLUMN quantity TYPE NUMERIC(14,3),
+	ALTER COLUMN received_quantity TYPE NUMERIC(14,3),
+	ADD COLUMN IF NOT EXISTS unit VARCHAR(20) NOT NULL DEFAULT 'pcs',
+	ADD COLUMN IF NOT EXISTS unit_factor NUMERIC(14,3) NOT NULL DEFAULT 1;
+ALTER TABLE goods_receipt_lines ALTER COLUMN quantity TYPE NUMERIC(14,3);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_list_stock;
+DROP INDEX IF EXISTS idx_products_list_price;
+DROP INDEX IF EXISTS idx_products_list_name;
+DROP INDEX IF EXISTS idx_products_sku_trgm;
+DROP INDEX IF EXISTS idx_products_name_trgm;
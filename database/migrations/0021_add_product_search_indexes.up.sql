@@ -0,0 +1,10 @@
+-- Trigram indexes back substring, prefix and fuzzy (typo tolerant) search on
+-- product names and SKUs.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_products_name_trgm ON products USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_products_sku_trgm ON products USING gin (sku gin_trgm_ops);
+
+-- Keyset pagination of the top-level product list per sort order.
+CREATE INDEX IF NOT EXISTS idx_products_list_name ON products (name, id) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_products_list_price ON products (price, id) WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_products_list_stock ON products (stock, id) WHERE parent_id IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS product_import_errors;
+DROP TABLE IF EXISTS product_import_jobs;
@@ -0,0 +1,30 @@
+-- Bulk product imports run in the background; each upload is tracked as a
+-- job with its progress counters. A dry run validates without saving.
+CREATE TABLE IF NOT EXISTS product_import_jobs (
+	id SERIAL PRIMARY KEY,
+	status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+	dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+	file_name VARCHAR(255) NOT NULL DEFAULT '',
+	format VARCHAR(10) NOT NULL CHECK (format IN ('csv', 'xlsx')),
+	total_rows INTEGER NOT NULL DEFAULT 0,
+	processed_rows INTEGER NOT NULL DEFAULT 0,
+	created_rows INTEGER NOT NULL DEFAULT 0,
+	updated_rows INTEGER NOT NULL DEFAULT 0,
+	failed_rows INTEGER NOT NULL DEFAULT 0,
+	message TEXT NOT NULL DEFAULT '',
+	created_by INTEGER REFERENCES users(id),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	started_at TIMESTAMPTZ,
+	finished_at TIMESTAMPTZ
+);
+
+-- Row-level problems found while validating or saving an import. Row is the
+-- spreadsheet line number (the header is line 1).
+CREATE TABLE IF NOT EXISTS product_import_errors (
+	id SERIAL PRIMARY KEY,
+	job_id INTEGER NOT NULL REFERENCES product_import_jobs(id) ON DELETE CASCADE,
+	row_number INTEGER NOT NULL,
+	column_name VARCHAR(100) NOT NULL DEFAULT '',
+	message TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_product_import_errors_job_id ON product_import_errors (job_id, row_number);
@@ -0,0 +1,12 @@
+ALTER TABLE transaction_payments DROP CONSTRAINT IF EXISTS transaction_payments_method_check;
+ALTER TABLE transaction_payments ADD CONSTRAINT transaction_payments_method_check
+	CHECK (method IN ('cash', 'card', 'ewallet', 'qris'));
+
+DROP TABLE IF EXISTS loyalty_point_entries;
+DROP INDEX IF EXISTS idx_transactions_customer_id;
+ALTER TABLE transactions
+	DROP COLUMN IF EXISTS points_redeemed,
+	DROP COLUMN IF EXISTS points_earned,
+	DROP COLUMN IF EXISTS customer_id;
+DROP TABLE IF EXISTS loyalty_tiers;
+DROP TABLE IF EXISTS customers;
@@ -0,0 +1,50 @@
+-- Customer master data. Phone and member card are optional but unique when
+-- set. points is the loyalty balance, kept in step with loyalty_point_entries.
+CREATE TABLE IF NOT EXISTS customers (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	phone VARCHAR(50) NOT NULL DEFAULT '',
+	member_card VARCHAR(64) NOT NULL DEFAULT '',
+	email VARCHAR(255) NOT NULL DEFAULT '',
+	points INTEGER NOT NULL DEFAULT 0,
+	active BOOLEAN NOT NULL DEFAULT TRUE,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS customers_phone_key ON customers (phone) WHERE phone <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS customers_member_card_key ON customers (member_card) WHERE member_card <> '';
+CREATE INDEX IF NOT EXISTS idx_customers_name_trgm ON customers USING gin (name gin_trgm_ops);
+
+-- A customer is in the tier with the highest min_spend not above their
+-- lifetime spend; bonus_percent adds to the points they earn.
+CREATE TABLE IF NOT EXISTS loyalty_tiers (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(100) NOT NULL UNIQUE,
+	min_spend INTEGER NOT NULL UNIQUE CHECK (min_spend >= 0),
+	bonus_percent INTEGER NOT NULL DEFAULT 0 CHECK (bonus_percent >= 0)
+);
+
+ALTER TABLE transactions
+	ADD COLUMN IF NOT EXISTS customer_id INTEGER REFERENCES customers(id),
+	ADD COLUMN IF NOT EXISTS points_earned INTEGER NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS points_redeemed INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_transactions_customer_id ON transactions (customer_id, date) WHERE customer_id IS NOT NULL;
+
+-- Append-only point ledger; every change to customers.points goes through a
+-- row here in the same database transaction, with the resulting balance.
+CREATE TABLE IF NOT EXISTS loyalty_point_entries (
+	id SERIAL PRIMARY KEY,
+	customer_id INTEGER NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+	type VARCHAR(20) NOT NULL CHECK (type IN ('earn', 'redeem', 'void', 'return', 'adjustment')),
+	points INTEGER NOT NULL,
+	balance_after INTEGER NOT NULL,
+	transaction_id INTEGER REFERENCES transactions(id),
+	note TEXT NOT NULL DEFAULT '',
+	user_id INTEGER REFERENCES users(id),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_loyalty_point_entries_customer_id ON loyalty_point_entries (customer_id, id);
+
+-- Points can pay for a sale.
+ALTER TABLE transaction_payments DROP CONSTRAINT IF EXISTS transaction_payments_method_check;
+ALTER TABLE transaction_payments ADD CONSTRAINT transaction_payments_method_check
+	CHECK (method IN ('cash', 'card', 'ewallet', 'qris', 'points'));
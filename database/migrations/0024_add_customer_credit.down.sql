@@ -0,0 +1,8 @@
+ALTER TABLE transaction_payments DROP CONSTRAINT IF EXISTS transaction_payments_method_check;
+ALTER TABLE transaction_payments ADD CONSTRAINT transaction_payments_method_check
+	CHECK (method IN ('cash', 'card', 'ewallet', 'qris', 'points'));
+
+DROP TABLE IF EXISTS credit_entries;
+ALTER TABLE customers
+	DROP COLUMN IF EXISTS credit_balance,
+	DROP COLUMN IF EXISTS credit_limit;
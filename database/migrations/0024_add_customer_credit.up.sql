@@ -0,0 +1,28 @@
+-- Customer credit (kasbon). credit_balance is what the customer owes, kept in
+-- step with credit_entries; a negative balance is store credit.
+ALTER TABLE customers
+	ADD COLUMN IF NOT EXISTS credit_limit INTEGER NOT NULL DEFAULT 0 CHECK (credit_limit >= 0),
+	ADD COLUMN IF NOT EXISTS credit_balance INTEGER NOT NULL DEFAULT 0;
+
+-- Append-only receivable ledger. amount is signed: charges (credit tenders
+-- at checkout) raise the balance, repayments and refunds of voided or
+-- returned credit sales lower it.
+CREATE TABLE IF NOT EXISTS credit_entries (
+	id SERIAL PRIMARY KEY,
+	customer_id INTEGER NOT NULL REFERENCES customers(id),
+	type VARCHAR(20) NOT NULL CHECK (type IN ('charge', 'payment', 'void', 'return')),
+	amount INTEGER NOT NULL,
+	balance_after INTEGER NOT NULL,
+	transaction_id INTEGER REFERENCES transactions(id),
+	method VARCHAR(20) NOT NULL DEFAULT '',
+	reference VARCHAR(255) NOT NULL DEFAULT '',
+	note TEXT NOT NULL DEFAULT '',
+	user_id INTEGER REFERENCES users(id),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_credit_entries_customer_id ON credit_entries (customer_id, id);
+
+-- Sales can be put on the customer's account.
+ALTER TABLE transaction_payments DROP CONSTRAINT IF EXISTS transaction_payments_method_check;
+ALTER TABLE transaction_payments ADD CONSTRAINT transaction_payments_method_check
+	CHECK (method IN ('cash', 'card', 'ewallet', 'qris', 'points', 'credit'));
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_credit_entries_shift_id;
+ALTER TABLE credit_entries DROP COLUMN IF EXISTS shift_id;
+DROP INDEX IF EXISTS idx_transactions_shift_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS shift_id;
+DROP TABLE IF EXISTS shift_denominations;
+DROP TABLE IF EXISTS shift_cash_movements;
+DROP TABLE IF EXISTS shifts;
@@ -0,0 +1,44 @@
+-- Cashier shifts. A cashier has at most one open shift; sales, voids, returns
+-- and credit repayments made by a user during their open shift are linked to
+-- it. expected_cash, counted_cash and variance are filled in on close.
+CREATE TABLE IF NOT EXISTS shifts (
+	id SERIAL PRIMARY KEY,
+	cashier_id INTEGER NOT NULL REFERENCES users(id),
+	status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+	opening_float INTEGER NOT NULL DEFAULT 0 CHECK (opening_float >= 0),
+	note TEXT NOT NULL DEFAULT '',
+	opened_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	closed_by INTEGER REFERENCES users(id),
+	closed_at TIMESTAMPTZ,
+	close_note TEXT NOT NULL DEFAULT '',
+	expected_cash INTEGER,
+	counted_cash INTEGER,
+	variance INTEGER
+);
+CREATE UNIQUE INDEX IF NOT EXISTS shifts_open_cashier_key ON shifts (cashier_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_shifts_opened_at ON shifts (opened_at);
+
+-- Petty cash put into (cash_in) or taken out of (cash_out) the drawer.
+CREATE TABLE IF NOT EXISTS shift_cash_movements (
+	id SERIAL PRIMARY KEY,
+	shift_id INTEGER NOT NULL REFERENCES shifts(id) ON DELETE CASCADE,
+	type VARCHAR(20) NOT NULL CHECK (type IN ('cash_in', 'cash_out')),
+	amount INTEGER NOT NULL CHECK (amount > 0),
+	reason TEXT NOT NULL,
+	user_id INTEGER REFERENCES users(id),
+	created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_shift_cash_movements_shift_id ON shift_cash_movements (shift_id);
+
+-- Cash counted on close, per note or coin value.
+CREATE TABLE IF NOT EXISTS shift_denominations (
+	shift_id INTEGER NOT NULL REFERENCES shifts(id) ON DELETE CASCADE,
+	denomination INTEGER NOT NULL CHECK (denomination > 0),
+	count INTEGER NOT NULL CHECK (count >= 0),
+	PRIMARY KEY (shift_id, denomination)
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS shift_id INTEGER REFERENCES shifts(id);
+CREATE INDEX IF NOT EXISTS idx_transactions_shift_id ON transactions (shift_id) WHERE shift_id IS NOT NULL;
+ALTER TABLE credit_entries ADD COLUMN IF NOT EXISTS shift_id INTEGER REFERENCES shifts(id);
+CREATE INDEX IF NOT EXISTS idx_credit_entries_shift_id ON credit_entries (shift_id) WHERE shift_id IS NOT NULL;
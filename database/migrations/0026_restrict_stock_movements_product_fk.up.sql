@@ -0,0 +1,6 @@
+-- The stock ledger is append-only: products with stock history cannot be
+-- deleted, otherwise their movements disappear with them.
+ALTER TABLE stock_movements DROP CONSTRAINT IF EXISTS stock_movements_product_id_fkey;
+ALTER TABLE stock_movements
+	ADD CONSTRAINT stock_movements_product_id_fkey
+	FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE RESTRICT;
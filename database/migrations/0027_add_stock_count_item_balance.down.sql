@@ -0,0 +1 @@
+ALTER TABLE stock_count_items DROP COLUMN IF EXISTS system_quantity;
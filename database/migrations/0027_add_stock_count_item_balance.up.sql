@@ -0,0 +1,15 @@
+-- System stock at the moment each device's figure was saved. Variances are
+-- measured against the balance of the first figure for the product, so stock
+-- moving between two devices' counts is not read as a variance.
+ALTER TABLE stock_count_items ADD COLUMN IF NOT EXISTS system_quantity NUMERIC(14,3);
+
+UPDATE stock_count_items i
+SET system_quantity = COALESCE((
+	SELECT m.balance_after FROM stock_movements m
+	WHERE m.product_id = i.product_id AND m.created_at <= i.counted_at
+	ORDER BY m.id DESC
+	LIMIT 1
+), 0)
+WHERE system_quantity IS NULL;
+
+ALTER TABLE stock_count_items ALTER COLUMN system_quantity SET NOT NULL;
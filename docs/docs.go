@@ -24,6 +24,77 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/auth/login": {
+            "post": {
+                "description": "Exchange username and password for a bearer token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "Credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid username or password",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "get": {
+                "description": "Get the user the bearer token belongs to",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
         "/categories": {
             "get": {
                 "description": "Get a list of all categories or create a new category",
@@ -53,7 +124,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Category"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             },
             "post": {
                 "description": "Get a list of all categories or create a new category",
@@ -83,12 +159,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.Category"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
         "/categories/{id}": {
             "get": {
-                "description": "Operations on a single category",
+                "description": "Operations on a single category. Deleting a category that still has products is rejected with 409 unless reassign_to names another category to move them to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -106,6 +187,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Move products to this category before deleting",
+                        "name": "reassign_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -117,11 +204,22 @@ const docTemplate = `{
                     },
                     "204": {
                         "description": "No Content"
+                    },
+                    "409": {
+                        "description": "Category still has products",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             },
             "put": {
-                "description": "Operations on a single category",
+                "description": "Operations on a single category. Deleting a category that still has products is rejected with 409 unless reassign_to names another category to move them to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -139,6 +237,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Move products to this category before deleting",
+                        "name": "reassign_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -150,11 +254,22 @@ const docTemplate = `{
                     },
                     "204": {
                         "description": "No Content"
+                    },
+                    "409": {
+                        "description": "Category still has products",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             },
             "delete": {
-                "description": "Operations on a single category",
+                "description": "Operations on a single category. Deleting a category that still has products is rejected with 409 unless reassign_to names another category to move them to.",
                 "consumes": [
                     "application/json"
                 ],
@@ -172,6 +287,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Move products to this category before deleting",
+                        "name": "reassign_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -183,23 +304,40 @@ const docTemplate = `{
                     },
                     "204": {
                         "description": "No Content"
+                    },
+                    "409": {
+                        "description": "Category still has products",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/products": {
+        "/categories/{id}/products": {
             "get": {
-                "description": "Get a list of all products or create a new product",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get all products assigned to a category",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "categories"
+                ],
+                "summary": "Get products in a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all products or create a new one",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -210,16 +348,23 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "201": {
-                        "description": "Created",
+                    "404": {
+                        "description": "Category not found",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "type": "string"
                         }
                     }
-                }
-            },
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/credit-payments": {
             "post": {
-                "description": "Get a list of all products or create a new product",
+                "description": "Record a full or partial repayment of a customer's credit (kasbon) balance, received in cash, card, ewallet or qris (default cash). The payment may not exceed what the customer owes. A cash repayment requires the user to have an open shift. Returns the ledger entry with the balance after it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -227,31 +372,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "customers"
                 ],
-                "summary": "Get all products or create a new one",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Record a credit repayment",
+                "parameters": [
+                    {
+                        "description": "Repayment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Product"
-                            }
+                            "$ref": "#/definitions/models.CreditPaymentRequest"
                         }
-                    },
+                    }
+                ],
+                "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.CreditEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid amount or method, or more than the balance",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "type": "string"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/products/{id}": {
+        "/customers": {
             "get": {
-                "description": "Operations on a single product",
+                "description": "GET lists customers by name a page at a time; q matches part of the name, the start of the phone number or the exact member card number. POST registers a customer; phone and member_card are optional but unique, and active defaults to true. A new customer has no credit; a manager sets credit_limit with PUT. points, credit_balance, lifetime_value, visits, last_purchase_at and tier are computed and ignored on input.",
                 "consumes": [
                     "application/json"
                 ],
@@ -259,32 +423,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "customers"
                 ],
-                "summary": "Get, Update, or Delete a product by ID",
+                "summary": "List customers or register a new one",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search in name, phone and member card",
+                        "name": "q",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Customer data (POST)",
+                        "name": "customer",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.CustomerList"
                         }
                     },
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid customer",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Phone or member card already used",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             },
-            "put": {
-                "description": "Operations on a single product",
+            "post": {
+                "description": "GET lists customers by name a page at a time; q matches part of the name, the start of the phone number or the exact member card number. POST registers a customer; phone and member_card are optional but unique, and active defaults to true. A new customer has no credit; a manager sets credit_limit with PUT. points, credit_balance, lifetime_value, visits, last_purchase_at and tier are computed and ignored on input.",
                 "consumes": [
                     "application/json"
                 ],
@@ -292,32 +495,73 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "customers"
                 ],
-                "summary": "Get, Update, or Delete a product by ID",
+                "summary": "List customers or register a new one",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search in name, phone and member card",
+                        "name": "q",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Customer data (POST)",
+                        "name": "customer",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.CustomerList"
                         }
                     },
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid customer",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Phone or member card already used",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
-                }
-            },
-            "delete": {
-                "description": "Operations on a single product",
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/customers/{id}": {
+            "get": {
+                "description": "Operations on a single customer. GET includes the points balance, the credit limit and balance owed (negative is store credit), lifetime_value (net spend after voids and returns), visits, last_purchase_at and the current loyalty tier; the purchase history is GET /transactions?customer_id={id}. Updating (including credit_limit) and deleting are manager only; the credit statement is GET /customers/{id}/credit. A customer with transactions cannot be deleted; set active to false instead.",
                 "consumes": [
                     "application/json"
                 ],
@@ -325,155 +569,6625 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "customers"
                 ],
-                "summary": "Get, Update, or Delete a product by ID",
+                "summary": "Get, Update, or Delete a customer by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Customer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Customer data (PUT)",
+                        "name": "customer",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.Customer"
                         }
                     },
                     "204": {
                         "description": "No Content"
-                    }
-                }
-            }
-        },
-        "/report/hari-ini": {
-            "get": {
-                "description": "Get total revenue, total transactions, and best selling product for today",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "reports"
-                ],
-                "summary": "Get daily sales report",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Customer not found",
                         "schema": {
-                            "$ref": "#/definitions/models.DailyReport"
+                            "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Customer has transactions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Operations on a single customer. GET includes the points balance, the credit limit and balance owed (negative is store credit), lifetime_value (net spend after voids and returns), visits, last_purchase_at and the current loyalty tier; the purchase history is GET /transactions?customer_id={id}. Updating (including credit_limit) and deleting are manager only; the credit statement is GET /customers/{id}/credit. A customer with transactions cannot be deleted; set active to false instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get, Update, or Delete a customer by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Customer data (PUT)",
+                        "name": "customer",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Customer has transactions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Operations on a single customer. GET includes the points balance, the credit limit and balance owed (negative is store credit), lifetime_value (net spend after voids and returns), visits, last_purchase_at and the current loyalty tier; the purchase history is GET /transactions?customer_id={id}. Updating (including credit_limit) and deleting are manager only; the credit statement is GET /customers/{id}/credit. A customer with transactions cannot be deleted; set active to false instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get, Update, or Delete a customer by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Customer data (PUT)",
+                        "name": "customer",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Customer has transactions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/customers/{id}/credit": {
+            "get": {
+                "description": "Get the credit (kasbon) statement of a customer: the ledger entries in the period (charges from credit tenders, repayments, and voids and returns of credit sales) with opening and closing balance, plus the current balance, credit limit, available credit, unpaid charges and aging (0-30, 31-60 and over 60 days). Repayments settle the oldest charges first. Dates are business days; without start_date the statement starts at the first entry, end_date defaults to today.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get a customer's credit statement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD, default today)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreditStatement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid dates",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/customers/{id}/points": {
+            "get": {
+                "description": "GET lists the point ledger of a customer, newest first: points earned and redeemed at checkout, points given back or taken back by voids and returns, and manual adjustments, each with the balance after it. POST (manager) records a manual adjustment with signed points and a required note; the balance cannot go below zero.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "List or adjust loyalty points",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Adjustment (POST)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.PointAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyPointList"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyPointEntry"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "GET lists the point ledger of a customer, newest first: points earned and redeemed at checkout, points given back or taken back by voids and returns, and manual adjustments, each with the balance after it. POST (manager) records a manual adjustment with signed points and a required note; the balance cannot go below zero.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "List or adjust loyalty points",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Adjustment (POST)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.PointAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyPointList"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyPointEntry"
+                        }
+                    },
+                    "404": {
+                        "description": "Customer not found",
                         "schema": {
                             "type": "string"
                         }
                     }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/inventory/low-stock": {
+            "get": {
+                "description": "List products whose stock is below min_stock, most critical first, with the quantity still on open purchase orders and a suggested order quantity (reorder_quantity or the shortfall, whichever is larger, minus what is on order).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "List products below minimum stock",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LowStockItem"
+                            }
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/loyalty-tiers": {
+            "get": {
+                "description": "List loyalty tiers from the lowest min_spend, or create one (manager). A customer is in the tier with the highest min_spend not above their lifetime spend, and bonus_percent adds to the points they earn, e.g. 50 for 1.5x. Names and min_spend values are unique.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get all loyalty tiers or create a new one",
+                "parameters": [
+                    {
+                        "description": "Tier data (POST)",
+                        "name": "tier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LoyaltyTier"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tier",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Name or min_spend already used",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "List loyalty tiers from the lowest min_spend, or create one (manager). A customer is in the tier with the highest min_spend not above their lifetime spend, and bonus_percent adds to the points they earn, e.g. 50 for 1.5x. Names and min_spend values are unique.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get all loyalty tiers or create a new one",
+                "parameters": [
+                    {
+                        "description": "Tier data (POST)",
+                        "name": "tier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.LoyaltyTier"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tier",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Name or min_spend already used",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/loyalty-tiers/{id}": {
+            "get": {
+                "description": "Operations on a single loyalty tier. Deleting a tier moves its customers to the tier below.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get, Update, or Delete a loyalty tier by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tier data (PUT)",
+                        "name": "tier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Tier not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Operations on a single loyalty tier. Deleting a tier moves its customers to the tier below.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get, Update, or Delete a loyalty tier by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tier data (PUT)",
+                        "name": "tier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Tier not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Operations on a single loyalty tier. Deleting a tier moves its customers to the tier below.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "customers"
+                ],
+                "summary": "Get, Update, or Delete a loyalty tier by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tier data (PUT)",
+                        "name": "tier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LoyaltyTier"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Tier not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/modifier-groups": {
+            "get": {
+                "description": "List modifier groups with their modifiers, or create one. A required group needs at least min_select choices (at least 1) on every sale line of a product it is attached to; max_select 0 means no limit. Modifier prices are added to the unit price; active defaults to true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "modifiers"
+                ],
+                "summary": "Get all modifier groups or create a new one",
+                "parameters": [
+                    {
+                        "description": "Modifier group (POST)",
+                        "name": "group",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ModifierGroup"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid modifier group",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "List modifier groups with their modifiers, or create one. A required group needs at least min_select choices (at least 1) on every sale line of a product it is attached to; max_select 0 means no limit. Modifier prices are added to the unit price; active defaults to true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "modifiers"
+                ],
+                "summary": "Get all modifier groups or create a new one",
+                "parameters": [
+                    {
+                        "description": "Modifier group (POST)",
+                        "name": "group",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ModifierGroup"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid modifier group",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/modifier-groups/{id}": {
+            "get": {
+                "description": "Operations on a single modifier group. On PUT, modifiers with an id are updated, modifiers without one are added and modifiers left out are removed; past sales keep the name and price they were sold with. Deleting a group also detaches it from its products.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "modifiers"
+                ],
+                "summary": "Get, Update, or Delete a modifier group by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Modifier group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Modifier group (PUT)",
+                        "name": "group",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Modifier group not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Operations on a single modifier group. On PUT, modifiers with an id are updated, modifiers without one are added and modifiers left out are removed; past sales keep the name and price they were sold with. Deleting a group also detaches it from its products.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "modifiers"
+                ],
+                "summary": "Get, Update, or Delete a modifier group by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Modifier group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Modifier group (PUT)",
+                        "name": "group",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Modifier group not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Operations on a single modifier group. On PUT, modifiers with an id are updated, modifiers without one are added and modifiers left out are removed; past sales keep the name and price they were sold with. Deleting a group also detaches it from its products.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "modifiers"
+                ],
+                "summary": "Get, Update, or Delete a modifier group by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Modifier group ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Modifier group (PUT)",
+                        "name": "group",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierGroup"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Modifier group not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "GET lists top-level products a page at a time; variants are inline in their parent. q searches names by substring, prefix or similar spelling (typos), SKU prefixes and exact barcodes, including those of variants. Filter by category_id, min_price/max_price and in_stock. sort is relevance (default with q), id (default), name, price or stock, with - for descending. Pages are chosen with page or with the cursor from pagination.next_cursor, which stays stable while products change; pagination.links has ready-made URLs.\nPOST creates a product. Stock is kept in the base unit (default pcs); decimals (0-3) sets how many decimal places its quantities may have, e.g. 3 for goods sold per kg. units lists other units with their factor in base units (e.g. carton = 24) and an optional own price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List products or create a new one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search in name, SKU and barcode",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only products in this category",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only products with stock (or a variant with stock)",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "relevance, id, name, price or stock; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductList"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "GET lists top-level products a page at a time; variants are inline in their parent. q searches names by substring, prefix or similar spelling (typos), SKU prefixes and exact barcodes, including those of variants. Filter by category_id, min_price/max_price and in_stock. sort is relevance (default with q), id (default), name, price or stock, with - for descending. Pages are chosen with page or with the cursor from pagination.next_cursor, which stays stable while products change; pagination.links has ready-made URLs.\nPOST creates a product. Stock is kept in the base unit (default pcs); decimals (0-3) sets how many decimal places its quantities may have, e.g. 3 for goods sold per kg. units lists other units with their factor in base units (e.g. carton = 24) and an optional own price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List products or create a new one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search in name, SKU and barcode",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only products in this category",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only products with stock (or a variant with stock)",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "relevance, id, name, price or stock; prefix - for descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductList"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/export": {
+            "get": {
+                "description": "Download all products as CSV or XLSX, each parent followed by its variants, with category, stock in the base unit and barcodes. The columns match the import, so the file can be edited and imported again. Text starting with =, +, - or @ is prefixed with a single quote so spreadsheets do not run it as a formula; the import drops that quote again.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Export the product catalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/import": {
+            "get": {
+                "description": "GET lists the latest import jobs, newest first. POST uploads a CSV or XLSX file (first sheet) and starts a background import job; poll GET /products/import/{id} for progress and row-level errors. The first row holds column titles. Columns are sku, name, category, price, cost, stock, unit, decimals, min_stock, reorder_quantity and barcodes (separated by \";\", optionally \"type:code\"); mapping is a JSON object from these fields to the titles used in the file, e.g. {\"name\": \"Nama Barang\"}. Unmapped fields use the column with the same title; other columns are ignored. A row whose sku matches a product (or variant) updates it, changing only the filled-in cells; other rows create a product. Categories are matched by name and created when missing. With dry_run=true rows are only validated and created_rows/updated_rows count what would happen.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List product imports or start a new one",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file (max 10 MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or xlsx (default from the file name)",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping import fields to column titles",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the rows",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductImportJob"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file, format or mapping",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "GET lists the latest import jobs, newest first. POST uploads a CSV or XLSX file (first sheet) and starts a background import job; poll GET /products/import/{id} for progress and row-level errors. The first row holds column titles. Columns are sku, name, category, price, cost, stock, unit, decimals, min_stock, reorder_quantity and barcodes (separated by \";\", optionally \"type:code\"); mapping is a JSON object from these fields to the titles used in the file, e.g. {\"name\": \"Nama Barang\"}. Unmapped fields use the column with the same title; other columns are ignored. A row whose sku matches a product (or variant) updates it, changing only the filled-in cells; other rows create a product. Categories are matched by name and created when missing. With dry_run=true rows are only validated and created_rows/updated_rows count what would happen.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List product imports or start a new one",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or XLSX file (max 10 MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or xlsx (default from the file name)",
+                        "name": "format",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping import fields to column titles",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the rows",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductImportJob"
+                            }
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductImportJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file, format or mapping",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/import/{id}": {
+            "get": {
+                "description": "Get the status and counters of an import job (pending, running, completed or failed) with all row-level errors so far. Row is the line number in the file, the header being line 1.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get a product import job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductImportJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Import job not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/lookup": {
+            "get": {
+                "description": "Find the product for a scanned barcode or SKU. In-store scale labels (EAN-13 with prefix 20-29: 2-digit prefix, 5-digit PLU, 5-digit value, check digit) are decoded: prefixes 20-24 carry the weight in grams, 25-29 the price, and quantity is derived from it. For products with decimal quantities the weight is read as kg (1250 g = 1.25), otherwise as grams. Other codes return quantity 1.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Look up a product by scanned barcode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scanned barcode or SKU",
+                        "name": "barcode",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BarcodeLookup"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing barcode or invalid check digit",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "No product matches this barcode",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Operations on a single product. Stock is read-only on update: the sent value is ignored and changes go through the stock-movements endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get, Update, or Delete a product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "409": {
+                        "description": "Product already used in transactions or has stock history",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Operations on a single product. Stock is read-only on update: the sent value is ignored and changes go through the stock-movements endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get, Update, or Delete a product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "409": {
+                        "description": "Product already used in transactions or has stock history",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Operations on a single product. Stock is read-only on update: the sent value is ignored and changes go through the stock-movements endpoint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get, Update, or Delete a product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "409": {
+                        "description": "Product already used in transactions or has stock history",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/stock-movements": {
+            "get": {
+                "description": "GET lists the stock ledger of a product, newest first, with the balance after each entry. POST records a manual movement (purchase, adjustment, transfer or waste) with a signed quantity in the product's base unit; a note is required except for purchases. Sales, voids and returns are recorded by their transactions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List or record stock movements of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Movement type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Movement (POST)",
+                        "name": "movement",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovement"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovementList"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movement",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "GET lists the stock ledger of a product, newest first, with the balance after each entry. POST records a manual movement (purchase, adjustment, transfer or waste) with a signed quantity in the product's base unit; a note is required except for purchases. Sales, voids and returns are recorded by their transactions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List or record stock movements of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Movement type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Movement (POST)",
+                        "name": "movement",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovement"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovementList"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid movement",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products/{id}/variants": {
+            "get": {
+                "description": "GET lists the variants of a parent product with their options. POST adds a variant with its own SKU, barcodes, price, cost and stock; options pick one value per option group (e.g. Size = Large). The first variant defines the parent's option groups, later variants must set the same groups with a new combination of values. An empty name becomes \"Parent - Value / Value\", price 0 takes the parent's price, and category and tax rate always follow the parent. The parent must have zero stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List or add variants of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Parent product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant (POST)",
+                        "name": "variant",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Product"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid options or parent",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Variant, SKU or barcode already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "GET lists the variants of a parent product with their options. POST adds a variant with its own SKU, barcodes, price, cost and stock; options pick one value per option group (e.g. Size = Large). The first variant defines the parent's option groups, later variants must set the same groups with a new combination of values. An empty name becomes \"Parent - Value / Value\", price 0 takes the parent's price, and category and tax rate always follow the parent. The parent must have zero stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List or add variants of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Parent product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant (POST)",
+                        "name": "variant",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Product"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid options or parent",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Variant, SKU or barcode already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "List promotions or create one. Types: percentage and fixed (scope cart, product or category), buy_x_get_y (product_id, buy_quantity, get_quantity, optional get_product_id) and bundle (items sold together for value). Optional starts_at/ends_at, days_of_week (0 = Sunday) and start_time/end_time (HH:MM store time) limit when it applies.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get all promotions or create a new one",
+                "parameters": [
+                    {
+                        "description": "Promotion data (POST)",
+                        "name": "promotion",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Promotion"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "List promotions or create one. Types: percentage and fixed (scope cart, product or category), buy_x_get_y (product_id, buy_quantity, get_quantity, optional get_product_id) and bundle (items sold together for value). Optional starts_at/ends_at, days_of_week (0 = Sunday) and start_time/end_time (HH:MM store time) limit when it applies.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get all promotions or create a new one",
+                "parameters": [
+                    {
+                        "description": "Promotion data (POST)",
+                        "name": "promotion",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Promotion"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "description": "Operations on a single promotion. Deleting a promotion keeps its name and discount on past transactions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get, Update, or Delete a promotion by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion data (PUT)",
+                        "name": "promotion",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Operations on a single promotion. Deleting a promotion keeps its name and discount on past transactions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get, Update, or Delete a promotion by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion data (PUT)",
+                        "name": "promotion",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Operations on a single promotion. Deleting a promotion keeps its name and discount on past transactions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get, Update, or Delete a promotion by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion data (PUT)",
+                        "name": "promotion",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/purchase-orders": {
+            "get": {
+                "description": "List purchase orders, newest first, optionally filtered by status and supplier, or create one. Lines need product_id, quantity and unit_cost, and may name one of the product's units (e.g. carton) with unit_cost per that unit; stock only increases, in the base unit, when goods are received.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "List purchase orders or create a new one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open, partial, received or cancelled",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Purchase order (POST)",
+                        "name": "purchaseOrder",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PurchaseOrder"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid purchase order",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "List purchase orders, newest first, optionally filtered by status and supplier, or create one. Lines need product_id, quantity and unit_cost, and may name one of the product's units (e.g. carton) with unit_cost per that unit; stock only increases, in the base unit, when goods are received.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "List purchase orders or create a new one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open, partial, received or cancelled",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Purchase order (POST)",
+                        "name": "purchaseOrder",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PurchaseOrder"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid purchase order",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/purchase-orders/{id}": {
+            "get": {
+                "description": "Get a purchase order with its lines (ordered and received quantity) and every goods receipt recorded against it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Get purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/purchase-orders/{id}/cancel": {
+            "post": {
+                "description": "Stop waiting for the remaining quantities. Goods already received stay in stock.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Cancel a purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is already received or cancelled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/purchase-orders/{id}/receipts": {
+            "post": {
+                "description": "Record goods received against purchase order lines, in each line's unit; partial quantities are allowed. Each line adds stock as a purchase in the stock ledger and updates the product cost with a moving average. unit_cost defaults to the price on the order. The order becomes partial, or received once every line is complete.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase-orders"
+                ],
+                "summary": "Receive goods for a purchase order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Purchase order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Received lines",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GoodsReceipt"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid receipt",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Purchase order not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Purchase order is already received or cancelled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report": {
+            "get": {
+                "description": "Get revenue, transaction count and best seller per day, week or month, plus a summary with the top-N best sellers for the whole range. Dates are business days in the store timezone; period_end is exclusive.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get sales report for a date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD, default start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "day, week or month (default day)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of best sellers in the summary (default 5, max 50)",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count variant sales under their parent product",
+                        "name": "rollup",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SalesReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report/customers": {
+            "get": {
+                "description": "Get net sales of registered customers over a range of business days: the members' share of all sales, and the top customers by net spend (after voids and returns) with visits, average basket, points earned and redeemed in the range, and their lifetime value, first and last purchase and current tier.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get customer value report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD, default start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of customers to return (default 20, max 100)",
+                        "name": "top",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CustomerReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report/hari-ini": {
+            "get": {
+                "description": "Get total revenue, total transactions, best selling products and payment-method breakdown for the current business day in the store timezone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get daily sales report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of best sellers to return (default 5, max 50)",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Count variant sales under their parent product",
+                        "name": "rollup",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DailyReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report/margin": {
+            "get": {
+                "description": "Get net sales (after discounts, without tax), cost and gross profit per product, parent product (variants rolled up), category or business day, net of voids and returns. Cost is the product cost recorded on each sale line. Categories are the products' current categories.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get gross margin report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD, default start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "product (default), parent, category or day",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MarginReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report/modifiers": {
+            "get": {
+                "description": "Get how many units were sold with each modifier and the revenue from modifier prices (before discounts) over a range of business days, net of voids and returns, most chosen first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get modifier report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD, default start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ModifierReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report/promotions": {
+            "get": {
+                "description": "Get how many sales used each promotion and the discount it gave over a range of business days. Voided sales are excluded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get promotion usage report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD, default start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PromotionReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report/purchase-orders/outstanding": {
+            "get": {
+                "description": "List open and partially received purchase order lines with the quantity still to arrive and its value at the ordered cost, earliest expected date first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get outstanding purchase orders report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OutstandingPurchaseReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report/receivables": {
+            "get": {
+                "description": "Get the credit (kasbon) position of all customers as of today: total owed and total store credit, and per customer the balance, credit limit, oldest unpaid charge, last repayment and aging of what is owed in buckets of 0-30, 31-60 and over 60 days. Repayments settle the oldest charges first. Customers owing the most come first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get receivables aging report",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReceivablesReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report/supplier-purchases": {
+            "get": {
+                "description": "Get quantity and value received per supplier and product over a range of business days, by receipt date, with the average unit cost paid.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get supplier purchase history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD, default start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierPurchaseReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/report/tax": {
+            "get": {
+                "description": "Get the taxable amount (DPP) and tax per rate over a range of business days, net of voids and returns, plus the untaxed sales amount. Rates are the ones recorded on each sale.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get tax report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD, default start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/shifts": {
+            "get": {
+                "description": "GET lists cashier shifts, newest first; cashiers only see their own. POST opens a shift for the logged-in user with the starting float in the drawer; a user can have one open shift at a time. While it is open, their sales, voids, returns and credit repayments are linked to it. Taking or refunding cash requires an open shift.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "List shifts or open a new one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open or closed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only shifts of this cashier (managers)",
+                        "name": "cashier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Starting float (POST)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.OpenShiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShiftList"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shift"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid opening float",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "User already has an open shift",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "GET lists cashier shifts, newest first; cashiers only see their own. POST opens a shift for the logged-in user with the starting float in the drawer; a user can have one open shift at a time. While it is open, their sales, voids, returns and credit repayments are linked to it. Taking or refunding cash requires an open shift.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "List shifts or open a new one",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "open or closed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only shifts of this cashier (managers)",
+                        "name": "cashier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Starting float (POST)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.OpenShiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShiftList"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shift"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid opening float",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "User already has an open shift",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/shifts/current": {
+            "get": {
+                "description": "Get the shift the logged-in user has open, with its cash movements.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Get my open shift",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shift"
+                        }
+                    },
+                    "404": {
+                        "description": "No open shift",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/shifts/{id}": {
+            "get": {
+                "description": "Get a shift with its cash movements and, once closed, the cash counted per denomination, expected cash and variance (counted minus expected). Cashiers can only get their own shifts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Get a shift",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shift"
+                        }
+                    },
+                    "404": {
+                        "description": "Shift not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/shifts/{id}/cash-movements": {
+            "post": {
+                "description": "Record petty cash put into (cash_in) or taken out of (cash_out) the drawer of an open shift, with a reason. It counts towards the expected cash.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Record cash in or cash out",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cash movement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CashMovementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CashMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid type, amount or reason",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Shift not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Shift is already closed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/shifts/{id}/close": {
+            "post": {
+                "description": "Close an open shift with the cash in the drawer counted per denomination (e.g. {\"denomination\": 50000, \"count\": 3}); denominations left out count as zero. Expected cash is the starting float plus net cash from the shift's transactions and cash credit repayments plus cash in, minus cash out; variance is counted minus expected. Returns the Z report. The cashier or a manager can close it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Close a shift",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Counted cash",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CloseShiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShiftReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid denominations",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Shift not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Shift is already closed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/shifts/{id}/x-report": {
+            "get": {
+                "description": "Get the running totals of an open shift without closing it: sales, voids and returns, net sales and tax, payments per method, credit repayments, cash in and out and the cash expected in the drawer now. cash_outside_shifts shows net cash recorded without any shift while this shift was open (older data); it is not part of the expected cash.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Get X report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShiftReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Shift not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Shift is already closed, use the Z report",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/shifts/{id}/z-report": {
+            "get": {
+                "description": "Get the final report of a closed shift: the same totals as the X report, with the shift's counted cash per denomination, expected cash and variance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shifts"
+                ],
+                "summary": "Get Z report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShiftReport"
+                        }
+                    },
+                    "404": {
+                        "description": "Shift not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Shift is still open, use the X report",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stock-counts": {
+            "get": {
+                "description": "List stock count (stock opname) sessions, newest first, or open a new one. Only one session can be open at a time. Opening is manager only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "List stock counts or open a new one",
+                "parameters": [
+                    {
+                        "description": "Note (POST)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.OpenStockCountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StockCount"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockCount"
+                        }
+                    },
+                    "409": {
+                        "description": "Another stock count is already open",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "List stock count (stock opname) sessions, newest first, or open a new one. Only one session can be open at a time. Opening is manager only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "List stock counts or open a new one",
+                "parameters": [
+                    {
+                        "description": "Note (POST)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.OpenStockCountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StockCount"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockCount"
+                        }
+                    },
+                    "409": {
+                        "description": "Another stock count is already open",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stock-counts/{id}": {
+            "get": {
+                "description": "Get a stock count session with counted and system quantity, variance and variance value at cost per product. While open this is a preview; system stock is taken from the stock ledger at the time each product was last counted, so sales during the count are not variances.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Get stock count with variances",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockCount"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock count not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stock-counts/{id}/cancel": {
+            "post": {
+                "description": "Close an open session without changing stock. Manager only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Cancel a stock count",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockCount"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock count not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock count is no longer open",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stock-counts/{id}/items": {
+            "put": {
+                "description": "Record counted quantities from one device. An item may name one of the product's units (e.g. carton); it is stored in the base unit. Sending a product again from the same device replaces that device's figure; figures from different devices are added up, e.g. for stock on several shelves.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Record counted quantities",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Device and counted items",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockCountEntry"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockCount"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid items",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock count is no longer open",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/stock-counts/{id}/post": {
+            "post": {
+                "description": "Write every variance as an adjustment in the stock ledger and close the session. Products that were not counted are left unchanged. Manager only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock-counts"
+                ],
+                "summary": "Post a stock count",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock count ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockCount"
+                        }
+                    },
+                    "404": {
+                        "description": "Stock count not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Stock count is no longer open",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "description": "List suppliers or create one. active defaults to true; only active suppliers can receive new purchase orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get all suppliers or create a new one",
+                "parameters": [
+                    {
+                        "description": "Supplier data (POST)",
+                        "name": "supplier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Supplier"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid supplier",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "List suppliers or create one. active defaults to true; only active suppliers can receive new purchase orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get all suppliers or create a new one",
+                "parameters": [
+                    {
+                        "description": "Supplier data (POST)",
+                        "name": "supplier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Supplier"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid supplier",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/suppliers/{id}": {
+            "get": {
+                "description": "Operations on a single supplier. A supplier with purchase orders cannot be deleted; set active to false instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get, Update, or Delete a supplier by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier data (PUT)",
+                        "name": "supplier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier has purchase orders",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Operations on a single supplier. A supplier with purchase orders cannot be deleted; set active to false instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get, Update, or Delete a supplier by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier data (PUT)",
+                        "name": "supplier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier has purchase orders",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Operations on a single supplier. A supplier with purchase orders cannot be deleted; set active to false instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get, Update, or Delete a supplier by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier data (PUT)",
+                        "name": "supplier",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Supplier not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier has purchase orders",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/tax-rates": {
+            "get": {
+                "description": "List tax rates or create one. rate is a percentage (e.g. 11 for PPN 11%); inclusive means product prices already contain the tax. Assign rates to products or categories with tax_rate_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Get all tax rates or create a new one",
+                "parameters": [
+                    {
+                        "description": "Tax rate data (POST)",
+                        "name": "taxRate",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TaxRate"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tax rate",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "List tax rates or create one. rate is a percentage (e.g. 11 for PPN 11%); inclusive means product prices already contain the tax. Assign rates to products or categories with tax_rate_id.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Get all tax rates or create a new one",
+                "parameters": [
+                    {
+                        "description": "Tax rate data (POST)",
+                        "name": "taxRate",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TaxRate"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tax rate",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/tax-rates/{id}": {
+            "get": {
+                "description": "Operations on a single tax rate. Changes only affect new sales; past lines keep the rate they were taxed at. A rate still assigned to products or categories cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Get, Update, or Delete a tax rate by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tax rate data (PUT)",
+                        "name": "taxRate",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Tax rate not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Tax rate still in use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Operations on a single tax rate. Changes only affect new sales; past lines keep the rate they were taxed at. A rate still assigned to products or categories cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Get, Update, or Delete a tax rate by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tax rate data (PUT)",
+                        "name": "taxRate",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Tax rate not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Tax rate still in use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Operations on a single tax rate. Changes only affect new sales; past lines keep the rate they were taxed at. A rate still assigned to products or categories cannot be deleted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Get, Update, or Delete a tax rate by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tax rate data (PUT)",
+                        "name": "taxRate",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TaxRate"
+                        }
+                    },
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Tax rate not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Tax rate still in use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/transactions": {
+            "get": {
+                "description": "List transaction headers, newest first, with pagination and filters. Dates are inclusive business days in the store timezone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "List transactions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, inclusive (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only transactions containing this product",
+                        "name": "product_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only transactions of this customer (purchase history)",
+                        "name": "customer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only transactions of this cashier shift",
+                        "name": "shift_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sale, void or return",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum total",
+                        "name": "min_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum total",
+                        "name": "max_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TransactionList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a new transaction with details and payments. Subtotals and total are priced server-side from products and stock is deducted. Quantities may be decimal (e.g. 0.75 kg); a line may name one of the product's units (e.g. carton), priced at that unit's price or factor times the base price, and stock is deducted in the base unit. Payments (cash, card, ewallet, qris, points, credit; split allowed) must cover the total; for cash send the amount handed over and the change is returned. A cash tender requires the cashier to have an open shift. With customer_id the customer earns loyalty points on the part of the total not paid with points, plus their tier bonus; a points payment gives the rupiah amount, a multiple of the value of one point, and needs enough points. A credit payment puts that amount on the customer's credit (kasbon) account, within their credit limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Create a new transaction",
+                "parameters": [
+                    {
+                        "description": "Transaction Data",
+                        "name": "transaction",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.InsufficientStockError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "description": "Get a transaction header with its line items, including product name and unit price at time of sale",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get transaction by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/transactions/{id}/returns": {
+            "post": {
+                "description": "Return quantities of individual line items, in the unit each line was sold in. Creates a linked negative \"return\" transaction valued pro rata to the original line and restocks the items. For a customer sale, points paid with are given back first, then the part paid on credit is taken off the customer's credit balance, and earned points are taken back pro rata. Manager or owner only; the caller is recorded as approver. The document goes to the sale's cashier shift while it is open, otherwise to the manager's open shift, which a cash refund then requires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Return items from a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Returned line items",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReturnRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction already voided",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/transactions/{id}/void": {
+            "post": {
+                "description": "Void everything not yet returned from a sale made on the current business day whose cashier shift is not closed yet; otherwise use a return. Creates a linked negative \"void\" transaction and restocks the items. For a customer sale, points paid with are given back first, then the part paid on credit is taken off the customer's credit balance, the rest is refunded in refund_method, and the points earned are taken back. Manager or owner only; the caller is recorded as approver. The document goes to the sale's cashier shift while it is open, otherwise to the manager's open shift, which a cash refund then requires.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Void a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Void reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VoidRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Transaction not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Transaction already voided",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "List users or create a user with a role (owner, manager, cashier). Owner only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get all users or create a new one",
+                "parameters": [
+                    {
+                        "description": "User data (POST)",
+                        "name": "user",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "List users or create a user with a role (owner, manager, cashier). Owner only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get all users or create a new one",
+                "parameters": [
+                    {
+                        "description": "User data (POST)",
+                        "name": "user",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get a user, or update name, role, password or active flag. An empty password keeps the current one. Owner only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get or update a user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User data (PUT)",
+                        "name": "user",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Get a user, or update name, role, password or active flag. An empty password keeps the current one. Owner only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get or update a user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User data (PUT)",
+                        "name": "user",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.UserInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "models.AppliedPromotion": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BarcodeLookup": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/models.Product"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BestSellingProduct": {
+            "type": "object",
+            "properties": {
+                "nama": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "qty_terjual": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CashDenomination": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "denomination": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CashMovement": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "shift_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CashMovementRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CloseShiftRequest": {
+            "type": "object",
+            "properties": {
+                "denominations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CashDenomination"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreditAging": {
+            "type": "object",
+            "properties": {
+                "days_0_30": {
+                    "type": "integer"
+                },
+                "days_31_60": {
+                    "type": "integer"
+                },
+                "days_over_60": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreditEntry": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "balance_after": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "shift_id": {
+                    "type": "integer"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreditPaymentRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreditStatement": {
+            "type": "object",
+            "properties": {
+                "aging": {
+                    "$ref": "#/definitions/models.CreditAging"
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "charges": {
+                    "type": "integer"
+                },
+                "closing_balance": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "type": "integer"
+                },
+                "credits": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CreditEntry"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "open_charges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OpenCharge"
+                    }
+                },
+                "opening_balance": {
+                    "type": "integer"
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Customer": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credit_balance": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "type": "integer"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_purchase_at": {
+                    "type": "string"
+                },
+                "lifetime_value": {
+                    "type": "integer"
+                },
+                "member_card": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "tier": {
+                    "$ref": "#/definitions/models.LoyaltyTier"
+                },
+                "visits": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CustomerList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Customer"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                }
+            }
+        },
+        "models.CustomerReceivable": {
+            "type": "object",
+            "properties": {
+                "aging": {
+                    "$ref": "#/definitions/models.CreditAging"
+                },
+                "balance": {
+                    "type": "integer"
+                },
+                "credit_limit": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "last_payment_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "oldest_charge_at": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CustomerReport": {
+            "type": "object",
+            "properties": {
+                "active_customers": {
+                    "type": "integer"
+                },
+                "customers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CustomerValue"
+                    }
+                },
+                "member_sales": {
+                    "type": "integer"
+                },
+                "member_transactions": {
+                    "type": "integer"
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "total_sales": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CustomerValue": {
+            "type": "object",
+            "properties": {
+                "average_basket": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "first_purchase_at": {
+                    "type": "string"
+                },
+                "last_purchase_at": {
+                    "type": "string"
+                },
+                "lifetime_value": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "points_earned": {
+                    "type": "integer"
+                },
+                "points_redeemed": {
+                    "type": "integer"
+                },
+                "spend": {
+                    "type": "integer"
+                },
+                "tier": {
+                    "type": "string"
+                },
+                "transactions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DailyReport": {
+            "type": "object",
+            "properties": {
+                "gross_profit": {
+                    "type": "integer"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentSummary"
+                    }
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "produk_terlaris": {
+                    "$ref": "#/definitions/models.BestSellingProduct"
+                },
+                "top_products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BestSellingProduct"
+                    }
+                },
+                "total_cost": {
+                    "type": "integer"
+                },
+                "total_revenue": {
+                    "type": "integer"
+                },
+                "total_transaksi": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DetailModifier": {
+            "type": "object",
+            "properties": {
+                "group_name": {
+                    "type": "string"
+                },
+                "modifier_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GoodsReceipt": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GoodsReceiptLine"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "purchase_order_id": {
+                    "type": "integer"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "received_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GoodsReceiptLine": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "purchase_order_line_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit_cost": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.InsufficientStockError": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InsufficientStockItem"
+                    }
+                }
+            }
+        },
+        "models.InsufficientStockItem": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "requested": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "models.LowStockItem": {
+            "type": "object",
+            "properties": {
+                "min_stock": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "on_order": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "reorder_quantity": {
+                    "type": "number"
+                },
+                "stock": {
+                    "type": "number"
+                },
+                "suggested_order": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.LoyaltyPointEntry": {
+            "type": "object",
+            "properties": {
+                "balance_after": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LoyaltyPointList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LoyaltyPointEntry"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                }
+            }
+        },
+        "models.LoyaltyTier": {
+            "type": "object",
+            "properties": {
+                "bonus_percent": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MarginLine": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "gross_profit": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "margin_percent": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "net_sales": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.MarginReport": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "integer"
+                },
+                "gross_profit": {
+                    "type": "integer"
+                },
+                "group_by": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MarginLine"
+                    }
+                },
+                "margin_percent": {
+                    "type": "number"
+                },
+                "net_sales": {
+                    "type": "integer"
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Modifier": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ModifierGroup": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "max_select": {
+                    "type": "integer"
+                },
+                "min_select": {
+                    "type": "integer"
+                },
+                "modifiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Modifier"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ModifierReport": {
+            "type": "object",
+            "properties": {
+                "modifiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ModifierUsage"
+                    }
+                },
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ModifierUsage": {
+            "type": "object",
+            "properties": {
+                "group_name": {
+                    "type": "string"
+                },
+                "modifier_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OpenCharge": {
+            "type": "object",
+            "properties": {
+                "age_days": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "charged_at": {
+                    "type": "string"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OpenShiftRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "opening_float": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OpenStockCountRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OutstandingPurchaseLine": {
+            "type": "object",
+            "properties": {
+                "expected_date": {
+                    "type": "string"
+                },
+                "order_date": {
+                    "type": "string"
+                },
+                "ordered": {
+                    "type": "number"
+                },
+                "outstanding": {
+                    "type": "number"
+                },
+                "outstanding_value": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "purchase_order_id": {
+                    "type": "integer"
+                },
+                "received": {
+                    "type": "number"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "supplier_name": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OutstandingPurchaseReport": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OutstandingPurchaseLine"
+                    }
+                },
+                "total_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Pagination": {
+            "type": "object",
+            "properties": {
+                "links": {
+                    "$ref": "#/definitions/models.PaginationLinks"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PaginationLinks": {
+            "type": "object",
+            "properties": {
+                "first": {
+                    "type": "string"
+                },
+                "last": {
+                    "type": "string"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "prev": {
+                    "type": "string"
+                },
+                "self": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "change": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "tendered": {
+                    "type": "integer"
+                },
+                "transaction_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PaymentSummary": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PointAdjustmentRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Product": {
+            "type": "object",
+            "properties": {
+                "barcodes": {
+                    "description": "Barcodes pada PUT: nil (field tidak dikirim) berarti tidak diubah,\n[] menghapus semua barcode.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductBarcode"
+                    }
+                },
+                "category": {
+                    "$ref": "#/definitions/models.Category"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "type": "integer"
+                },
+                "decimals": {
+                    "type": "integer"
+                },
+                "has_variants": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "min_stock": {
+                    "type": "number"
+                },
+                "modifier_group_ids": {
+                    "description": "ModifierGroupIDs adalah grup modifier yang dipasang langsung pada produk;\npada PUT, nil berarti tidak diubah. ModifierGroups berisi grup yang\nberlaku, termasuk grup milik produk induk.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "modifier_groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ModifierGroup"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "option_groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductOptionGroup"
+                    }
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VariantOption"
+                    }
+                },
+                "parent_id": {
+                    "description": "Varian adalah produk dengan ParentID dan Options. Produk induk punya\nHasVariants, OptionGroups dan Variants, dan tidak bisa dijual langsung.",
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "reorder_quantity": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "number"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "description": "Unit adalah satuan dasar stok dan harga (default pcs); Decimals adalah\njumlah desimal qty yang diizinkan, mis. 3 untuk barang timbang per kg.\nUnits adalah satuan lain dengan isinya; pada PUT, nil berarti tidak\ndiubah.",
+                    "type": "string"
+                },
+                "units": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductUnit"
+                    }
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                }
+            }
+        },
+        "models.ProductBarcode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductImportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "created_rows": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowError"
+                    }
+                },
+                "failed_rows": {
+                    "type": "integer"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                },
+                "updated_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProductList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                }
+            }
+        },
+        "models.ProductOptionGroup": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductOptionValue"
+                    }
+                }
+            }
+        },
+        "models.ProductOptionValue": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductUnit": {
+            "type": "object",
+            "properties": {
+                "factor": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Promotion": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "buy_quantity": {
+                    "type": "integer"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "days_of_week": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "get_product_id": {
+                    "type": "integer"
+                },
+                "get_quantity": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PromotionItem"
+                    }
+                },
+                "min_subtotal": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PromotionItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PromotionReport": {
+            "type": "object",
+            "properties": {
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PromotionUsage"
+                    }
+                },
+                "total_discount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PromotionUsage": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "type": "integer"
+                },
+                "total_discount": {
+                    "type": "integer"
+                },
+                "transaction_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "created_by": {
+                    "type": "integer"
+                },
+                "expected_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseOrderLine"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "order_date": {
+                    "type": "string"
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GoodsReceipt"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "supplier_name": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PurchaseOrderLine": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "received_quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "unit_cost": {
+                    "type": "integer"
+                },
+                "unit_factor": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ReceivablesReport": {
+            "type": "object",
+            "properties": {
+                "aging": {
+                    "$ref": "#/definitions/models.CreditAging"
+                },
+                "as_of": {
+                    "type": "string"
+                },
+                "customers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CustomerReceivable"
+                    }
+                },
+                "store_credit": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ReturnItem": {
+            "type": "object",
+            "properties": {
+                "detail_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ReturnRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReturnItem"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "refund_method": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SalesReport": {
+            "type": "object",
+            "properties": {
+                "group_by": {
+                    "type": "string"
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DailyReport"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/models.DailyReport"
+                }
+            }
+        },
+        "models.Shift": {
+            "type": "object",
+            "properties": {
+                "cash_movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CashMovement"
+                    }
+                },
+                "cashier_id": {
+                    "type": "integer"
+                },
+                "cashier_name": {
+                    "type": "string"
+                },
+                "close_note": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "integer"
+                },
+                "counted_cash": {
+                    "type": "integer"
+                },
+                "denominations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CashDenomination"
+                    }
+                },
+                "expected_cash": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "opening_float": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "variance": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ShiftList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Shift"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                }
+            }
+        },
+        "models.ShiftReport": {
+            "type": "object",
+            "properties": {
+                "cash_in": {
+                    "type": "integer"
+                },
+                "cash_out": {
+                    "type": "integer"
+                },
+                "cash_outside_shifts": {
+                    "description": "CashOutsideShifts adalah tunai bersih dari transaksi dan pembayaran\nkasbon tanpa shift yang dicatat selama shift ini open. Sejak tender\ntunai wajib shift nilainya hanya berasal dari data lama; tidak termasuk\nExpectedCash.",
+                    "type": "integer"
+                },
+                "cash_repayments": {
+                    "type": "integer"
+                },
+                "cash_sales": {
+                    "type": "integer"
+                },
+                "credit_repayments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentSummary"
+                    }
+                },
+                "expected_cash": {
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "net_sales": {
+                    "type": "integer"
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PaymentSummary"
+                    }
+                },
+                "return_amount": {
+                    "type": "integer"
+                },
+                "returns": {
+                    "type": "integer"
+                },
+                "sales": {
+                    "type": "integer"
+                },
+                "sales_amount": {
+                    "type": "integer"
+                },
+                "shift": {
+                    "$ref": "#/definitions/models.Shift"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "void_amount": {
+                    "type": "integer"
+                },
+                "voids": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.StockCount": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockCountLine"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "opened_at": {
+                    "type": "string"
+                },
+                "opened_by": {
+                    "type": "integer"
+                },
+                "shortage_value": {
+                    "description": "Nilai selisih pada harga pokok: kurang (negatif), lebih, dan bersihnya.",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "surplus_value": {
+                    "type": "integer"
+                },
+                "variance_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.StockCountEntry": {
+            "type": "object",
+            "properties": {
+                "device": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockCountItem"
+                    }
+                }
+            }
+        },
+        "models.StockCountItem": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.StockCountLine": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "integer"
+                },
+                "counted_quantity": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "system_quantity": {
+                    "type": "number"
+                },
+                "variance": {
+                    "type": "number"
+                },
+                "variance_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.StockMovement": {
+            "type": "object",
+            "properties": {
+                "balance_after": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "reference_id": {
+                    "type": "integer"
+                },
+                "reference_type": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "/transactions": {
-            "post": {
-                "description": "Create a new transaction with details",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "transactions"
-                ],
-                "summary": "Create a new transaction",
-                "parameters": [
-                    {
-                        "description": "Transaction Data",
-                        "name": "transaction",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Transaction"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "string"
-                        }
+        "models.StockMovementList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockMovement"
                     }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.BestSellingProduct": {
+        },
+        "models.Supplier": {
             "type": "object",
             "properties": {
-                "nama": {
+                "active": {
+                    "type": "boolean"
+                },
+                "address": {
                     "type": "string"
                 },
-                "qty_terjual": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
                 }
             }
         },
-        "models.Category": {
+        "models.SupplierProductPurchase": {
             "type": "object",
             "properties": {
-                "id": {
+                "average_cost": {
                     "type": "integer"
                 },
-                "name": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_name": {
                     "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "value": {
+                    "type": "integer"
                 }
             }
         },
-        "models.DailyReport": {
+        "models.SupplierPurchaseReport": {
             "type": "object",
             "properties": {
-                "produk_terlaris": {
-                    "$ref": "#/definitions/models.BestSellingProduct"
+                "period_end": {
+                    "type": "string"
                 },
-                "total_revenue": {
+                "period_start": {
+                    "type": "string"
+                },
+                "suppliers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SupplierPurchaseSummary"
+                    }
+                },
+                "total_value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SupplierPurchaseSummary": {
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SupplierProductPurchase"
+                    }
+                },
+                "receipt_count": {
                     "type": "integer"
                 },
-                "total_transaksi": {
+                "supplier_id": {
+                    "type": "integer"
+                },
+                "supplier_name": {
+                    "type": "string"
+                },
+                "total_value": {
                     "type": "integer"
                 }
             }
         },
-        "models.Product": {
+        "models.TaxRate": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "integer"
                 },
+                "inclusive": {
+                    "type": "boolean"
+                },
                 "name": {
                     "type": "string"
                 },
-                "price": {
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.TaxReport": {
+            "type": "object",
+            "properties": {
+                "non_taxable_amount": {
                     "type": "integer"
                 },
-                "stock": {
+                "period_end": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TaxSummary"
+                    }
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "taxable_amount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TaxSummary": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "tax": {
+                    "type": "integer"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                },
+                "taxable_amount": {
                     "type": "integer"
                 }
             }
@@ -481,6 +7195,18 @@ const docTemplate = `{
         "models.Transaction": {
             "type": "object",
             "properties": {
+                "approved_by": {
+                    "type": "string"
+                },
+                "cashier_id": {
+                    "type": "integer"
+                },
+                "change": {
+                    "type": "integer"
+                },
+                "customer_id": {
+                    "type": "integer"
+                },
                 "date": {
                     "type": "string"
                 },
@@ -490,33 +7216,211 @@ const docTemplate = `{
                         "$ref": "#/definitions/models.TransactionDetail"
                     }
                 },
+                "discount": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Payment"
+                    }
+                },
+                "points_earned": {
+                    "description": "PointsEarned dan PointsRedeemed adalah poin loyalty yang didapat dan\ndipakai pelanggan, diisi server. Pada void/retur keduanya negatif:\npoin yang ditarik kembali dan poin yang dikembalikan.",
+                    "type": "integer"
+                },
+                "points_redeemed": {
+                    "type": "integer"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppliedPromotion"
+                    }
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reference_id": {
+                    "type": "integer"
+                },
+                "shift_id": {
+                    "type": "integer"
+                },
+                "tax": {
+                    "type": "integer"
+                },
                 "total": {
                     "type": "integer"
+                },
+                "type": {
+                    "type": "string"
                 }
             }
         },
         "models.TransactionDetail": {
             "type": "object",
             "properties": {
+                "discount": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "modifiers": {
+                    "description": "Modifiers dipilih per baris dan berlaku untuk setiap unit; harganya\nsudah termasuk dalam UnitPrice.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DetailModifier"
+                    }
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VariantOption"
+                    }
+                },
+                "parent_product_id": {
+                    "type": "integer"
+                },
                 "product_id": {
                     "type": "integer"
                 },
+                "product_name": {
+                    "type": "string"
+                },
                 "quantity": {
+                    "type": "number"
+                },
+                "reference_detail_id": {
                     "type": "integer"
                 },
                 "subtotal": {
                     "type": "integer"
                 },
+                "tax": {
+                    "type": "integer"
+                },
+                "tax_inclusive": {
+                    "type": "boolean"
+                },
+                "tax_name": {
+                    "type": "string"
+                },
+                "tax_rate": {
+                    "type": "number"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                },
                 "transaction_id": {
                     "type": "integer"
+                },
+                "unit": {
+                    "description": "Unit adalah satuan baris (kosong di checkout berarti satuan dasar);\nUnitPrice dan UnitCost per Unit, UnitFactor isi satuan dasar per Unit.",
+                    "type": "string"
+                },
+                "unit_factor": {
+                    "type": "number"
+                },
+                "unit_price": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "description": "VariantID, atau Options bersama product_id induk, memilih varian saat\ncheckout. Setelah itu ProductID berisi varian dan keduanya dikosongkan.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TransactionList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Transaction"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/models.Pagination"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
+        },
+        "models.UserInput": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.VariantOption": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.VoidRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "refund_method": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Type \"Bearer\" followed by a space and the token from /auth/login.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
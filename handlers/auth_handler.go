@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strings"
+)
+
+type contextKey string
+
+const userContextKey contextKey = "user"
+
+// RoleRules memetakan method HTTP ke role yang boleh mengakses route.
+// Key "*" berlaku untuk method yang tidak disebut secara eksplisit.
+type RoleRules map[string][]string
+
+type AuthHandler struct {
+	service *services.AuthService
+}
+
+func NewAuthHandler(service *services.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// HandleLogin authenticates a user
+// @Summary Log in
+// @Description Exchange username and password for a bearer token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body models.LoginRequest true "Credentials"
+// @Success 200 {object} models.LoginResponse
+// @Failure 401 {string} string "Invalid username or password"
+// @Router /auth/login [post]
+func (h *AuthHandler) HandleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req models.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.service.Login(req)
+	if errors.Is(err, models.ErrInvalidCredentials) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleMe returns the current user
+// @Summary Get current user
+// @Description Get the user the bearer token belongs to
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.User
+// @Failure 401 {string} string "Authentication required"
+// @Router /auth/me [get]
+func (h *AuthHandler) HandleMe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(currentUser(r))
+}
+
+// Protect membungkus handler: request harus membawa bearer token yang valid
+// (401) dan role user harus diizinkan untuk method tersebut (403).
+func (h *AuthHandler) Protect(rules RoleRules, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, models.ErrUnauthorized.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		user, err := h.service.Authenticate(token)
+		if errors.Is(err, models.ErrUnauthorized) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		roles, ok := rules[r.Method]
+		if !ok {
+			roles = rules["*"]
+		}
+		if !hasRole(roles, user.Role) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), userContextKey, user)))
+	}
+}
+
+// currentUser mengembalikan user yang sudah diautentikasi oleh Protect.
+func currentUser(r *http.Request) *models.User {
+	user, _ := r.Context().Value(userContextKey).(*models.User)
+	return user
+}
+
+func hasRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
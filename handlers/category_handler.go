@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"go-kasir-api/models"
 	"go-kasir-api/services"
 	"net/http"
@@ -21,6 +22,7 @@ func NewCategoryHandler(service *services.CategoryService) *CategoryHandler {
 // @Summary Get all categories or create a new one
 // @Description Get a list of all categories or create a new category
 // @Tags categories
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Success 200 {array} models.Category
@@ -40,17 +42,25 @@ func (h *CategoryHandler) HandleCategories(w http.ResponseWriter, r *http.Reques
 
 // HandleCategoryByID handles get, update, and delete operations
 // @Summary Get, Update, or Delete a category by ID
-// @Description Operations on a single category
+// @Description Operations on a single category. Deleting a category that still has products is rejected with 409 unless reassign_to names another category to move them to.
 // @Tags categories
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path int true "Category ID"
+// @Param reassign_to query int false "Move products to this category before deleting"
 // @Success 200 {object} models.Category
 // @Success 204 "No Content"
+// @Failure 409 {string} string "Category still has products"
 // @Router /categories/{id} [get]
 // @Router /categories/{id} [put]
 // @Router /categories/{id} [delete]
 func (h *CategoryHandler) HandleCategoryByID(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, "/products") {
+		h.HandleCategoryProducts(w, r)
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		h.GetByID(w, r)
@@ -63,6 +73,43 @@ func (h *CategoryHandler) HandleCategoryByID(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// HandleCategoryProducts lists the products of a category
+// @Summary Get products in a category
+// @Description Get all products assigned to a category
+// @Tags categories
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {array} models.Product
+// @Failure 404 {string} string "Category not found"
+// @Router /categories/{id}/products [get]
+func (h *CategoryHandler) HandleCategoryProducts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	idStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/categories/"), "/products")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid Category ID", http.StatusBadRequest)
+		return
+	}
+
+	products, err := h.service.GetProducts(id)
+	if errors.Is(err, models.ErrCategoryNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(products)
+}
+
 func (h *CategoryHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	categories, err := h.service.GetAll()
 	if err != nil {
@@ -141,8 +188,28 @@ func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.service.Delete(id); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	var reassignTo *int
+	if v := r.URL.Query().Get("reassign_to"); v != "" {
+		target, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "Invalid reassign_to", http.StatusBadRequest)
+			return
+		}
+		reassignTo = &target
+	}
+
+	if err := h.service.Delete(id, reassignTo); err != nil {
+		var validationErr *models.ValidationError
+		switch {
+		case errors.Is(err, models.ErrCategoryNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, models.ErrCategoryInUse):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.As(err, &validationErr):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+)
+
+type CreditHandler struct {
+	service *services.CreditService
+}
+
+func NewCreditHandler(service *services.CreditService) *CreditHandler {
+	return &CreditHandler{service: service}
+}
+
+// HandleCreditPayments - POST /api/credit-payments
+// @Summary Record a credit repayment
+// @Description Record a full or partial repayment of a customer's credit (kasbon) balance, received in cash, card, ewallet or qris (default cash). The payment may not exceed what the customer owes. A cash repayment requires the user to have an open shift. Returns the ledger entry with the balance after it.
+// @Tags customers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body models.CreditPaymentRequest true "Repayment"
+// @Success 201 {object} models.CreditEntry
+// @Failure 400 {string} string "Invalid amount or method, or more than the balance"
+// @Failure 404 {string} string "Customer not found"
+// @Router /credit-payments [post]
+func (h *CreditHandler) HandleCreditPayments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req models.CreditPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.service.Pay(req, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
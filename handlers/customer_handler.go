@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type CustomerHandler struct {
+	service *services.CustomerService
+	credit  *services.CreditService
+}
+
+func NewCustomerHandler(service *services.CustomerService, credit *services.CreditService) *CustomerHandler {
+	return &CustomerHandler{service: service, credit: credit}
+}
+
+// HandleCustomers - GET/POST /api/customers
+// @Summary List customers or register a new one
+// @Description GET lists customers by name a page at a time; q matches part of the name, the start of the phone number or the exact member card number. POST registers a customer; phone and member_card are optional but unique, and active defaults to true. A new customer has no credit; a manager sets credit_limit with PUT. points, credit_balance, lifetime_value, visits, last_purchase_at and tier are computed and ignored on input.
+// @Tags customers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param q query string false "Search in name, phone and member card"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Param customer body models.Customer false "Customer data (POST)"
+// @Success 200 {object} models.CustomerList
+// @Success 201 {object} models.Customer
+// @Failure 400 {string} string "Invalid customer"
+// @Failure 409 {string} string "Phone or member card already used"
+// @Router /customers [get]
+// @Router /customers [post]
+func (h *CustomerHandler) HandleCustomers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.List(w, r)
+	case http.MethodPost:
+		h.Create(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *CustomerHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.CustomerFilter{Query: q.Get("q")}
+	page, err := queryInt(q, "page")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	pageSize, err := queryInt(q, "page_size")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter.Page, filter.PageSize = intOrZero(page), intOrZero(pageSize)
+
+	list, err := h.service.List(filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	paginationLinks(r.URL, &list.Pagination)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+func (h *CustomerHandler) Create(w http.ResponseWriter, r *http.Request) {
+	customer := models.Customer{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&customer); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Create(&customer); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(customer)
+}
+
+// HandleCustomerByID - GET/PUT/DELETE /api/customers/{id}
+// @Summary Get, Update, or Delete a customer by ID
+// @Description Operations on a single customer. GET includes the points balance, the credit limit and balance owed (negative is store credit), lifetime_value (net spend after voids and returns), visits, last_purchase_at and the current loyalty tier; the purchase history is GET /transactions?customer_id={id}. Updating (including credit_limit) and deleting are manager only; the credit statement is GET /customers/{id}/credit. A customer with transactions cannot be deleted; set active to false instead.
+// @Tags customers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param customer body models.Customer false "Customer data (PUT)"
+// @Success 200 {object} models.Customer
+// @Success 204 "No Content"
+// @Failure 404 {string} string "Customer not found"
+// @Failure 409 {string} string "Customer has transactions"
+// @Router /customers/{id} [get]
+// @Router /customers/{id} [put]
+// @Router /customers/{id} [delete]
+func (h *CustomerHandler) HandleCustomerByID(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/customers/"), "/")
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		http.Error(w, "Invalid customer ID", http.StatusBadRequest)
+		return
+	}
+
+	action := ""
+	if len(parts) > 1 {
+		action = strings.Join(parts[1:], "/")
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		h.GetByID(w, r, id)
+	case action == "" && r.Method == http.MethodPut:
+		h.Update(w, r, id)
+	case action == "" && r.Method == http.MethodDelete:
+		h.Delete(w, r, id)
+	case action == "points" && r.Method == http.MethodGet:
+		h.ListPoints(w, r, id)
+	case action == "points" && r.Method == http.MethodPost:
+		h.AdjustPoints(w, r, id)
+	case action == "credit" && r.Method == http.MethodGet:
+		h.Statement(w, r, id)
+	case action == "" || action == "points" || action == "credit":
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *CustomerHandler) GetByID(w http.ResponseWriter, r *http.Request, id int) {
+	customer, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(customer)
+}
+
+func (h *CustomerHandler) Update(w http.ResponseWriter, r *http.Request, id int) {
+	customer := models.Customer{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&customer); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	customer.ID = id
+	updated, err := h.service.Update(&customer)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+func (h *CustomerHandler) Delete(w http.ResponseWriter, r *http.Request, id int) {
+	if err := h.service.Delete(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListPoints lists the loyalty point ledger of a customer
+// @Summary List or adjust loyalty points
+// @Description GET lists the point ledger of a customer, newest first: points earned and redeemed at checkout, points given back or taken back by voids and returns, and manual adjustments, each with the balance after it. POST (manager) records a manual adjustment with signed points and a required note; the balance cannot go below zero.
+// @Tags customers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Param request body models.PointAdjustmentRequest false "Adjustment (POST)"
+// @Success 200 {object} models.LoyaltyPointList
+// @Success 201 {object} models.LoyaltyPointEntry
+// @Failure 404 {string} string "Customer not found"
+// @Router /customers/{id}/points [get]
+// @Router /customers/{id}/points [post]
+func (h *CustomerHandler) ListPoints(w http.ResponseWriter, r *http.Request, id int) {
+	q := r.URL.Query()
+	page, err := queryInt(q, "page")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	pageSize, err := queryInt(q, "page_size")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.service.Points(id, intOrZero(page), intOrZero(pageSize))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+func (h *CustomerHandler) AdjustPoints(w http.ResponseWriter, r *http.Request, id int) {
+	var req models.PointAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.service.AdjustPoints(id, req, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+// Statement returns the credit statement of a customer
+// @Summary Get a customer's credit statement
+// @Description Get the credit (kasbon) statement of a customer: the ledger entries in the period (charges from credit tenders, repayments, and voids and returns of credit sales) with opening and closing balance, plus the current balance, credit limit, available credit, unpaid charges and aging (0-30, 31-60 and over 60 days). Repayments settle the oldest charges first. Dates are business days; without start_date the statement starts at the first entry, end_date defaults to today.
+// @Tags customers
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param start_date query string false "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD, default today)"
+// @Success 200 {object} models.CreditStatement
+// @Failure 400 {string} string "Invalid dates"
+// @Failure 404 {string} string "Customer not found"
+// @Router /customers/{id}/credit [get]
+func (h *CustomerHandler) Statement(w http.ResponseWriter, r *http.Request, id int) {
+	q := r.URL.Query()
+	from, err := queryDate(q, "start_date")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := queryDate(q, "end_date")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	statement, err := h.credit.Statement(id, from, to, time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(statement)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"go-kasir-api/models"
+	"net/http"
+)
+
+var notFoundErrors = []error{
+	models.ErrProductNotFound,
+	models.ErrBarcodeNotFound,
+	models.ErrCategoryNotFound,
+	models.ErrTransactionNotFound,
+	models.ErrUserNotFound,
+	models.ErrPromotionNotFound,
+	models.ErrModifierGroupNotFound,
+	models.ErrTaxRateNotFound,
+	models.ErrStockCountNotFound,
+	models.ErrSupplierNotFound,
+	models.ErrPurchaseOrderNotFound,
+	models.ErrImportJobNotFound,
+	models.ErrCustomerNotFound,
+	models.ErrLoyaltyTierNotFound,
+	models.ErrShiftNotFound,
+}
+
+var conflictErrors = []error{
+	models.ErrProductInUse,
+	models.ErrSKUTaken,
+	models.ErrBarcodeTaken,
+	models.ErrVariantExists,
+	models.ErrCategoryInUse,
+	models.ErrTransactionVoided,
+	models.ErrUsernameTaken,
+	models.ErrTaxRateInUse,
+	models.ErrStockCountOpen,
+	models.ErrStockCountClosed,
+	models.ErrSupplierInUse,
+	models.ErrPurchaseOrderClosed,
+	models.ErrCustomerTaken,
+	models.ErrCustomerInUse,
+	models.ErrLoyaltyTierTaken,
+	models.ErrShiftAlreadyOpen,
+	models.ErrShiftOpen,
+	models.ErrShiftClosed,
+}
+
+// writeError memetakan error dari service ke status HTTP yang sesuai.
+func writeError(w http.ResponseWriter, err error) {
+	var stockErr *models.InsufficientStockError
+	if errors.As(err, &stockErr) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":  "error",
+			"message": "Insufficient stock",
+			"items":   stockErr.Items,
+		})
+		return
+	}
+
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, target := range notFoundErrors {
+		if errors.Is(err, target) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	}
+	for _, target := range conflictErrors {
+		if errors.Is(err, target) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+	}
+
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type LoyaltyTierHandler struct {
+	service *services.LoyaltyTierService
+}
+
+func NewLoyaltyTierHandler(service *services.LoyaltyTierService) *LoyaltyTierHandler {
+	return &LoyaltyTierHandler{service: service}
+}
+
+// HandleLoyaltyTiers handles list and create operations
+// @Summary Get all loyalty tiers or create a new one
+// @Description List loyalty tiers from the lowest min_spend, or create one (manager). A customer is in the tier with the highest min_spend not above their lifetime spend, and bonus_percent adds to the points they earn, e.g. 50 for 1.5x. Names and min_spend values are unique.
+// @Tags customers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param tier body models.LoyaltyTier false "Tier data (POST)"
+// @Success 200 {array} models.LoyaltyTier
+// @Success 201 {object} models.LoyaltyTier
+// @Failure 400 {string} string "Invalid tier"
+// @Failure 409 {string} string "Name or min_spend already used"
+// @Router /loyalty-tiers [get]
+// @Router /loyalty-tiers [post]
+func (h *LoyaltyTierHandler) HandleLoyaltyTiers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		tiers, err := h.service.GetAll()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tiers)
+	case http.MethodPost:
+		var tier models.LoyaltyTier
+		if err := json.NewDecoder(r.Body).Decode(&tier); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := h.service.Create(&tier); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(tier)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleLoyaltyTierByID handles get, update, and delete operations
+// @Summary Get, Update, or Delete a loyalty tier by ID
+// @Description Operations on a single loyalty tier. Deleting a tier moves its customers to the tier below.
+// @Tags customers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Tier ID"
+// @Param tier body models.LoyaltyTier false "Tier data (PUT)"
+// @Success 200 {object} models.LoyaltyTier
+// @Success 204 "No Content"
+// @Failure 404 {string} string "Tier not found"
+// @Router /loyalty-tiers/{id} [get]
+// @Router /loyalty-tiers/{id} [put]
+// @Router /loyalty-tiers/{id} [delete]
+func (h *LoyaltyTierHandler) HandleLoyaltyTierByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/loyalty-tiers/"))
+	if err != nil {
+		http.Error(w, "Invalid tier ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		tier, err := h.service.GetByID(id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tier)
+	case http.MethodPut:
+		var tier models.LoyaltyTier
+		if err := json.NewDecoder(r.Body).Decode(&tier); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		tier.ID = id
+		if err := h.service.Update(&tier); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(tier)
+	case http.MethodDelete:
+		if err := h.service.Delete(id); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
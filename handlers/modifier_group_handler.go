@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type ModifierGroupHandler struct {
+	service *services.ModifierGroupService
+}
+
+func NewModifierGroupHandler(service *services.ModifierGroupService) *ModifierGroupHandler {
+	return &ModifierGroupHandler{service: service}
+}
+
+// HandleModifierGroups handles list and create operations
+// @Summary Get all modifier groups or create a new one
+// @Description List modifier groups with their modifiers, or create one. A required group needs at least min_select choices (at least 1) on every sale line of a product it is attached to; max_select 0 means no limit. Modifier prices are added to the unit price; active defaults to true.
+// @Tags modifiers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param group body models.ModifierGroup false "Modifier group (POST)"
+// @Success 200 {array} models.ModifierGroup
+// @Success 201 {object} models.ModifierGroup
+// @Failure 400 {string} string "Invalid modifier group"
+// @Router /modifier-groups [get]
+// @Router /modifier-groups [post]
+func (h *ModifierGroupHandler) HandleModifierGroups(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAll(w, r)
+	case http.MethodPost:
+		h.Create(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleModifierGroupByID handles get, update, and delete operations
+// @Summary Get, Update, or Delete a modifier group by ID
+// @Description Operations on a single modifier group. On PUT, modifiers with an id are updated, modifiers without one are added and modifiers left out are removed; past sales keep the name and price they were sold with. Deleting a group also detaches it from its products.
+// @Tags modifiers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Modifier group ID"
+// @Param group body models.ModifierGroup false "Modifier group (PUT)"
+// @Success 200 {object} models.ModifierGroup
+// @Success 204 "No Content"
+// @Failure 404 {string} string "Modifier group not found"
+// @Router /modifier-groups/{id} [get]
+// @Router /modifier-groups/{id} [put]
+// @Router /modifier-groups/{id} [delete]
+func (h *ModifierGroupHandler) HandleModifierGroupByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/modifier-groups/"))
+	if err != nil {
+		http.Error(w, "Invalid modifier group ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetByID(w, r, id)
+	case http.MethodPut:
+		h.Update(w, r, id)
+	case http.MethodDelete:
+		h.Delete(w, r, id)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *ModifierGroupHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.service.GetAll()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
+
+func (h *ModifierGroupHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var group models.ModifierGroup
+	if err := json.NewDecoder(r.Body).Decode(&group); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Create(&group); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(group)
+}
+
+func (h *ModifierGroupHandler) GetByID(w http.ResponseWriter, r *http.Request, id int) {
+	group, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(group)
+}
+
+func (h *ModifierGroupHandler) Update(w http.ResponseWriter, r *http.Request, id int) {
+	var group models.ModifierGroup
+	if err := json.NewDecoder(r.Body).Decode(&group); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	group.ID = id
+	if err := h.service.Update(&group); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(group)
+}
+
+func (h *ModifierGroupHandler) Delete(w http.ResponseWriter, r *http.Request, id int) {
+	if err := h.service.Delete(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"go-kasir-api/models"
+	"net/url"
+	"strconv"
+)
+
+// paginationLinks mengisi URL relatif ke halaman lain dari request u dengan
+// filter yang sama. Pada pagination cursor (Page 0) hanya self dan next.
+func paginationLinks(u *url.URL, p *models.Pagination) {
+	link := func(set func(q url.Values)) string {
+		q := u.Query()
+		set(q)
+		return (&url.URL{Path: u.Path, RawQuery: q.Encode()}).String()
+	}
+	page := func(n int) string {
+		return link(func(q url.Values) {
+			q.Del("cursor")
+			q.Set("page", strconv.Itoa(n))
+		})
+	}
+
+	links := &models.PaginationLinks{Self: (&url.URL{Path: u.Path, RawQuery: u.RawQuery}).String()}
+	if p.Page == 0 {
+		if p.NextCursor != "" {
+			links.Next = link(func(q url.Values) {
+				q.Del("page")
+				q.Set("cursor", p.NextCursor)
+			})
+		}
+		p.Links = links
+		return
+	}
+
+	links.First = page(1)
+	if p.Page > 1 {
+		links.Prev = page(min(p.Page-1, max(p.TotalPages, 1)))
+	}
+	if p.Page < p.TotalPages {
+		links.Next = page(p.Page + 1)
+	}
+	links.Last = page(max(p.TotalPages, 1))
+	p.Links = links
+}
@@ -5,32 +5,46 @@ import (
 	"go-kasir-api/models"
 	"go-kasir-api/services"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
 
 type ProductHandler struct {
-	service *services.ProductService
+	service      *services.ProductService
+	stockService *services.StockService
 }
 
-func NewProductHandler(service *services.ProductService) *ProductHandler {
-	return &ProductHandler{service: service}
+func NewProductHandler(service *services.ProductService, stockService *services.StockService) *ProductHandler {
+	return &ProductHandler{service: service, stockService: stockService}
 }
 
 // HandleProducts - GET /api/products
-// @Summary Get all products or create a new one
-// @Description Get a list of all products or create a new product
+// @Summary List products or create a new one
+// @Description GET lists top-level products a page at a time; variants are inline in their parent. q searches names by substring, prefix or similar spelling (typos), SKU prefixes and exact barcodes, including those of variants. Filter by category_id, min_price/max_price and in_stock. sort is relevance (default with q), id (default), name, price or stock, with - for descending. Pages are chosen with page or with the cursor from pagination.next_cursor, which stays stable while products change; pagination.links has ready-made URLs.
+// @Description POST creates a product. Stock is kept in the base unit (default pcs); decimals (0-3) sets how many decimal places its quantities may have, e.g. 3 for goods sold per kg. units lists other units with their factor in base units (e.g. carton = 24) and an optional own price.
 // @Tags products
+// @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Success 200 {array} models.Product
+// @Param q query string false "Search in name, SKU and barcode"
+// @Param category_id query int false "Only products in this category"
+// @Param min_price query int false "Minimum price"
+// @Param max_price query int false "Maximum price"
+// @Param in_stock query bool false "Only products with stock (or a variant with stock)"
+// @Param sort query string false "relevance, id, name, price or stock; prefix - for descending"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} models.ProductList
 // @Success 201 {object} models.Product
+// @Failure 400 {string} string "Invalid filter"
 // @Router /products [get]
 // @Router /products [post]
 func (h *ProductHandler) HandleProducts(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		h.GetAll(w, r)
+		h.List(w, r)
 	case http.MethodPost:
 		h.Create(w, r)
 	default:
@@ -38,15 +52,51 @@ func (h *ProductHandler) HandleProducts(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	products, err := h.service.GetAll()
+func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseProductFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.service.List(filter)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
+	paginationLinks(r.URL, &list.Pagination)
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(products)
+	json.NewEncoder(w).Encode(list)
+}
+
+func parseProductFilter(q url.Values) (models.ProductFilter, error) {
+	filter := models.ProductFilter{Query: q.Get("q"), Sort: q.Get("sort"), Cursor: q.Get("cursor")}
+	var err error
+
+	if filter.CategoryID, err = queryInt(q, "category_id"); err != nil {
+		return filter, err
+	}
+	if filter.MinPrice, err = queryInt(q, "min_price"); err != nil {
+		return filter, err
+	}
+	if filter.MaxPrice, err = queryInt(q, "max_price"); err != nil {
+		return filter, err
+	}
+	if filter.InStock, err = queryBool(q, "in_stock"); err != nil {
+		return filter, err
+	}
+	page, err := queryInt(q, "page")
+	if err != nil {
+		return filter, err
+	}
+	pageSize, err := queryInt(q, "page_size")
+	if err != nil {
+		return filter, err
+	}
+	filter.Page, filter.PageSize = intOrZero(page), intOrZero(pageSize)
+
+	return filter, nil
 }
 
 func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -57,9 +107,9 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.Create(&product)
+	err = h.service.Create(&product, currentUser(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
@@ -70,17 +120,32 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 // HandleProductByID - GET/PUT/DELETE /api/products/{id}
 // @Summary Get, Update, or Delete a product by ID
-// @Description Operations on a single product
+// @Description Operations on a single product. Stock is read-only on update: the sent value is ignored and changes go through the stock-movements endpoint.
 // @Tags products
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path int true "Product ID"
 // @Success 200 {object} models.Product
 // @Success 204 "No Content"
+// @Failure 409 {string} string "Product already used in transactions or has stock history"
 // @Router /products/{id} [get]
 // @Router /products/{id} [put]
 // @Router /products/{id} [delete]
 func (h *ProductHandler) HandleProductByID(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/products/lookup" {
+		h.HandleLookup(w, r)
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, "/stock-movements") {
+		h.HandleStockMovements(w, r)
+		return
+	}
+	if strings.HasSuffix(r.URL.Path, "/variants") {
+		h.HandleVariants(w, r)
+		return
+	}
+
 	switch r.Method {
 	case http.MethodGet:
 		h.GetByID(w, r)
@@ -104,7 +169,7 @@ func (h *ProductHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 
 	product, err := h.service.GetByID(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, err)
 		return
 	}
 
@@ -130,7 +195,7 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 	product.ID = id
 	err = h.service.Update(&product)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
@@ -149,7 +214,7 @@ func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	err = h.service.Delete(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -157,4 +222,200 @@ func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{
 		"message": "Product deleted successfully",
 	})
-}
\ No newline at end of file
+}
+
+// HandleVariants - GET/POST /api/products/{id}/variants
+// @Summary List or add variants of a product
+// @Description GET lists the variants of a parent product with their options. POST adds a variant with its own SKU, barcodes, price, cost and stock; options pick one value per option group (e.g. Size = Large). The first variant defines the parent's option groups, later variants must set the same groups with a new combination of values. An empty name becomes "Parent - Value / Value", price 0 takes the parent's price, and category and tax rate always follow the parent. The parent must have zero stock.
+// @Tags products
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Parent product ID"
+// @Param variant body models.Product false "Variant (POST)"
+// @Success 200 {array} models.Product
+// @Success 201 {object} models.Product
+// @Failure 400 {string} string "Invalid options or parent"
+// @Failure 404 {string} string "Product not found"
+// @Failure 409 {string} string "Variant, SKU or barcode already exists"
+// @Router /products/{id}/variants [get]
+// @Router /products/{id}/variants [post]
+func (h *ProductHandler) HandleVariants(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/products/"), "/variants")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		product, err := h.service.GetByID(id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		variants := product.Variants
+		if variants == nil {
+			variants = make([]models.Product, 0)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(variants)
+	case http.MethodPost:
+		var variant models.Product
+		if err := json.NewDecoder(r.Body).Decode(&variant); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		if err := h.service.CreateVariant(id, &variant, currentUser(r)); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(variant)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleStockMovements - GET/POST /api/products/{id}/stock-movements
+// @Summary List or record stock movements of a product
+// @Description GET lists the stock ledger of a product, newest first, with the balance after each entry. POST records a manual movement (purchase, adjustment, transfer or waste) with a signed quantity in the product's base unit; a note is required except for purchases. Sales, voids and returns are recorded by their transactions.
+// @Tags products
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Param start_date query string false "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD)"
+// @Param type query string false "Movement type"
+// @Param movement body models.StockMovement false "Movement (POST)"
+// @Success 200 {object} models.StockMovementList
+// @Success 201 {object} models.StockMovement
+// @Failure 400 {string} string "Invalid movement"
+// @Failure 404 {string} string "Product not found"
+// @Router /products/{id}/stock-movements [get]
+// @Router /products/{id}/stock-movements [post]
+func (h *ProductHandler) HandleStockMovements(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/products/"), "/stock-movements")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "Invalid product ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.ListStockMovements(w, r, id)
+	case http.MethodPost:
+		h.RecordStockMovement(w, r, id)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *ProductHandler) ListStockMovements(w http.ResponseWriter, r *http.Request, id int) {
+	q := r.URL.Query()
+	filter := models.StockMovementFilter{ProductID: id, Type: q.Get("type")}
+	var err error
+	if filter.StartDate, err = queryDate(q, "start_date"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if filter.EndDate, err = queryDate(q, "end_date"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, err := queryInt(q, "page")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	pageSize, err := queryInt(q, "page_size")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter.Page, filter.PageSize = intOrZero(page), intOrZero(pageSize)
+
+	list, err := h.stockService.ListMovements(filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+func (h *ProductHandler) RecordStockMovement(w http.ResponseWriter, r *http.Request, id int) {
+	var movement models.StockMovement
+	if err := json.NewDecoder(r.Body).Decode(&movement); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	movement.ProductID = id
+	if err := h.stockService.RecordMovement(&movement, currentUser(r)); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(movement)
+}
+
+// HandleLowStock - GET /api/inventory/low-stock
+// @Summary List products below minimum stock
+// @Description List products whose stock is below min_stock, most critical first, with the quantity still on open purchase orders and a suggested order quantity (reorder_quantity or the shortfall, whichever is larger, minus what is on order).
+// @Tags inventory
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} models.LowStockItem
+// @Router /inventory/low-stock [get]
+func (h *ProductHandler) HandleLowStock(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	items, err := h.stockService.LowStock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
+
+// HandleLookup - GET /api/products/lookup?barcode=
+// @Summary Look up a product by scanned barcode
+// @Description Find the product for a scanned barcode or SKU. In-store scale labels (EAN-13 with prefix 20-29: 2-digit prefix, 5-digit PLU, 5-digit value, check digit) are decoded: prefixes 20-24 carry the weight in grams, 25-29 the price, and quantity is derived from it. For products with decimal quantities the weight is read as kg (1250 g = 1.25), otherwise as grams. Other codes return quantity 1.
+// @Tags products
+// @Security BearerAuth
+// @Produce json
+// @Param barcode query string true "Scanned barcode or SKU"
+// @Success 200 {object} models.BarcodeLookup
+// @Failure 400 {string} string "Missing barcode or invalid check digit"
+// @Failure 404 {string} string "No product matches this barcode"
+// @Router /products/lookup [get]
+func (h *ProductHandler) HandleLookup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := h.service.Lookup(r.URL.Query().Get("barcode"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxImportFileSize membatasi ukuran file yang diunggah untuk impor produk.
+const maxImportFileSize = 10 << 20
+
+type ProductImportHandler struct {
+	service *services.ProductImportService
+}
+
+func NewProductImportHandler(service *services.ProductImportService) *ProductImportHandler {
+	return &ProductImportHandler{service: service}
+}
+
+// HandleImports - GET/POST /api/products/import
+// @Summary List product imports or start a new one
+// @Description GET lists the latest import jobs, newest first. POST uploads a CSV or XLSX file (first sheet) and starts a background import job; poll GET /products/import/{id} for progress and row-level errors. The first row holds column titles. Columns are sku, name, category, price, cost, stock, unit, decimals, min_stock, reorder_quantity and barcodes (separated by ";", optionally "type:code"); mapping is a JSON object from these fields to the titles used in the file, e.g. {"name": "Nama Barang"}. Unmapped fields use the column with the same title; other columns are ignored. A row whose sku matches a product (or variant) updates it, changing only the filled-in cells; other rows create a product. Categories are matched by name and created when missing. With dry_run=true rows are only validated and created_rows/updated_rows count what would happen.
+// @Tags products
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or XLSX file (max 10 MB)"
+// @Param format formData string false "csv or xlsx (default from the file name)"
+// @Param mapping formData string false "JSON object mapping import fields to column titles"
+// @Param dry_run formData bool false "Only validate the rows"
+// @Success 200 {array} models.ProductImportJob
+// @Success 202 {object} models.ProductImportJob
+// @Failure 400 {string} string "Invalid file, format or mapping"
+// @Router /products/import [get]
+// @Router /products/import [post]
+func (h *ProductImportHandler) HandleImports(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		jobs, err := h.service.GetAll()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(jobs)
+	case http.MethodPost:
+		h.Start(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *ProductImportHandler) Start(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize+1<<20)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		http.Error(w, "Invalid upload, send a multipart form with a file of at most 10 MB", http.StatusBadRequest)
+		return
+	}
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Invalid upload", http.StatusBadRequest)
+		return
+	}
+
+	req := models.ProductImportRequest{
+		FileName: fileHeader.Filename,
+		Format:   r.FormValue("format"),
+		Data:     data,
+	}
+	if req.DryRun, err = queryBool(url.Values(r.MultipartForm.Value), "dry_run"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if v := r.FormValue("mapping"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Mapping); err != nil {
+			http.Error(w, "mapping must be a JSON object of field to column title", http.StatusBadRequest)
+			return
+		}
+	}
+
+	job, err := h.service.Start(req, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+// HandleImportByID - GET /api/products/import/{id}
+// @Summary Get a product import job
+// @Description Get the status and counters of an import job (pending, running, completed or failed) with all row-level errors so far. Row is the line number in the file, the header being line 1.
+// @Tags products
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Import job ID"
+// @Success 200 {object} models.ProductImportJob
+// @Failure 404 {string} string "Import job not found"
+// @Router /products/import/{id} [get]
+func (h *ProductImportHandler) HandleImportByID(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/products/import/"))
+	if err != nil {
+		http.Error(w, "Invalid import job ID", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// HandleExport - GET /api/products/export
+// @Summary Export the product catalog
+// @Description Download all products as CSV or XLSX, each parent followed by its variants, with category, stock in the base unit and barcodes. The columns match the import, so the file can be edited and imported again. Text starting with =, +, - or @ is prefixed with a single quote so spreadsheets do not run it as a formula; the import drops that quote again.
+// @Tags products
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "csv (default) or xlsx"
+// @Success 200 {file} file
+// @Failure 400 {string} string "Invalid format"
+// @Router /products/export [get]
+func (h *ProductImportHandler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = models.ImportFormatCSV
+	}
+
+	var buf bytes.Buffer
+	if err := h.service.Export(format, &buf); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == models.ImportFormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="products-%s.%s"`, time.Now().Format("20060102"), format))
+	w.Write(buf.Bytes())
+}
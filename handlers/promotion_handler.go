@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type PromotionHandler struct {
+	service *services.PromotionService
+}
+
+func NewPromotionHandler(service *services.PromotionService) *PromotionHandler {
+	return &PromotionHandler{service: service}
+}
+
+// HandlePromotions handles list and create operations
+// @Summary Get all promotions or create a new one
+// @Description List promotions or create one. Types: percentage and fixed (scope cart, product or category), buy_x_get_y (product_id, buy_quantity, get_quantity, optional get_product_id) and bundle (items sold together for value). Optional starts_at/ends_at, days_of_week (0 = Sunday) and start_time/end_time (HH:MM store time) limit when it applies.
+// @Tags promotions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param promotion body models.Promotion false "Promotion data (POST)"
+// @Success 200 {array} models.Promotion
+// @Success 201 {object} models.Promotion
+// @Failure 400 {string} string "Invalid promotion"
+// @Router /promotions [get]
+// @Router /promotions [post]
+func (h *PromotionHandler) HandlePromotions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAll(w, r)
+	case http.MethodPost:
+		h.Create(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandlePromotionByID handles get, update, and delete operations
+// @Summary Get, Update, or Delete a promotion by ID
+// @Description Operations on a single promotion. Deleting a promotion keeps its name and discount on past transactions.
+// @Tags promotions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Promotion ID"
+// @Param promotion body models.Promotion false "Promotion data (PUT)"
+// @Success 200 {object} models.Promotion
+// @Success 204 "No Content"
+// @Failure 404 {string} string "Promotion not found"
+// @Router /promotions/{id} [get]
+// @Router /promotions/{id} [put]
+// @Router /promotions/{id} [delete]
+func (h *PromotionHandler) HandlePromotionByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/promotions/"))
+	if err != nil {
+		http.Error(w, "Invalid promotion ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetByID(w, r, id)
+	case http.MethodPut:
+		h.Update(w, r, id)
+	case http.MethodDelete:
+		h.Delete(w, r, id)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *PromotionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	promotions, err := h.service.GetAll()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(promotions)
+}
+
+func (h *PromotionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	promotion := models.Promotion{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&promotion); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Create(&promotion); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(promotion)
+}
+
+func (h *PromotionHandler) GetByID(w http.ResponseWriter, r *http.Request, id int) {
+	promotion, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(promotion)
+}
+
+func (h *PromotionHandler) Update(w http.ResponseWriter, r *http.Request, id int) {
+	var promotion models.Promotion
+	if err := json.NewDecoder(r.Body).Decode(&promotion); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	promotion.ID = id
+	if err := h.service.Update(&promotion); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(promotion)
+}
+
+func (h *PromotionHandler) Delete(w http.ResponseWriter, r *http.Request, id int) {
+	if err := h.service.Delete(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type PurchaseOrderHandler struct {
+	service *services.PurchaseOrderService
+}
+
+func NewPurchaseOrderHandler(service *services.PurchaseOrderService) *PurchaseOrderHandler {
+	return &PurchaseOrderHandler{service: service}
+}
+
+// HandlePurchaseOrders handles list and create operations
+// @Summary List purchase orders or create a new one
+// @Description List purchase orders, newest first, optionally filtered by status and supplier, or create one. Lines need product_id, quantity and unit_cost, and may name one of the product's units (e.g. carton) with unit_cost per that unit; stock only increases, in the base unit, when goods are received.
+// @Tags purchase-orders
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param status query string false "open, partial, received or cancelled"
+// @Param supplier_id query int false "Supplier ID"
+// @Param purchaseOrder body models.PurchaseOrder false "Purchase order (POST)"
+// @Success 200 {array} models.PurchaseOrder
+// @Success 201 {object} models.PurchaseOrder
+// @Failure 400 {string} string "Invalid purchase order"
+// @Router /purchase-orders [get]
+// @Router /purchase-orders [post]
+func (h *PurchaseOrderHandler) HandlePurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		supplierID, err := queryInt(q, "supplier_id")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		orders, err := h.service.GetAll(q.Get("status"), supplierID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(orders)
+	case http.MethodPost:
+		var po models.PurchaseOrder
+		if err := json.NewDecoder(r.Body).Decode(&po); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		created, err := h.service.Create(&po, currentUser(r))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandlePurchaseOrderByID dispatches /api/purchase-orders/{id}[/action]
+func (h *PurchaseOrderHandler) HandlePurchaseOrderByID(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/purchase-orders/"), "/")
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		http.Error(w, "Invalid purchase order ID", http.StatusBadRequest)
+		return
+	}
+
+	action := ""
+	if len(parts) > 1 {
+		action = strings.Join(parts[1:], "/")
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		h.GetByID(w, r, id)
+	case action == "receipts" && r.Method == http.MethodPost:
+		h.Receive(w, r, id)
+	case action == "cancel" && r.Method == http.MethodPost:
+		h.Cancel(w, r, id)
+	case action == "" || action == "receipts" || action == "cancel":
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// GetByID gets a purchase order
+// @Summary Get purchase order
+// @Description Get a purchase order with its lines (ordered and received quantity) and every goods receipt recorded against it.
+// @Tags purchase-orders
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 404 {string} string "Purchase order not found"
+// @Router /purchase-orders/{id} [get]
+func (h *PurchaseOrderHandler) GetByID(w http.ResponseWriter, r *http.Request, id int) {
+	po, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(po)
+}
+
+// Receive records a goods receipt
+// @Summary Receive goods for a purchase order
+// @Description Record goods received against purchase order lines, in each line's unit; partial quantities are allowed. Each line adds stock as a purchase in the stock ledger and updates the product cost with a moving average. unit_cost defaults to the price on the order. The order becomes partial, or received once every line is complete.
+// @Tags purchase-orders
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Param receipt body models.GoodsReceipt true "Received lines"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 400 {string} string "Invalid receipt"
+// @Failure 404 {string} string "Purchase order not found"
+// @Failure 409 {string} string "Purchase order is already received or cancelled"
+// @Router /purchase-orders/{id}/receipts [post]
+func (h *PurchaseOrderHandler) Receive(w http.ResponseWriter, r *http.Request, id int) {
+	var receipt models.GoodsReceipt
+	if err := json.NewDecoder(r.Body).Decode(&receipt); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	po, err := h.service.Receive(id, &receipt, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(po)
+}
+
+// Cancel cancels a purchase order
+// @Summary Cancel a purchase order
+// @Description Stop waiting for the remaining quantities. Goods already received stay in stock.
+// @Tags purchase-orders
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 404 {string} string "Purchase order not found"
+// @Failure 409 {string} string "Purchase order is already received or cancelled"
+// @Router /purchase-orders/{id}/cancel [post]
+func (h *PurchaseOrderHandler) Cancel(w http.ResponseWriter, r *http.Request, id int) {
+	po, err := h.service.Cancel(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(po)
+}
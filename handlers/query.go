@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// queryInt membaca parameter integer opsional; nil jika tidak diisi.
+func queryInt(q url.Values, key string) (*int, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", key, v)
+	}
+	return &n, nil
+}
+
+// queryBool membaca parameter boolean opsional (true/false/1/0); false jika
+// tidak diisi.
+func queryBool(q url.Values, key string) (bool, error) {
+	v := q.Get(key)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q, expected true or false", key, v)
+	}
+	return b, nil
+}
+
+// queryDate membaca parameter tanggal YYYY-MM-DD opsional. Hasilnya tanggal
+// bisnis (tengah malam UTC); service yang mengubahnya ke zona waktu toko.
+func queryDate(q url.Values, key string) (*time.Time, error) {
+	v := q.Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateLayout, v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q, expected YYYY-MM-DD", key, v)
+	}
+	return &t, nil
+}
+
+// queryDateRange membaca start_date (wajib) dan end_date (default start_date).
+func queryDateRange(q url.Values) (time.Time, time.Time, error) {
+	start, err := queryDate(q, "start_date")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if start == nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("start_date is required")
+	}
+	end, err := queryDate(q, "end_date")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if end == nil {
+		end = start
+	}
+	return *start, *end, nil
+}
+
+func intOrZero(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
@@ -0,0 +1,400 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"time"
+)
+
+type ReportHandler struct {
+	service *services.ReportService
+}
+
+func NewReportHandler(service *services.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// HandleDailyReport gets the daily sales report
+// @Summary Get daily sales report
+// @Description Get total revenue, total transactions, best selling products and payment-method breakdown for the current business day in the store timezone
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param top query int false "Number of best sellers to return (default 5, max 50)"
+// @Param rollup query bool false "Count variant sales under their parent product"
+// @Success 200 {object} models.DailyReport
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report/hari-ini [get]
+func (h *ReportHandler) HandleDailyReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	top, err := queryInt(r.URL.Query(), "top")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rollup, err := queryBool(r.URL.Query(), "rollup")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// For "hari-ini", we use the business day running at time.Now()
+	report, err := h.service.GetDailyReport(time.Now(), intOrZero(top), rollup)
+	if err != nil {
+		http.Error(w, "Failed to get daily report: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// HandleSalesReport gets the sales report for a date range
+// @Summary Get sales report for a date range
+// @Description Get revenue, transaction count and best seller per day, week or month, plus a summary with the top-N best sellers for the whole range. Dates are business days in the store timezone; period_end is exclusive.
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param start_date query string true "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD, default start_date)"
+// @Param group_by query string false "day, week or month (default day)"
+// @Param top query int false "Number of best sellers in the summary (default 5, max 50)"
+// @Param rollup query bool false "Count variant sales under their parent product"
+// @Success 200 {object} models.SalesReport
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report [get]
+func (h *ReportHandler) HandleSalesReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	start, err := queryDate(q, "start_date")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if start == nil {
+		http.Error(w, "start_date is required", http.StatusBadRequest)
+		return
+	}
+	end, err := queryDate(q, "end_date")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if end == nil {
+		end = start
+	}
+	top, err := queryInt(q, "top")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rollup, err := queryBool(q, "rollup")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetSalesReport(*start, *end, q.Get("group_by"), intOrZero(top), rollup)
+	if err != nil {
+		var validationErr *models.ValidationError
+		if errors.As(err, &validationErr) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to get sales report: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// HandlePromotionReport gets promotion usage for a date range
+// @Summary Get promotion usage report
+// @Description Get how many sales used each promotion and the discount it gave over a range of business days. Voided sales are excluded.
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param start_date query string true "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD, default start_date)"
+// @Success 200 {object} models.PromotionReport
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report/promotions [get]
+func (h *ReportHandler) HandlePromotionReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	start, end, err := queryDateRange(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetPromotionReport(start, end)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// HandleModifierReport gets modifier popularity for a date range
+// @Summary Get modifier report
+// @Description Get how many units were sold with each modifier and the revenue from modifier prices (before discounts) over a range of business days, net of voids and returns, most chosen first.
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param start_date query string true "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD, default start_date)"
+// @Success 200 {object} models.ModifierReport
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report/modifiers [get]
+func (h *ReportHandler) HandleModifierReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	start, end, err := queryDateRange(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetModifierReport(start, end)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// HandleTaxReport gets the output tax summary for a date range
+// @Summary Get tax report
+// @Description Get the taxable amount (DPP) and tax per rate over a range of business days, net of voids and returns, plus the untaxed sales amount. Rates are the ones recorded on each sale.
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param start_date query string true "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD, default start_date)"
+// @Success 200 {object} models.TaxReport
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report/tax [get]
+func (h *ReportHandler) HandleTaxReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	start, end, err := queryDateRange(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetTaxReport(start, end)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// HandleOutstandingPurchaseReport gets purchase order quantities not yet received
+// @Summary Get outstanding purchase orders report
+// @Description List open and partially received purchase order lines with the quantity still to arrive and its value at the ordered cost, earliest expected date first.
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param supplier_id query int false "Supplier ID"
+// @Success 200 {object} models.OutstandingPurchaseReport
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report/purchase-orders/outstanding [get]
+func (h *ReportHandler) HandleOutstandingPurchaseReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	supplierID, err := queryInt(r.URL.Query(), "supplier_id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetOutstandingPurchaseReport(supplierID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// HandleSupplierPurchaseReport gets goods received per supplier for a date range
+// @Summary Get supplier purchase history
+// @Description Get quantity and value received per supplier and product over a range of business days, by receipt date, with the average unit cost paid.
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param start_date query string true "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD, default start_date)"
+// @Param supplier_id query int false "Supplier ID"
+// @Success 200 {object} models.SupplierPurchaseReport
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report/supplier-purchases [get]
+func (h *ReportHandler) HandleSupplierPurchaseReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	start, end, err := queryDateRange(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	supplierID, err := queryInt(q, "supplier_id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetSupplierPurchaseReport(start, end, supplierID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// HandleMarginReport gets gross margin for a date range
+// @Summary Get gross margin report
+// @Description Get net sales (after discounts, without tax), cost and gross profit per product, parent product (variants rolled up), category or business day, net of voids and returns. Cost is the product cost recorded on each sale line. Categories are the products' current categories.
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param start_date query string true "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD, default start_date)"
+// @Param group_by query string false "product (default), parent, category or day"
+// @Success 200 {object} models.MarginReport
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report/margin [get]
+func (h *ReportHandler) HandleMarginReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	start, end, err := queryDateRange(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetMarginReport(start, end, q.Get("group_by"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// HandleCustomerReport gets customer spend and lifetime value
+// @Summary Get customer value report
+// @Description Get net sales of registered customers over a range of business days: the members' share of all sales, and the top customers by net spend (after voids and returns) with visits, average basket, points earned and redeemed in the range, and their lifetime value, first and last purchase and current tier.
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Param start_date query string true "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD, default start_date)"
+// @Param top query int false "Number of customers to return (default 20, max 100)"
+// @Success 200 {object} models.CustomerReport
+// @Failure 400 {string} string "Invalid parameters"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report/customers [get]
+func (h *ReportHandler) HandleCustomerReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := r.URL.Query()
+	start, end, err := queryDateRange(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	top, err := queryInt(q, "top")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.GetCustomerReport(start, end, intOrZero(top))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// HandleReceivablesReport gets the credit owed by customers
+// @Summary Get receivables aging report
+// @Description Get the credit (kasbon) position of all customers as of today: total owed and total store credit, and per customer the balance, credit limit, oldest unpaid charge, last repayment and aging of what is owed in buckets of 0-30, 31-60 and over 60 days. Repayments settle the oldest charges first. Customers owing the most come first.
+// @Tags reports
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.ReceivablesReport
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /report/receivables [get]
+func (h *ReportHandler) HandleReceivablesReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	report, err := h.service.GetReceivablesReport(time.Now())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type ShiftHandler struct {
+	service *services.ShiftService
+}
+
+func NewShiftHandler(service *services.ShiftService) *ShiftHandler {
+	return &ShiftHandler{service: service}
+}
+
+// HandleShifts - GET/POST /api/shifts
+// @Summary List shifts or open a new one
+// @Description GET lists cashier shifts, newest first; cashiers only see their own. POST opens a shift for the logged-in user with the starting float in the drawer; a user can have one open shift at a time. While it is open, their sales, voids, returns and credit repayments are linked to it. Taking or refunding cash requires an open shift.
+// @Tags shifts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param status query string false "open or closed"
+// @Param cashier_id query int false "Only shifts of this cashier (managers)"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Param request body models.OpenShiftRequest false "Starting float (POST)"
+// @Success 200 {object} models.ShiftList
+// @Success 201 {object} models.Shift
+// @Failure 400 {string} string "Invalid opening float"
+// @Failure 409 {string} string "User already has an open shift"
+// @Router /shifts [get]
+// @Router /shifts [post]
+func (h *ShiftHandler) HandleShifts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.List(w, r)
+	case http.MethodPost:
+		h.Open(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *ShiftHandler) List(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := models.ShiftFilter{Status: q.Get("status")}
+	var err error
+	if filter.CashierID, err = queryInt(q, "cashier_id"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, err := queryInt(q, "page")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	pageSize, err := queryInt(q, "page_size")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter.Page, filter.PageSize = intOrZero(page), intOrZero(pageSize)
+
+	list, err := h.service.List(filter, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	paginationLinks(r.URL, &list.Pagination)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+func (h *ShiftHandler) Open(w http.ResponseWriter, r *http.Request) {
+	var req models.OpenShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	shift, err := h.service.Open(req, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(shift)
+}
+
+// HandleShiftByID dispatches /api/shifts/{id}[/action] and /api/shifts/current
+func (h *ShiftHandler) HandleShiftByID(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/shifts/"), "/")
+	if len(parts) == 1 && parts[0] == "current" {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.Current(w, r)
+		return
+	}
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		http.Error(w, "Invalid shift ID", http.StatusBadRequest)
+		return
+	}
+
+	action := ""
+	if len(parts) > 1 {
+		action = strings.Join(parts[1:], "/")
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		h.GetByID(w, r, id)
+	case action == "cash-movements" && r.Method == http.MethodPost:
+		h.AddCashMovement(w, r, id)
+	case action == "close" && r.Method == http.MethodPost:
+		h.Close(w, r, id)
+	case action == "x-report" && r.Method == http.MethodGet:
+		h.XReport(w, r, id)
+	case action == "z-report" && r.Method == http.MethodGet:
+		h.ZReport(w, r, id)
+	case action == "" || action == "cash-movements" || action == "close" || action == "x-report" || action == "z-report":
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Current gets the open shift of the logged-in user
+// @Summary Get my open shift
+// @Description Get the shift the logged-in user has open, with its cash movements.
+// @Tags shifts
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.Shift
+// @Failure 404 {string} string "No open shift"
+// @Router /shifts/current [get]
+func (h *ShiftHandler) Current(w http.ResponseWriter, r *http.Request) {
+	shift, err := h.service.Current(currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(shift)
+}
+
+// GetByID gets a shift
+// @Summary Get a shift
+// @Description Get a shift with its cash movements and, once closed, the cash counted per denomination, expected cash and variance (counted minus expected). Cashiers can only get their own shifts.
+// @Tags shifts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Success 200 {object} models.Shift
+// @Failure 404 {string} string "Shift not found"
+// @Router /shifts/{id} [get]
+func (h *ShiftHandler) GetByID(w http.ResponseWriter, r *http.Request, id int) {
+	shift, err := h.service.GetByID(id, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(shift)
+}
+
+// AddCashMovement records petty cash in or out of the drawer
+// @Summary Record cash in or cash out
+// @Description Record petty cash put into (cash_in) or taken out of (cash_out) the drawer of an open shift, with a reason. It counts towards the expected cash.
+// @Tags shifts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Param request body models.CashMovementRequest true "Cash movement"
+// @Success 201 {object} models.CashMovement
+// @Failure 400 {string} string "Invalid type, amount or reason"
+// @Failure 404 {string} string "Shift not found"
+// @Failure 409 {string} string "Shift is already closed"
+// @Router /shifts/{id}/cash-movements [post]
+func (h *ShiftHandler) AddCashMovement(w http.ResponseWriter, r *http.Request, id int) {
+	var req models.CashMovementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	movement, err := h.service.AddCashMovement(id, req, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(movement)
+}
+
+// Close closes a shift with the cash counted
+// @Summary Close a shift
+// @Description Close an open shift with the cash in the drawer counted per denomination (e.g. {"denomination": 50000, "count": 3}); denominations left out count as zero. Expected cash is the starting float plus net cash from the shift's transactions and cash credit repayments plus cash in, minus cash out; variance is counted minus expected. Returns the Z report. The cashier or a manager can close it.
+// @Tags shifts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Param request body models.CloseShiftRequest true "Counted cash"
+// @Success 200 {object} models.ShiftReport
+// @Failure 400 {string} string "Invalid denominations"
+// @Failure 404 {string} string "Shift not found"
+// @Failure 409 {string} string "Shift is already closed"
+// @Router /shifts/{id}/close [post]
+func (h *ShiftHandler) Close(w http.ResponseWriter, r *http.Request, id int) {
+	var req models.CloseShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.service.Close(id, req, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// XReport gets the mid-shift report
+// @Summary Get X report
+// @Description Get the running totals of an open shift without closing it: sales, voids and returns, net sales and tax, payments per method, credit repayments, cash in and out and the cash expected in the drawer now. cash_outside_shifts shows net cash recorded without any shift while this shift was open (older data); it is not part of the expected cash.
+// @Tags shifts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Success 200 {object} models.ShiftReport
+// @Failure 404 {string} string "Shift not found"
+// @Failure 409 {string} string "Shift is already closed, use the Z report"
+// @Router /shifts/{id}/x-report [get]
+func (h *ShiftHandler) XReport(w http.ResponseWriter, r *http.Request, id int) {
+	report, err := h.service.XReport(id, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// ZReport gets the end-of-shift report
+// @Summary Get Z report
+// @Description Get the final report of a closed shift: the same totals as the X report, with the shift's counted cash per denomination, expected cash and variance.
+// @Tags shifts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Success 200 {object} models.ShiftReport
+// @Failure 404 {string} string "Shift not found"
+// @Failure 409 {string} string "Shift is still open, use the X report"
+// @Router /shifts/{id}/z-report [get]
+func (h *ShiftHandler) ZReport(w http.ResponseWriter, r *http.Request, id int) {
+	report, err := h.service.ZReport(id, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type StockCountHandler struct {
+	service *services.StockCountService
+}
+
+func NewStockCountHandler(service *services.StockCountService) *StockCountHandler {
+	return &StockCountHandler{service: service}
+}
+
+// HandleStockCounts handles list and open operations
+// @Summary List stock counts or open a new one
+// @Description List stock count (stock opname) sessions, newest first, or open a new one. Only one session can be open at a time. Opening is manager only.
+// @Tags stock-counts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body models.OpenStockCountRequest false "Note (POST)"
+// @Success 200 {array} models.StockCount
+// @Success 201 {object} models.StockCount
+// @Failure 409 {string} string "Another stock count is already open"
+// @Router /stock-counts [get]
+// @Router /stock-counts [post]
+func (h *StockCountHandler) HandleStockCounts(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		counts, err := h.service.GetAll()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(counts)
+	case http.MethodPost:
+		var req models.OpenStockCountRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+		count, err := h.service.Open(req.Note, currentUser(r))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(count)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleStockCountByID dispatches /api/stock-counts/{id}[/action]
+func (h *StockCountHandler) HandleStockCountByID(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/stock-counts/"), "/")
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		http.Error(w, "Invalid stock count ID", http.StatusBadRequest)
+		return
+	}
+
+	action := ""
+	if len(parts) > 1 {
+		action = strings.Join(parts[1:], "/")
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		h.GetByID(w, r, id)
+	case action == "items" && r.Method == http.MethodPut:
+		h.RecordItems(w, r, id)
+	case action == "post" && r.Method == http.MethodPost:
+		h.Post(w, r, id)
+	case action == "cancel" && r.Method == http.MethodPost:
+		h.Cancel(w, r, id)
+	case action == "" || action == "items" || action == "post" || action == "cancel":
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// GetByID gets a stock count with its variances
+// @Summary Get stock count with variances
+// @Description Get a stock count session with counted and system quantity, variance and variance value at cost per product. While open this is a preview; system stock is taken from the stock ledger at the time each product was last counted, so sales during the count are not variances.
+// @Tags stock-counts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Stock count ID"
+// @Success 200 {object} models.StockCount
+// @Failure 404 {string} string "Stock count not found"
+// @Router /stock-counts/{id} [get]
+func (h *StockCountHandler) GetByID(w http.ResponseWriter, r *http.Request, id int) {
+	count, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(count)
+}
+
+// RecordItems records counted quantities from a device
+// @Summary Record counted quantities
+// @Description Record counted quantities from one device. An item may name one of the product's units (e.g. carton); it is stored in the base unit. Sending a product again from the same device replaces that device's figure; figures from different devices are added up, e.g. for stock on several shelves.
+// @Tags stock-counts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Stock count ID"
+// @Param request body models.StockCountEntry true "Device and counted items"
+// @Success 200 {object} models.StockCount
+// @Failure 400 {string} string "Invalid items"
+// @Failure 409 {string} string "Stock count is no longer open"
+// @Router /stock-counts/{id}/items [put]
+func (h *StockCountHandler) RecordItems(w http.ResponseWriter, r *http.Request, id int) {
+	var entry models.StockCountEntry
+	if err := json.NewDecoder(r.Body).Decode(&entry); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.RecordItems(id, entry, currentUser(r)); err != nil {
+		writeError(w, err)
+		return
+	}
+	h.GetByID(w, r, id)
+}
+
+// Post posts a stock count
+// @Summary Post a stock count
+// @Description Write every variance as an adjustment in the stock ledger and close the session. Products that were not counted are left unchanged. Manager only.
+// @Tags stock-counts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Stock count ID"
+// @Success 200 {object} models.StockCount
+// @Failure 404 {string} string "Stock count not found"
+// @Failure 409 {string} string "Stock count is no longer open"
+// @Router /stock-counts/{id}/post [post]
+func (h *StockCountHandler) Post(w http.ResponseWriter, r *http.Request, id int) {
+	count, err := h.service.Post(id, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(count)
+}
+
+// Cancel cancels a stock count
+// @Summary Cancel a stock count
+// @Description Close an open session without changing stock. Manager only.
+// @Tags stock-counts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Stock count ID"
+// @Success 200 {object} models.StockCount
+// @Failure 404 {string} string "Stock count not found"
+// @Failure 409 {string} string "Stock count is no longer open"
+// @Router /stock-counts/{id}/cancel [post]
+func (h *StockCountHandler) Cancel(w http.ResponseWriter, r *http.Request, id int) {
+	count, err := h.service.Cancel(id, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(count)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type SupplierHandler struct {
+	service *services.SupplierService
+}
+
+func NewSupplierHandler(service *services.SupplierService) *SupplierHandler {
+	return &SupplierHandler{service: service}
+}
+
+// HandleSuppliers handles list and create operations
+// @Summary Get all suppliers or create a new one
+// @Description List suppliers or create one. active defaults to true; only active suppliers can receive new purchase orders.
+// @Tags suppliers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param supplier body models.Supplier false "Supplier data (POST)"
+// @Success 200 {array} models.Supplier
+// @Success 201 {object} models.Supplier
+// @Failure 400 {string} string "Invalid supplier"
+// @Router /suppliers [get]
+// @Router /suppliers [post]
+func (h *SupplierHandler) HandleSuppliers(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAll(w, r)
+	case http.MethodPost:
+		h.Create(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleSupplierByID handles get, update, and delete operations
+// @Summary Get, Update, or Delete a supplier by ID
+// @Description Operations on a single supplier. A supplier with purchase orders cannot be deleted; set active to false instead.
+// @Tags suppliers
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Param supplier body models.Supplier false "Supplier data (PUT)"
+// @Success 200 {object} models.Supplier
+// @Success 204 "No Content"
+// @Failure 404 {string} string "Supplier not found"
+// @Failure 409 {string} string "Supplier has purchase orders"
+// @Router /suppliers/{id} [get]
+// @Router /suppliers/{id} [put]
+// @Router /suppliers/{id} [delete]
+func (h *SupplierHandler) HandleSupplierByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/suppliers/"))
+	if err != nil {
+		http.Error(w, "Invalid supplier ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetByID(w, r, id)
+	case http.MethodPut:
+		h.Update(w, r, id)
+	case http.MethodDelete:
+		h.Delete(w, r, id)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *SupplierHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	suppliers, err := h.service.GetAll()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suppliers)
+}
+
+func (h *SupplierHandler) Create(w http.ResponseWriter, r *http.Request) {
+	supplier := models.Supplier{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&supplier); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Create(&supplier); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(supplier)
+}
+
+func (h *SupplierHandler) GetByID(w http.ResponseWriter, r *http.Request, id int) {
+	supplier, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(supplier)
+}
+
+func (h *SupplierHandler) Update(w http.ResponseWriter, r *http.Request, id int) {
+	supplier := models.Supplier{Active: true}
+	if err := json.NewDecoder(r.Body).Decode(&supplier); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	supplier.ID = id
+	if err := h.service.Update(&supplier); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(supplier)
+}
+
+func (h *SupplierHandler) Delete(w http.ResponseWriter, r *http.Request, id int) {
+	if err := h.service.Delete(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
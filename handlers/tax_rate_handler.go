@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-kasir-api/models"
+	"go-kasir-api/services"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type TaxRateHandler struct {
+	service *services.TaxRateService
+}
+
+func NewTaxRateHandler(service *services.TaxRateService) *TaxRateHandler {
+	return &TaxRateHandler{service: service}
+}
+
+// HandleTaxRates handles list and create operations
+// @Summary Get all tax rates or create a new one
+// @Description List tax rates or create one. rate is a percentage (e.g. 11 for PPN 11%); inclusive means product prices already contain the tax. Assign rates to products or categories with tax_rate_id.
+// @Tags tax-rates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param taxRate body models.TaxRate false "Tax rate data (POST)"
+// @Success 200 {array} models.TaxRate
+// @Success 201 {object} models.TaxRate
+// @Failure 400 {string} string "Invalid tax rate"
+// @Router /tax-rates [get]
+// @Router /tax-rates [post]
+func (h *TaxRateHandler) HandleTaxRates(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetAll(w, r)
+	case http.MethodPost:
+		h.Create(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleTaxRateByID handles get, update, and delete operations
+// @Summary Get, Update, or Delete a tax rate by ID
+// @Description Operations on a single tax rate. Changes only affect new sales; past lines keep the rate they were taxed at. A rate still assigned to products or categories cannot be deleted.
+// @Tags tax-rates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Tax rate ID"
+// @Param taxRate body models.TaxRate false "Tax rate data (PUT)"
+// @Success 200 {object} models.TaxRate
+// @Success 204 "No Content"
+// @Failure 404 {string} string "Tax rate not found"
+// @Failure 409 {string} string "Tax rate still in use"
+// @Router /tax-rates/{id} [get]
+// @Router /tax-rates/{id} [put]
+// @Router /tax-rates/{id} [delete]
+func (h *TaxRateHandler) HandleTaxRateByID(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/tax-rates/"))
+	if err != nil {
+		http.Error(w, "Invalid tax rate ID", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.GetByID(w, r, id)
+	case http.MethodPut:
+		h.Update(w, r, id)
+	case http.MethodDelete:
+		h.Delete(w, r, id)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *TaxRateHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	taxRates, err := h.service.GetAll()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taxRates)
+}
+
+func (h *TaxRateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var taxRate models.TaxRate
+	if err := json.NewDecoder(r.Body).Decode(&taxRate); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Create(&taxRate); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(taxRate)
+}
+
+func (h *TaxRateHandler) GetByID(w http.ResponseWriter, r *http.Request, id int) {
+	taxRate, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taxRate)
+}
+
+func (h *TaxRateHandler) Update(w http.ResponseWriter, r *http.Request, id int) {
+	var taxRate models.TaxRate
+	if err := json.NewDecoder(r.Body).Decode(&taxRate); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	taxRate.ID = id
+	if err := h.service.Update(&taxRate); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(taxRate)
+}
+
+func (h *TaxRateHandler) Delete(w http.ResponseWriter, r *http.Request, id int) {
+	if err := h.service.Delete(id); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"go-kasir-api/models"
 	"go-kasir-api/services"
 	"net/http"
-	"time"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 type TransactionHandler struct {
@@ -16,15 +19,228 @@ func NewTransactionHandler(service *services.TransactionService) *TransactionHan
 	return &TransactionHandler{service: service}
 }
 
+// HandleTransactions dispatches /api/transactions to list or checkout
+func (h *TransactionHandler) HandleTransactions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.HandleListTransactions(w, r)
+	case http.MethodPost:
+		h.HandleCreateTransaction(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// HandleListTransactions lists transactions
+// @Summary List transactions
+// @Description List transaction headers, newest first, with pagination and filters. Dates are inclusive business days in the store timezone.
+// @Tags transactions
+// @Security BearerAuth
+// @Produce json
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Page size (default 20, max 100)"
+// @Param start_date query string false "From date (YYYY-MM-DD)"
+// @Param end_date query string false "To date, inclusive (YYYY-MM-DD)"
+// @Param product_id query int false "Only transactions containing this product"
+// @Param customer_id query int false "Only transactions of this customer (purchase history)"
+// @Param shift_id query int false "Only transactions of this cashier shift"
+// @Param type query string false "sale, void or return"
+// @Param min_total query int false "Minimum total"
+// @Param max_total query int false "Maximum total"
+// @Success 200 {object} models.TransactionList
+// @Failure 400 {string} string "Invalid filter"
+// @Failure 500 {string} string "Internal Server Error"
+// @Router /transactions [get]
+func (h *TransactionHandler) HandleListTransactions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := parseTransactionFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	list, err := h.service.List(filter)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// HandleTransactionByID gets a single transaction
+// @Summary Get transaction by ID
+// @Description Get a transaction header with its line items, including product name and unit price at time of sale
+// @Tags transactions
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Success 200 {object} models.Transaction
+// @Failure 404 {string} string "Transaction not found"
+// @Router /transactions/{id} [get]
+func (h *TransactionHandler) HandleTransactionByID(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/transactions/"), "/")
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	action := ""
+	if len(parts) > 1 {
+		action = strings.Join(parts[1:], "/")
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		h.GetByID(w, r, id)
+	case action == "void" && r.Method == http.MethodPost:
+		h.Void(w, r, id)
+	case action == "returns" && r.Method == http.MethodPost:
+		h.Return(w, r, id)
+	case action == "" || action == "void" || action == "returns":
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *TransactionHandler) GetByID(w http.ResponseWriter, r *http.Request, id int) {
+	transaction, err := h.service.GetByID(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(transaction)
+}
+
+// Void voids a whole transaction
+// @Summary Void a transaction
+// @Description Void everything not yet returned from a sale made on the current business day whose cashier shift is not closed yet; otherwise use a return. Creates a linked negative "void" transaction and restocks the items. For a customer sale, points paid with are given back first, then the part paid on credit is taken off the customer's credit balance, the rest is refunded in refund_method, and the points earned are taken back. Manager or owner only; the caller is recorded as approver. The document goes to the sale's cashier shift while it is open, otherwise to the manager's open shift, which a cash refund then requires.
+// @Tags transactions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param request body models.VoidRequest true "Void reason"
+// @Success 201 {object} models.Transaction
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Transaction not found"
+// @Failure 409 {string} string "Transaction already voided"
+// @Router /transactions/{id}/void [post]
+func (h *TransactionHandler) Void(w http.ResponseWriter, r *http.Request, id int) {
+	var req models.VoidRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	void, err := h.service.Void(id, req, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(void)
+}
+
+// Return returns part of a transaction
+// @Summary Return items from a transaction
+// @Description Return quantities of individual line items, in the unit each line was sold in. Creates a linked negative "return" transaction valued pro rata to the original line and restocks the items. For a customer sale, points paid with are given back first, then the part paid on credit is taken off the customer's credit balance, and earned points are taken back pro rata. Manager or owner only; the caller is recorded as approver. The document goes to the sale's cashier shift while it is open, otherwise to the manager's open shift, which a cash refund then requires.
+// @Tags transactions
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Transaction ID"
+// @Param request body models.ReturnRequest true "Returned line items"
+// @Success 201 {object} models.Transaction
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Transaction not found"
+// @Failure 409 {string} string "Transaction already voided"
+// @Router /transactions/{id}/returns [post]
+func (h *TransactionHandler) Return(w http.ResponseWriter, r *http.Request, id int) {
+	var req models.ReturnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ret, err := h.service.Return(id, req, currentUser(r))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(ret)
+}
+
+func parseTransactionFilter(q url.Values) (models.TransactionFilter, error) {
+	var filter models.TransactionFilter
+	var err error
+
+	if filter.StartDate, err = queryDate(q, "start_date"); err != nil {
+		return filter, err
+	}
+	if filter.EndDate, err = queryDate(q, "end_date"); err != nil {
+		return filter, err
+	}
+	if filter.ProductID, err = queryInt(q, "product_id"); err != nil {
+		return filter, err
+	}
+	if filter.CustomerID, err = queryInt(q, "customer_id"); err != nil {
+		return filter, err
+	}
+	if filter.ShiftID, err = queryInt(q, "shift_id"); err != nil {
+		return filter, err
+	}
+	filter.Type = q.Get("type")
+	if filter.MinTotal, err = queryInt(q, "min_total"); err != nil {
+		return filter, err
+	}
+	if filter.MaxTotal, err = queryInt(q, "max_total"); err != nil {
+		return filter, err
+	}
+
+	page, err := queryInt(q, "page")
+	if err != nil {
+		return filter, err
+	}
+	if page != nil {
+		filter.Page = *page
+	}
+	pageSize, err := queryInt(q, "page_size")
+	if err != nil {
+		return filter, err
+	}
+	if pageSize != nil {
+		filter.PageSize = *pageSize
+	}
+
+	return filter, nil
+}
+
 // HandleCreateTransaction creates a new transaction
 // @Summary Create a new transaction
-// @Description Create a new transaction with details
+// @Description Create a new transaction with details and payments. Subtotals and total are priced server-side from products and stock is deducted. Quantities may be decimal (e.g. 0.75 kg); a line may name one of the product's units (e.g. carton), priced at that unit's price or factor times the base price, and stock is deducted in the base unit. Payments (cash, card, ewallet, qris, points, credit; split allowed) must cover the total; for cash send the amount handed over and the change is returned. A cash tender requires the cashier to have an open shift. With customer_id the customer earns loyalty points on the part of the total not paid with points, plus their tier bonus; a points payment gives the rupiah amount, a multiple of the value of one point, and needs enough points. A credit payment puts that amount on the customer's credit (kasbon) account, within their credit limit.
 // @Tags transactions
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param transaction body models.Transaction true "Transaction Data"
 // @Success 201 {object} map[string]interface{}
 // @Failure 400 {string} string "Invalid request body"
+// @Failure 409 {object} models.InsufficientStockError
 // @Failure 500 {string} string "Internal Server Error"
 // @Router /transactions [post]
 func (h *TransactionHandler) HandleCreateTransaction(w http.ResponseWriter, r *http.Request) {
@@ -39,8 +255,14 @@ func (h *TransactionHandler) HandleCreateTransaction(w http.ResponseWriter, r *h
 		return
 	}
 
+	transaction.CashierID = &currentUser(r).ID
 	if err := h.service.CreateTransaction(&transaction); err != nil {
-		http.Error(w, "Failed to create transaction: "+err.Error(), http.StatusInternalServerError)
+		// An unknown product in the cart is a bad request, not a missing resource
+		if errors.Is(err, models.ErrProductNotFound) {
+			http.Error(w, "Failed to create transaction: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeError(w, err)
 		return
 	}
 
@@ -52,28 +274,3 @@ func (h *TransactionHandler) HandleCreateTransaction(w http.ResponseWriter, r *h
 		"data":    transaction, // Returns transaction with ID populated
 	})
 }
-
-// HandleDailyReport gets the daily sales report
-// @Summary Get daily sales report
-// @Description Get total revenue, total transactions, and best selling product for today
-// @Tags reports
-// @Produce json
-// @Success 200 {object} models.DailyReport
-// @Failure 500 {string} string "Internal Server Error"
-// @Router /report/hari-ini [get]
-func (h *TransactionHandler) HandleDailyReport(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	// For "hari-ini", we use time.Now()
-	report, err := h.service.GetDailyReport(time.Now())
-	if err != nil {
-		http.Error(w, "Failed to get daily report: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(report)
-}
@@ -1,15 +1,21 @@
 package main
 
 import (
+	"bufio"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata" // Store timezone must load on images without tzdata
 
 	"go-kasir-api/database"
 	"go-kasir-api/handlers"
+	"go-kasir-api/models"
 	"go-kasir-api/repositories"
 	"go-kasir-api/services"
 
@@ -17,6 +23,7 @@ import (
 
 	viper "github.com/spf13/viper"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"golang.org/x/term"
 )
 
 // @title Go Kasir API
@@ -34,6 +41,11 @@ import (
 // @host localhost:8080
 // @BasePath /api
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Type "Bearer" followed by a space and the token from /auth/login.
+
 func main() {
 	// 1. Initialize Viper and Load Config
 	viper.AutomaticEnv()
@@ -47,19 +59,110 @@ func main() {
 	}
 
 	type Config struct {
-		Port   string `mapstructure:"PORT"`
-		DBConn string `mapstructure:"DB_CONN"`
+		Port              string `mapstructure:"PORT"`
+		DBConn            string `mapstructure:"DB_CONN"`
+		StoreTimezone     string `mapstructure:"STORE_TIMEZONE"`
+		BusinessDayCutoff string `mapstructure:"BUSINESS_DAY_CUTOFF"`
+		AuthSecret        string `mapstructure:"AUTH_SECRET"`
+		TokenTTL          string `mapstructure:"TOKEN_TTL"`
+		AlertNotifier     string `mapstructure:"ALERT_NOTIFIER"`
+		AlertWebhookURL   string `mapstructure:"ALERT_WEBHOOK_URL"`
+		AlertEmailFrom    string `mapstructure:"ALERT_EMAIL_FROM"`
+		AlertEmailTo      string `mapstructure:"ALERT_EMAIL_TO"`
+		AlertOutboxDir    string `mapstructure:"ALERT_OUTBOX_DIR"`
+		SMTPAddr          string `mapstructure:"SMTP_ADDR"`
+		SMTPUsername      string `mapstructure:"SMTP_USERNAME"`
+		SMTPPassword      string `mapstructure:"SMTP_PASSWORD"`
+		LoyaltyEarnAmount string `mapstructure:"LOYALTY_EARN_AMOUNT"`
+		LoyaltyPointValue string `mapstructure:"LOYALTY_POINT_VALUE"`
 	}
 
 	config := Config{
-		Port:   viper.GetString("PORT"),
-		DBConn: viper.GetString("DB_CONN"),
+		Port:              viper.GetString("PORT"),
+		DBConn:            viper.GetString("DB_CONN"),
+		StoreTimezone:     viper.GetString("STORE_TIMEZONE"),
+		BusinessDayCutoff: viper.GetString("BUSINESS_DAY_CUTOFF"),
+		AuthSecret:        viper.GetString("AUTH_SECRET"),
+		TokenTTL:          viper.GetString("TOKEN_TTL"),
+		AlertNotifier:     viper.GetString("ALERT_NOTIFIER"),
+		AlertWebhookURL:   viper.GetString("ALERT_WEBHOOK_URL"),
+		AlertEmailFrom:    viper.GetString("ALERT_EMAIL_FROM"),
+		AlertEmailTo:      viper.GetString("ALERT_EMAIL_TO"),
+		AlertOutboxDir:    viper.GetString("ALERT_OUTBOX_DIR"),
+		SMTPAddr:          viper.GetString("SMTP_ADDR"),
+		SMTPUsername:      viper.GetString("SMTP_USERNAME"),
+		SMTPPassword:      viper.GetString("SMTP_PASSWORD"),
+		LoyaltyEarnAmount: viper.GetString("LOYALTY_EARN_AMOUNT"),
+		LoyaltyPointValue: viper.GetString("LOYALTY_POINT_VALUE"),
 	}
 
 	// Default port if not set
 	if config.Port == "" {
 		config.Port = "8080"
 	}
+	if config.StoreTimezone == "" {
+		config.StoreTimezone = "Asia/Jakarta"
+	}
+
+	if config.TokenTTL == "" {
+		config.TokenTTL = "12h"
+	}
+	if config.LoyaltyEarnAmount == "" {
+		config.LoyaltyEarnAmount = "10000"
+	}
+	if config.LoyaltyPointValue == "" {
+		config.LoyaltyPointValue = "100"
+	}
+
+	businessDay, err := services.NewBusinessDay(config.StoreTimezone, config.BusinessDayCutoff)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tokenTTL, err := time.ParseDuration(config.TokenTTL)
+	if err != nil {
+		log.Fatal("Invalid TOKEN_TTL:", err)
+	}
+
+	// Loyalty: one point per LOYALTY_EARN_AMOUNT spent, each point worth
+	// LOYALTY_POINT_VALUE when paying; 0 turns earning or redeeming off.
+	var loyalty services.LoyaltyRules
+	if loyalty.EarnAmount, err = strconv.Atoi(config.LoyaltyEarnAmount); err != nil || loyalty.EarnAmount < 0 {
+		log.Fatal("Invalid LOYALTY_EARN_AMOUNT: ", config.LoyaltyEarnAmount)
+	}
+	if loyalty.PointValue, err = strconv.Atoi(config.LoyaltyPointValue); err != nil || loyalty.PointValue < 0 {
+		log.Fatal("Invalid LOYALTY_POINT_VALUE: ", config.LoyaltyPointValue)
+	}
+
+	// Low-stock alerts: log (default), webhook, email or none
+	var notifier services.Notifier
+	switch config.AlertNotifier {
+	case "", "log":
+		notifier = services.NewLogNotifier(nil)
+	case "webhook":
+		if config.AlertWebhookURL == "" {
+			log.Fatal("ALERT_WEBHOOK_URL is required for ALERT_NOTIFIER=webhook")
+		}
+		notifier = services.NewWebhookNotifier(config.AlertWebhookURL)
+	case "email":
+		if config.AlertEmailFrom == "" || config.AlertEmailTo == "" {
+			log.Fatal("ALERT_EMAIL_FROM and ALERT_EMAIL_TO are required for ALERT_NOTIFIER=email")
+		}
+		var sender services.EmailSender
+		if config.SMTPAddr != "" {
+			sender = services.NewSMTPSender(config.SMTPAddr, config.SMTPUsername, config.SMTPPassword)
+		} else {
+			// No SMTP server configured: write emails to a local outbox instead
+			if config.AlertOutboxDir == "" {
+				config.AlertOutboxDir = "outbox"
+			}
+			sender = services.NewOutboxSender(config.AlertOutboxDir)
+		}
+		notifier = services.NewEmailNotifier(sender, config.AlertEmailFrom, strings.Split(config.AlertEmailTo, ","))
+	case "none":
+	default:
+		log.Fatalf("Invalid ALERT_NOTIFIER %q, expected log, webhook, email or none", config.AlertNotifier)
+	}
 
 	// 2. Setup Database
 	if config.DBConn == "" {
@@ -72,27 +175,114 @@ func main() {
 	}
 	defer db.Close()
 
+	// `main migrate up|down [n]|status` manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(db, os.Args[2:]); err != nil {
+			log.Fatal("Migration failed:", err)
+		}
+		return
+	}
+
 	// Run Auto-Migration
 	if err := database.RunMigrations(db); err != nil {
 		log.Fatal("Failed to run migrations:", err)
 	}
 
+	// User
+	userRepo := repositories.NewUserRepository(db)
+	userService := services.NewUserService(userRepo)
+
+	// `main user add <username> <role>` creates a user (e.g. the first owner) and exits
+	if len(os.Args) > 1 && os.Args[1] == "user" {
+		if err := runUserCommand(userService, os.Args[2:]); err != nil {
+			log.Fatal("User command failed:", err)
+		}
+		return
+	}
+
+	if len(config.AuthSecret) < 32 {
+		log.Fatal("AUTH_SECRET must be set to at least 32 characters")
+	}
+
 	// 3. Initialize Injectors (Repositories, Services, Handlers)
+	// Auth
+	tokenService := services.NewTokenService(config.AuthSecret, tokenTTL)
+	authService := services.NewAuthService(userRepo, tokenService)
+	authHandler := handlers.NewAuthHandler(authService)
+	userHandler := handlers.NewUserHandler(userService)
+
+	// Tax
+	taxRateRepo := repositories.NewTaxRateRepository(db)
+	taxRateService := services.NewTaxRateService(taxRateRepo)
+	taxRateHandler := handlers.NewTaxRateHandler(taxRateService)
+
 	// Product
 	productRepo := repositories.NewProductRepository(db)
-	productService := services.NewProductService(productRepo)
-	productHandler := handlers.NewProductHandler(productService)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	productService := services.NewProductService(productRepo, categoryRepo, taxRateRepo)
+	stockMovementRepo := repositories.NewStockMovementRepository(db)
+	stockService := services.NewStockService(stockMovementRepo, productRepo, businessDay)
+	productHandler := handlers.NewProductHandler(productService, stockService)
+	productImportRepo := repositories.NewProductImportRepository(db)
+	productImportService := services.NewProductImportService(productImportRepo, productService, productRepo, categoryRepo)
+	if err := productImportService.FailUnfinished(); err != nil {
+		log.Fatal("Failed to recover product imports:", err)
+	}
+	productImportHandler := handlers.NewProductImportHandler(productImportService)
 
 	// Category
-	categoryRepo := repositories.NewCategoryRepository(db)
-	categoryService := services.NewCategoryService(categoryRepo)
+	categoryService := services.NewCategoryService(categoryRepo, productRepo, taxRateRepo)
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 
+	// Modifier
+	modifierGroupRepo := repositories.NewModifierGroupRepository(db)
+	modifierGroupService := services.NewModifierGroupService(modifierGroupRepo)
+	modifierGroupHandler := handlers.NewModifierGroupHandler(modifierGroupService)
+
+	// Stock count
+	stockCountRepo := repositories.NewStockCountRepository(db)
+	stockCountService := services.NewStockCountService(stockCountRepo, productRepo)
+	stockCountHandler := handlers.NewStockCountHandler(stockCountService)
+
+	// Purchasing
+	supplierRepo := repositories.NewSupplierRepository(db)
+	supplierService := services.NewSupplierService(supplierRepo)
+	supplierHandler := handlers.NewSupplierHandler(supplierService)
+	purchaseOrderRepo := repositories.NewPurchaseOrderRepository(db)
+	purchaseOrderService := services.NewPurchaseOrderService(purchaseOrderRepo, supplierRepo, productRepo)
+	purchaseOrderHandler := handlers.NewPurchaseOrderHandler(purchaseOrderService)
+
+	// Promotion
+	promotionRepo := repositories.NewPromotionRepository(db)
+	promotionService := services.NewPromotionService(promotionRepo)
+	promotionHandler := handlers.NewPromotionHandler(promotionService)
+
+	// Customer
+	customerRepo := repositories.NewCustomerRepository(db)
+	customerService := services.NewCustomerService(customerRepo)
+	creditRepo := repositories.NewCreditRepository(db)
+	creditService := services.NewCreditService(creditRepo, customerRepo, businessDay)
+	customerHandler := handlers.NewCustomerHandler(customerService, creditService)
+	creditHandler := handlers.NewCreditHandler(creditService)
+	loyaltyTierRepo := repositories.NewLoyaltyTierRepository(db)
+	loyaltyTierService := services.NewLoyaltyTierService(loyaltyTierRepo)
+	loyaltyTierHandler := handlers.NewLoyaltyTierHandler(loyaltyTierService)
+
 	// Transaction
-	transactionRepo := repositories.NewTransactionRepository(db)
-	transactionService := services.NewTransactionService(transactionRepo)
+	transactionRepo := repositories.NewTransactionRepository(db, productRepo)
+	transactionService := services.NewTransactionService(transactionRepo, productRepo, modifierGroupRepo, promotionRepo, taxRateRepo, customerRepo, loyalty, businessDay, notifier)
 	transactionHandler := handlers.NewTransactionHandler(transactionService)
 
+	// Shift
+	shiftRepo := repositories.NewShiftRepository(db)
+	shiftService := services.NewShiftService(shiftRepo)
+	shiftHandler := handlers.NewShiftHandler(shiftService)
+
+	// Report
+	reportRepo := repositories.NewReportRepository(db)
+	reportService := services.NewReportService(reportRepo, businessDay)
+	reportHandler := handlers.NewReportHandler(reportService)
+
 	// 4. Setup Router
 	mux := http.NewServeMux()
 
@@ -107,17 +297,85 @@ func main() {
 		})
 	})
 
+	// Access rules: everyone logged in can read the catalog and ring up sales,
+	// managers change the catalog, purchase stock, void and see reports, owners manage users.
+	anyRole := handlers.RoleRules{"*": models.AllRoles}
+	readAnyWriteManager := handlers.RoleRules{http.MethodGet: models.AllRoles, "*": models.ManagerRoles}
+	managerOnly := handlers.RoleRules{"*": models.ManagerRoles}
+	ownerOnly := handlers.RoleRules{"*": models.OwnerRoles}
+	// Anyone can count stock; only managers open, post or cancel a count.
+	countAnyPostManager := handlers.RoleRules{http.MethodGet: models.AllRoles, http.MethodPut: models.AllRoles, "*": models.ManagerRoles}
+
+	// Auth Routes
+	mux.HandleFunc("/api/auth/login", authHandler.HandleLogin)
+	mux.HandleFunc("/api/auth/me", authHandler.Protect(anyRole, authHandler.HandleMe))
+
+	// User Routes
+	mux.HandleFunc("/api/users", authHandler.Protect(ownerOnly, userHandler.HandleUsers))
+	mux.HandleFunc("/api/users/", authHandler.Protect(ownerOnly, userHandler.HandleUserByID))
+
 	// Product Routes
-	mux.HandleFunc("/api/products", productHandler.HandleProducts)
-	mux.HandleFunc("/api/products/", productHandler.HandleProductByID)
+	mux.HandleFunc("/api/products", authHandler.Protect(readAnyWriteManager, productHandler.HandleProducts))
+	mux.HandleFunc("/api/products/", authHandler.Protect(readAnyWriteManager, productHandler.HandleProductByID))
+	mux.HandleFunc("/api/products/import", authHandler.Protect(managerOnly, productImportHandler.HandleImports))
+	mux.HandleFunc("/api/products/import/", authHandler.Protect(managerOnly, productImportHandler.HandleImportByID))
+	mux.HandleFunc("/api/products/export", authHandler.Protect(managerOnly, productImportHandler.HandleExport))
+
+	// Inventory Routes
+	mux.HandleFunc("/api/inventory/low-stock", authHandler.Protect(readAnyWriteManager, productHandler.HandleLowStock))
 
 	// Category Routes
-	mux.HandleFunc("/api/categories", categoryHandler.HandleCategories)
-	mux.HandleFunc("/api/categories/", categoryHandler.HandleCategoryByID)
+	mux.HandleFunc("/api/categories", authHandler.Protect(readAnyWriteManager, categoryHandler.HandleCategories))
+	mux.HandleFunc("/api/categories/", authHandler.Protect(readAnyWriteManager, categoryHandler.HandleCategoryByID))
+
+	// Modifier Routes
+	mux.HandleFunc("/api/modifier-groups", authHandler.Protect(readAnyWriteManager, modifierGroupHandler.HandleModifierGroups))
+	mux.HandleFunc("/api/modifier-groups/", authHandler.Protect(readAnyWriteManager, modifierGroupHandler.HandleModifierGroupByID))
+
+	// Stock Count Routes
+	mux.HandleFunc("/api/stock-counts", authHandler.Protect(readAnyWriteManager, stockCountHandler.HandleStockCounts))
+	mux.HandleFunc("/api/stock-counts/", authHandler.Protect(countAnyPostManager, stockCountHandler.HandleStockCountByID))
+
+	// Tax Rate Routes
+	mux.HandleFunc("/api/tax-rates", authHandler.Protect(readAnyWriteManager, taxRateHandler.HandleTaxRates))
+	mux.HandleFunc("/api/tax-rates/", authHandler.Protect(readAnyWriteManager, taxRateHandler.HandleTaxRateByID))
+
+	// Purchasing Routes
+	mux.HandleFunc("/api/suppliers", authHandler.Protect(managerOnly, supplierHandler.HandleSuppliers))
+	mux.HandleFunc("/api/suppliers/", authHandler.Protect(managerOnly, supplierHandler.HandleSupplierByID))
+	mux.HandleFunc("/api/purchase-orders", authHandler.Protect(managerOnly, purchaseOrderHandler.HandlePurchaseOrders))
+	mux.HandleFunc("/api/purchase-orders/", authHandler.Protect(managerOnly, purchaseOrderHandler.HandlePurchaseOrderByID))
+
+	// Promotion Routes
+	mux.HandleFunc("/api/promotions", authHandler.Protect(readAnyWriteManager, promotionHandler.HandlePromotions))
+	mux.HandleFunc("/api/promotions/", authHandler.Protect(readAnyWriteManager, promotionHandler.HandlePromotionByID))
+
+	// Customer Routes
+	mux.HandleFunc("/api/customers", authHandler.Protect(anyRole, customerHandler.HandleCustomers))
+	mux.HandleFunc("/api/customers/", authHandler.Protect(readAnyWriteManager, customerHandler.HandleCustomerByID))
+	mux.HandleFunc("/api/credit-payments", authHandler.Protect(anyRole, creditHandler.HandleCreditPayments))
+	mux.HandleFunc("/api/loyalty-tiers", authHandler.Protect(readAnyWriteManager, loyaltyTierHandler.HandleLoyaltyTiers))
+	mux.HandleFunc("/api/loyalty-tiers/", authHandler.Protect(readAnyWriteManager, loyaltyTierHandler.HandleLoyaltyTierByID))
 
 	// Transaction Routes
-	mux.HandleFunc("/api/transactions", transactionHandler.HandleCreateTransaction)
-	mux.HandleFunc("/api/report/hari-ini", transactionHandler.HandleDailyReport)
+	mux.HandleFunc("/api/transactions", authHandler.Protect(anyRole, transactionHandler.HandleTransactions))
+	mux.HandleFunc("/api/transactions/", authHandler.Protect(readAnyWriteManager, transactionHandler.HandleTransactionByID))
+
+	// Shift Routes: cashiers run their own shifts, managers see and close all of them
+	mux.HandleFunc("/api/shifts", authHandler.Protect(anyRole, shiftHandler.HandleShifts))
+	mux.HandleFunc("/api/shifts/", authHandler.Protect(anyRole, shiftHandler.HandleShiftByID))
+
+	// Report Routes
+	mux.HandleFunc("/api/report", authHandler.Protect(managerOnly, reportHandler.HandleSalesReport))
+	mux.HandleFunc("/api/report/hari-ini", authHandler.Protect(managerOnly, reportHandler.HandleDailyReport))
+	mux.HandleFunc("/api/report/promotions", authHandler.Protect(managerOnly, reportHandler.HandlePromotionReport))
+	mux.HandleFunc("/api/report/tax", authHandler.Protect(managerOnly, reportHandler.HandleTaxReport))
+	mux.HandleFunc("/api/report/modifiers", authHandler.Protect(managerOnly, reportHandler.HandleModifierReport))
+	mux.HandleFunc("/api/report/margin", authHandler.Protect(managerOnly, reportHandler.HandleMarginReport))
+	mux.HandleFunc("/api/report/purchase-orders/outstanding", authHandler.Protect(managerOnly, reportHandler.HandleOutstandingPurchaseReport))
+	mux.HandleFunc("/api/report/customers", authHandler.Protect(managerOnly, reportHandler.HandleCustomerReport))
+	mux.HandleFunc("/api/report/receivables", authHandler.Protect(managerOnly, reportHandler.HandleReceivablesReport))
+	mux.HandleFunc("/api/report/supplier-purchases", authHandler.Protect(managerOnly, reportHandler.HandleSupplierPurchaseReport))
 
 	// Package specific routes (Legacy - can be removed if fully migrated)
 	// product.RegisterHandlers(mux) // Legacy removed
@@ -133,4 +391,79 @@ func main() {
 	if err := server.ListenAndServe(); err != nil {
 		log.Fatal("Server failed to start:", err)
 	}
-}
\ No newline at end of file
+}
+
+func runMigrateCommand(db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: migrate up|down [steps]|status")
+	}
+
+	switch args[0] {
+	case "up":
+		return database.RunMigrations(db)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid steps %q", args[1])
+			}
+			steps = n
+		}
+		return database.RollbackMigrations(db, steps)
+	case "status":
+		status, err := database.GetMigrationStatus(db)
+		if err != nil {
+			return err
+		}
+		for _, s := range status {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%04d  %-45s %s\n", s.Version, s.Name, appliedAt)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+}
+
+func runUserCommand(userService *services.UserService, args []string) error {
+	if len(args) != 3 || args[0] != "add" {
+		return fmt.Errorf("usage: user add <username> <owner|manager|cashier>")
+	}
+
+	password, err := readPassword()
+	if err != nil {
+		return err
+	}
+
+	user, err := userService.Create(models.UserInput{
+		Username: args[1],
+		Role:     args[2],
+		Password: password,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s user %q (id %d)\n", user.Role, user.Username, user.ID)
+	return nil
+}
+
+// readPassword membaca password dari stdin. Di terminal input tidak
+// ditampilkan; dari pipe dibaca satu baris.
+func readPassword() (string, error) {
+	fmt.Print("Password: ")
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		password, err := term.ReadPassword(fd)
+		fmt.Println()
+		return string(password), err
+	}
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		return "", err
+	}
+	return strings.TrimRight(password, "\r\n"), nil
+}
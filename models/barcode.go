@@ -0,0 +1,29 @@
+package models
+
+const (
+	BarcodeEAN13    = "ean13"
+	BarcodeUPCA     = "upca"
+	BarcodeEAN8     = "ean8"
+	BarcodePLU      = "plu"
+	BarcodeInternal = "internal"
+)
+
+// ProductBarcode adalah satu barcode produk. Type kosong dideteksi dari kode:
+// 8/12/13 digit dengan check digit valid menjadi ean8/upca/ean13, selain itu
+// internal. PLU adalah kode barang 5 digit di label timbangan.
+type ProductBarcode struct {
+	ID   int    `json:"id"`
+	Code string `json:"code"`
+	Type string `json:"type"`
+}
+
+// BarcodeLookup adalah hasil scan. Untuk label timbangan (prefix 20-29),
+// Weight atau Price berisi nilai yang tercetak di label dan Quantity sudah
+// dihitung darinya; selain itu Quantity 1.
+type BarcodeLookup struct {
+	Barcode  string   `json:"barcode"`
+	Product  Product  `json:"product"`
+	Quantity Quantity `json:"quantity" swaggertype:"number"`
+	Weight   *int     `json:"weight,omitempty"`
+	Price    *int     `json:"price,omitempty"`
+}
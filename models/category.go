@@ -1,6 +1,7 @@
 package models
 
 type Category struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	TaxRateID *int   `json:"tax_rate_id"`
 }
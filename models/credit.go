@@ -0,0 +1,99 @@
+package models
+
+import "time"
+
+const (
+	CreditCharge  = "charge"
+	CreditPayment = "payment"
+	CreditVoid    = "void"
+	CreditReturn  = "return"
+)
+
+// CreditEntry adalah satu baris ledger kasbon pelanggan. Amount bertanda:
+// positif menambah utang (charge dari tender credit saat checkout), negatif
+// menguranginya (pembayaran cicilan, void dan retur penjualan kredit).
+type CreditEntry struct {
+	ID            int       `json:"id"`
+	CustomerID    int       `json:"customer_id"`
+	Type          string    `json:"type"`
+	Amount        int       `json:"amount"`
+	BalanceAfter  int       `json:"balance_after"`
+	TransactionID *int      `json:"transaction_id,omitempty"`
+	Method        string    `json:"method,omitempty"`
+	Reference     string    `json:"reference,omitempty"`
+	Note          string    `json:"note,omitempty"`
+	UserID        *int      `json:"user_id,omitempty"`
+	ShiftID       *int      `json:"shift_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// CreditPaymentRequest adalah pembayaran kasbon, boleh sebagian, dengan
+// metode cash, card, ewallet atau qris.
+type CreditPaymentRequest struct {
+	CustomerID int    `json:"customer_id"`
+	Amount     int    `json:"amount"`
+	Method     string `json:"method"`
+	Reference  string `json:"reference"`
+	Note       string `json:"note"`
+}
+
+// OpenCharge adalah bagian charge yang belum terbayar. Pembayaran dianggap
+// melunasi charge tertua lebih dulu, jadi yang terbuka selalu charge terbaru.
+type OpenCharge struct {
+	CustomerID    int       `json:"-"`
+	TransactionID *int      `json:"transaction_id,omitempty"`
+	ChargedAt     time.Time `json:"charged_at"`
+	Amount        int       `json:"amount"`
+	AgeDays       int       `json:"age_days"`
+}
+
+// CreditAging membagi utang terbuka menurut umur charge dalam hari bisnis.
+type CreditAging struct {
+	Days0To30  int `json:"days_0_30"`
+	Days31To60 int `json:"days_31_60"`
+	Over60     int `json:"days_over_60"`
+}
+
+// CreditStatement adalah rekening koran kasbon satu pelanggan untuk
+// [PeriodStart, PeriodEnd); tanpa PeriodStart berarti sejak entri pertama.
+// OpeningBalance ditambah Charges dikurangi Credits sama dengan
+// ClosingBalance. Balance, Available, OpenCharges dan Aging adalah posisi
+// saat ini.
+type CreditStatement struct {
+	CustomerID     int           `json:"customer_id"`
+	Name           string        `json:"name"`
+	CreditLimit    int           `json:"credit_limit"`
+	Balance        int           `json:"balance"`
+	Available      int           `json:"available"`
+	PeriodStart    *time.Time    `json:"period_start,omitempty"`
+	PeriodEnd      time.Time     `json:"period_end"`
+	OpeningBalance int           `json:"opening_balance"`
+	Charges        int           `json:"charges"`
+	Credits        int           `json:"credits"`
+	ClosingBalance int           `json:"closing_balance"`
+	Entries        []CreditEntry `json:"entries"`
+	OpenCharges    []OpenCharge  `json:"open_charges"`
+	Aging          CreditAging   `json:"aging"`
+}
+
+// ReceivablesReport adalah posisi piutang kasbon per AsOf (tanggal bisnis).
+// Total adalah jumlah utang pelanggan, StoreCredit jumlah saldo titipan.
+type ReceivablesReport struct {
+	AsOf        time.Time            `json:"as_of"`
+	Total       int                  `json:"total"`
+	StoreCredit int                  `json:"store_credit"`
+	Aging       CreditAging          `json:"aging"`
+	Customers   []CustomerReceivable `json:"customers"`
+}
+
+// CustomerReceivable adalah saldo kasbon satu pelanggan beserta umurnya.
+type CustomerReceivable struct {
+	CustomerID     int         `json:"customer_id"`
+	Name           string      `json:"name"`
+	Phone          string      `json:"phone"`
+	CreditLimit    int         `json:"credit_limit"`
+	Balance        int         `json:"balance"`
+	Aging          CreditAging `json:"aging"`
+	OldestChargeAt *time.Time  `json:"oldest_charge_at,omitempty"`
+	LastPaymentAt  *time.Time  `json:"last_payment_at,omitempty"`
+}
@@ -0,0 +1,111 @@
+package models
+
+import "time"
+
+const (
+	PointsEarn       = "earn"
+	PointsRedeem     = "redeem"
+	PointsVoid       = "void"
+	PointsReturn     = "return"
+	PointsAdjustment = "adjustment"
+)
+
+// Customer adalah pelanggan terdaftar (member). Points adalah saldo poin
+// loyalty yang hanya berubah lewat ledger poin, CreditBalance utang kasbon
+// yang hanya berubah lewat ledger kredit (negatif berarti saldo titipan).
+// CreditLimit hanya bisa diubah lewat update. LifetimeValue (penjualan
+// bersih setelah void dan retur), Visits, LastPurchaseAt dan Tier dihitung
+// dari transaksinya dan diabaikan saat create/update.
+type Customer struct {
+	ID             int          `json:"id"`
+	Name           string       `json:"name"`
+	Phone          string       `json:"phone"`
+	MemberCard     string       `json:"member_card"`
+	Email          string       `json:"email"`
+	Points         int          `json:"points"`
+	CreditLimit    int          `json:"credit_limit"`
+	CreditBalance  int          `json:"credit_balance"`
+	Active         bool         `json:"active"`
+	CreatedAt      time.Time    `json:"created_at"`
+	LifetimeValue  int          `json:"lifetime_value"`
+	Visits         int          `json:"visits"`
+	LastPurchaseAt *time.Time   `json:"last_purchase_at,omitempty"`
+	Tier           *LoyaltyTier `json:"tier,omitempty"`
+}
+
+// LoyaltyTier adalah tingkat member. Pelanggan masuk tier dengan MinSpend
+// tertinggi yang tidak melebihi LifetimeValue-nya; BonusPercent menambah poin
+// yang didapat, mis. 50 berarti 1,5x.
+type LoyaltyTier struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	MinSpend     int    `json:"min_spend"`
+	BonusPercent int    `json:"bonus_percent"`
+}
+
+// CustomerFilter adalah filter untuk GET /api/customers. Query dicocokkan
+// dengan potongan nama, awalan nomor telepon atau nomor kartu member persis.
+type CustomerFilter struct {
+	Query    string
+	Page     int
+	PageSize int
+}
+
+type CustomerList struct {
+	Data       []Customer `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// LoyaltyPointEntry adalah satu baris ledger poin pelanggan. Points bertanda:
+// positif menambah saldo, negatif mengurangi.
+type LoyaltyPointEntry struct {
+	ID            int       `json:"id"`
+	CustomerID    int       `json:"customer_id"`
+	Type          string    `json:"type"`
+	Points        int       `json:"points"`
+	BalanceAfter  int       `json:"balance_after"`
+	TransactionID *int      `json:"transaction_id,omitempty"`
+	Note          string    `json:"note,omitempty"`
+	UserID        *int      `json:"user_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type LoyaltyPointList struct {
+	Data       []LoyaltyPointEntry `json:"data"`
+	Pagination Pagination          `json:"pagination"`
+}
+
+// PointAdjustmentRequest menambah (positif) atau mengurangi (negatif) poin
+// secara manual; Note wajib diisi.
+type PointAdjustmentRequest struct {
+	Points int    `json:"points"`
+	Note   string `json:"note"`
+}
+
+// CustomerReport merangkum belanja pelanggan terdaftar pada
+// [PeriodStart, PeriodEnd). Nilai penjualan sudah dikurangi void dan retur.
+type CustomerReport struct {
+	PeriodStart        time.Time       `json:"period_start"`
+	PeriodEnd          time.Time       `json:"period_end"`
+	TotalSales         int             `json:"total_sales"`
+	MemberSales        int             `json:"member_sales"`
+	MemberTransactions int             `json:"member_transactions"`
+	ActiveCustomers    int             `json:"active_customers"`
+	Customers          []CustomerValue `json:"customers"`
+}
+
+// CustomerValue adalah belanja satu pelanggan dalam periode laporan,
+// dibandingkan dengan nilai seumur hidupnya.
+type CustomerValue struct {
+	CustomerID      int        `json:"customer_id"`
+	Name            string     `json:"name"`
+	Tier            string     `json:"tier,omitempty"`
+	Transactions    int        `json:"transactions"`
+	Spend           int        `json:"spend"`
+	AverageBasket   int        `json:"average_basket"`
+	PointsEarned    int        `json:"points_earned"`
+	PointsRedeemed  int        `json:"points_redeemed"`
+	LifetimeValue   int        `json:"lifetime_value"`
+	FirstPurchaseAt *time.Time `json:"first_purchase_at,omitempty"`
+	LastPurchaseAt  *time.Time `json:"last_purchase_at,omitempty"`
+}
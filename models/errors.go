@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrProductNotFound = errors.New("produk tidak ditemukan")
+	ErrProductInUse    = errors.New("produk sudah punya transaksi atau riwayat stok dan tidak bisa dihapus")
+	ErrSKUTaken        = errors.New("sku is already used by another product")
+	ErrBarcodeTaken    = errors.New("barcode is already assigned to another product")
+	ErrBarcodeNotFound = errors.New("no product matches this barcode")
+	ErrVariantExists   = errors.New("a variant with these options already exists")
+
+	ErrTransactionNotFound = errors.New("transaksi tidak ditemukan")
+	ErrTransactionVoided   = errors.New("transaksi sudah di-void")
+
+	ErrPromotionNotFound = errors.New("promotion not found")
+
+	ErrModifierGroupNotFound = errors.New("modifier group not found")
+
+	ErrStockCountNotFound = errors.New("stock count not found")
+	ErrStockCountOpen     = errors.New("another stock count is already open")
+	ErrStockCountClosed   = errors.New("stock count is no longer open")
+
+	ErrSupplierNotFound      = errors.New("supplier not found")
+	ErrSupplierInUse         = errors.New("supplier has purchase orders, deactivate it instead")
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrPurchaseOrderClosed   = errors.New("purchase order is already received or cancelled")
+
+	ErrImportJobNotFound = errors.New("import job not found")
+
+	ErrCustomerNotFound    = errors.New("customer not found")
+	ErrCustomerTaken       = errors.New("phone or member card is already used by another customer")
+	ErrCustomerInUse       = errors.New("customer has transactions, deactivate it instead")
+	ErrLoyaltyTierNotFound = errors.New("loyalty tier not found")
+	ErrLoyaltyTierTaken    = errors.New("another loyalty tier has this name or min_spend")
+
+	ErrShiftNotFound    = errors.New("shift not found")
+	ErrShiftAlreadyOpen = errors.New("cashier already has an open shift")
+	ErrShiftOpen        = errors.New("shift is still open")
+	ErrShiftClosed      = errors.New("shift is already closed")
+
+	ErrTaxRateNotFound = errors.New("tax rate not found")
+	ErrTaxRateInUse    = errors.New("tax rate is still assigned to products or categories")
+
+	ErrUserNotFound       = errors.New("user not found")
+	ErrUsernameTaken      = errors.New("username already exists")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrUnauthorized       = errors.New("authentication required")
+
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrCategoryInUse    = errors.New("category still has products, reassign them first")
+)
+
+// ValidationError menandakan input dari client tidak valid (HTTP 400).
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+func NewValidationError(format string, args ...interface{}) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+type InsufficientStockItem struct {
+	ProductID int      `json:"product_id"`
+	Name      string   `json:"name"`
+	Requested Quantity `json:"requested" swaggertype:"number"`
+	Available Quantity `json:"available" swaggertype:"number"`
+}
+
+// InsufficientStockError dikembalikan saat checkout meminta qty melebihi stok.
+type InsufficientStockError struct {
+	Items []InsufficientStockItem `json:"items"`
+}
+
+func (e *InsufficientStockError) Error() string {
+	names := make([]string, 0, len(e.Items))
+	for _, item := range e.Items {
+		names = append(names, fmt.Sprintf("%s (requested %s, available %s)", item.Name, item.Requested, item.Available))
+	}
+	return "insufficient stock: " + strings.Join(names, ", ")
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// LowStockItem adalah produk yang stoknya di bawah MinStock. OnOrder adalah
+// sisa qty di PO yang masih open/partial; SuggestedOrder adalah ReorderQuantity
+// (atau kekurangannya terhadap MinStock bila lebih besar) dikurangi OnOrder.
+type LowStockItem struct {
+	ProductID       int      `json:"product_id"`
+	Name            string   `json:"name"`
+	Stock           Quantity `json:"stock" swaggertype:"number"`
+	MinStock        Quantity `json:"min_stock" swaggertype:"number"`
+	ReorderQuantity Quantity `json:"reorder_quantity" swaggertype:"number"`
+	OnOrder         Quantity `json:"on_order" swaggertype:"number"`
+	SuggestedOrder  Quantity `json:"suggested_order" swaggertype:"number"`
+}
+
+// LowStockAlert dikirim saat sebuah checkout membuat stok produk turun dari
+// MinStock atau lebih menjadi di bawah MinStock.
+type LowStockAlert struct {
+	ProductID       int       `json:"product_id"`
+	Name            string    `json:"name"`
+	Stock           Quantity  `json:"stock" swaggertype:"number"`
+	MinStock        Quantity  `json:"min_stock" swaggertype:"number"`
+	ReorderQuantity Quantity  `json:"reorder_quantity" swaggertype:"number"`
+	TransactionID   int       `json:"transaction_id"`
+	CreatedAt       time.Time `json:"created_at"`
+}
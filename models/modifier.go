@@ -0,0 +1,66 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ModifierGroup adalah kumpulan tambahan untuk baris penjualan, mis. Sugar
+// level atau Extra shot, yang bisa dipasang ke banyak produk. Grup wajib
+// (Required) butuh paling sedikit MinSelect pilihan; MaxSelect 0 berarti
+// tanpa batas.
+type ModifierGroup struct {
+	ID        int        `json:"id"`
+	Name      string     `json:"name"`
+	Required  bool       `json:"required"`
+	MinSelect int        `json:"min_select"`
+	MaxSelect int        `json:"max_select"`
+	Modifiers []Modifier `json:"modifiers"`
+}
+
+// Modifier adalah satu pilihan dalam grup. Price ditambahkan ke harga per
+// unit baris yang memilihnya.
+type Modifier struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Price  int    `json:"price"`
+	Active bool   `json:"active"`
+}
+
+// UnmarshalJSON membuat active default true bila tidak dikirim.
+func (m *Modifier) UnmarshalJSON(data []byte) error {
+	type plain Modifier
+	p := plain{Active: true}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*m = Modifier(p)
+	return nil
+}
+
+// DetailModifier adalah modifier yang dipilih pada satu baris transaksi. Saat
+// checkout cukup modifier_id; nama grup, nama dan harga disalin dari master.
+type DetailModifier struct {
+	ModifierID *int   `json:"modifier_id"`
+	GroupName  string `json:"group_name"`
+	Name       string `json:"name"`
+	Price      int    `json:"price"`
+}
+
+// ModifierUsage adalah jumlah unit bersih yang memakai satu modifier dan
+// pendapatan dari harga modifier-nya (sebelum diskon).
+type ModifierUsage struct {
+	ModifierID *int     `json:"modifier_id"`
+	GroupName  string   `json:"group_name"`
+	Name       string   `json:"name"`
+	Quantity   Quantity `json:"quantity" swaggertype:"number"`
+	Revenue    int      `json:"revenue"`
+}
+
+// ModifierReport merangkum pemakaian modifier pada [PeriodStart, PeriodEnd),
+// paling sering dipilih dulu.
+type ModifierReport struct {
+	PeriodStart time.Time       `json:"period_start"`
+	PeriodEnd   time.Time       `json:"period_end"`
+	Modifiers   []ModifierUsage `json:"modifiers"`
+}
@@ -0,0 +1,32 @@
+package models
+
+const (
+	PaymentCash    = "cash"
+	PaymentCard    = "card"
+	PaymentEWallet = "ewallet"
+	PaymentQRIS    = "qris"
+	// PaymentPoints membayar dengan poin loyalty pelanggan; Amount adalah
+	// nilai rupiahnya.
+	PaymentPoints = "points"
+	// PaymentCredit menagihkan pembayaran ke kasbon pelanggan.
+	PaymentCredit = "credit"
+)
+
+// Payment adalah satu tender pada transaksi. Untuk tunai, client mengirim
+// uang yang diterima di Amount; server mengisi Tendered, Change dan Amount
+// yang benar-benar dipakai untuk membayar.
+type Payment struct {
+	ID            int    `json:"id"`
+	TransactionID int    `json:"transaction_id"`
+	Method        string `json:"method"`
+	Amount        int    `json:"amount"`
+	Tendered      int    `json:"tendered,omitempty"`
+	Change        int    `json:"change,omitempty"`
+	Reference     string `json:"reference,omitempty"`
+}
+
+type PaymentSummary struct {
+	Method string `json:"method"`
+	Amount int    `json:"amount"`
+	Count  int    `json:"count"`
+}
@@ -1,8 +1,86 @@
 package models
 
 type Product struct {
+	ID              int       `json:"id"`
+	Name            string    `json:"name"`
+	SKU             string    `json:"sku"`
+	Price           int       `json:"price"`
+	Cost            int       `json:"cost"`
+	Stock           Quantity  `json:"stock" swaggertype:"number"`
+	MinStock        Quantity  `json:"min_stock" swaggertype:"number"`
+	ReorderQuantity Quantity  `json:"reorder_quantity" swaggertype:"number"`
+	CategoryID      *int      `json:"category_id"`
+	TaxRateID       *int      `json:"tax_rate_id"`
+	Category        *Category `json:"category,omitempty"`
+	// Unit adalah satuan dasar stok dan harga (default pcs); Decimals adalah
+	// jumlah desimal qty yang diizinkan, mis. 3 untuk barang timbang per kg.
+	// Units adalah satuan lain dengan isinya; pada PUT, nil berarti tidak
+	// diubah.
+	Unit     string        `json:"unit"`
+	Decimals int           `json:"decimals"`
+	Units    []ProductUnit `json:"units"`
+	// Barcodes pada PUT: nil (field tidak dikirim) berarti tidak diubah,
+	// [] menghapus semua barcode.
+	Barcodes []ProductBarcode `json:"barcodes"`
+
+	// Varian adalah produk dengan ParentID dan Options. Produk induk punya
+	// HasVariants, OptionGroups dan Variants, dan tidak bisa dijual langsung.
+	ParentID     *int                 `json:"parent_id,omitempty"`
+	Options      []VariantOption      `json:"options,omitempty"`
+	HasVariants  bool                 `json:"has_variants"`
+	OptionGroups []ProductOptionGroup `json:"option_groups,omitempty"`
+	Variants     []Product            `json:"variants,omitempty"`
+
+	// ModifierGroupIDs adalah grup modifier yang dipasang langsung pada produk;
+	// pada PUT, nil berarti tidak diubah. ModifierGroups berisi grup yang
+	// berlaku, termasuk grup milik produk induk.
+	ModifierGroupIDs []int           `json:"modifier_group_ids"`
+	ModifierGroups   []ModifierGroup `json:"modifier_groups,omitempty"`
+}
+
+// ProductUnit adalah satuan jual atau beli lain, mis. karton berisi 24 pcs.
+// Price kosong berarti Factor kali harga satuan dasar.
+type ProductUnit struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"`
+	Factor Quantity `json:"factor" swaggertype:"number"`
+	Price  *int     `json:"price,omitempty"`
+}
+
+// Urutan daftar produk. Awalan "-" membalik urutan, mis. "-price".
+// Relevance (default saat ada Query) tidak bisa dipakai dengan cursor.
+const (
+	ProductSortRelevance = "relevance"
+	ProductSortID        = "id"
+	ProductSortName      = "name"
+	ProductSortPrice     = "price"
+	ProductSortStock     = "stock"
+)
+
+// ProductFilter adalah filter untuk GET /api/products. Bila After terisi,
+// halaman diambil sesudah produk itu (cursor) dan Page diabaikan.
+type ProductFilter struct {
+	Query      string
+	CategoryID *int
+	MinPrice   *int
+	MaxPrice   *int
+	InStock    bool
+	Sort       string
+	Page       int
+	PageSize   int
+	Cursor     string
+	After      *ProductCursor
+}
+
+// ProductCursor menandai produk terakhir di halaman sebelumnya: urutan yang
+// dipakai, nilai kolom urutannya dan ID-nya.
+type ProductCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v,omitempty"`
 	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	Price int    `json:"price"`
-	Stock int    `json:"stock"`
-}
\ No newline at end of file
+}
+
+type ProductList struct {
+	Data       []Product  `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+const (
+	ImportPending   = "pending"
+	ImportRunning   = "running"
+	ImportCompleted = "completed"
+	ImportFailed    = "failed"
+
+	ImportFormatCSV  = "csv"
+	ImportFormatXLSX = "xlsx"
+)
+
+// ProductImportFields adalah kolom yang dikenali saat impor, sekaligus kolom
+// hasil ekspor. Barcodes dipisah dengan ";".
+var ProductImportFields = []string{
+	"sku", "name", "category", "price", "cost", "stock", "unit", "decimals",
+	"min_stock", "reorder_quantity", "barcodes",
+}
+
+// ProductImportJob adalah satu unggahan impor produk yang diproses di
+// background. Baris dengan SKU yang sudah ada memperbarui produk itu; baris
+// lain membuat produk baru. Pada dry run semua baris hanya divalidasi, dan
+// Created/Updated menghitung baris yang akan dibuat atau diperbarui.
+type ProductImportJob struct {
+	ID         int              `json:"id"`
+	Status     string           `json:"status"`
+	DryRun     bool             `json:"dry_run"`
+	FileName   string           `json:"file_name"`
+	Format     string           `json:"format"`
+	TotalRows  int              `json:"total_rows"`
+	Processed  int              `json:"processed_rows"`
+	Created    int              `json:"created_rows"`
+	Updated    int              `json:"updated_rows"`
+	Failed     int              `json:"failed_rows"`
+	Message    string           `json:"message,omitempty"`
+	CreatedBy  *int             `json:"created_by,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	StartedAt  *time.Time       `json:"started_at,omitempty"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+	Errors     []ImportRowError `json:"errors,omitempty"`
+}
+
+// ImportRowError adalah masalah pada satu baris file; Row adalah nomor baris
+// di spreadsheet (header di baris 1) dan Column nama header-nya bila ada.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProductImportRequest adalah isi unggahan impor. Mapping memetakan field
+// impor ke judul kolom di file, mis. {"name": "Nama Barang"}; field yang
+// tidak dipetakan dicari dari judul kolom yang sama dengan nama field.
+type ProductImportRequest struct {
+	FileName string
+	Format   string
+	Data     []byte
+	Mapping  map[string]string
+	DryRun   bool
+}
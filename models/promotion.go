@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+const (
+	PromotionPercentage = "percentage"
+	PromotionFixed      = "fixed"
+	PromotionBuyXGetY   = "buy_x_get_y"
+	PromotionBundle     = "bundle"
+
+	ScopeCart     = "cart"
+	ScopeProduct  = "product"
+	ScopeCategory = "category"
+)
+
+// Promotion adalah aturan diskon yang dievaluasi saat checkout.
+//
+//   - percentage: Value persen dari baris (scope product/category) atau keranjang (scope cart)
+//   - fixed: Value rupiah per unit (scope product/category) atau per keranjang (scope cart)
+//   - buy_x_get_y: beli BuyQuantity ProductID, gratis GetQuantity GetProductID (default produk yang sama)
+//   - bundle: semua Items dengan qty masing-masing dijual seharga Value
+//
+// StartsAt/EndsAt membatasi masa berlaku, DaysOfWeek (0 = Minggu) dan
+// StartTime/EndTime ("HH:MM", waktu toko) membatasi jam berlaku, mis. happy hour.
+type Promotion struct {
+	ID           int             `json:"id"`
+	Name         string          `json:"name"`
+	Type         string          `json:"type"`
+	Scope        string          `json:"scope"`
+	ProductID    *int            `json:"product_id,omitempty"`
+	CategoryID   *int            `json:"category_id,omitempty"`
+	Value        int             `json:"value"`
+	BuyQuantity  int             `json:"buy_quantity,omitempty"`
+	GetQuantity  int             `json:"get_quantity,omitempty"`
+	GetProductID *int            `json:"get_product_id,omitempty"`
+	MinSubtotal  int             `json:"min_subtotal,omitempty"`
+	StartsAt     *time.Time      `json:"starts_at,omitempty"`
+	EndsAt       *time.Time      `json:"ends_at,omitempty"`
+	DaysOfWeek   []int           `json:"days_of_week,omitempty"`
+	StartTime    string          `json:"start_time,omitempty"`
+	EndTime      string          `json:"end_time,omitempty"`
+	Active       bool            `json:"active"`
+	Items        []PromotionItem `json:"items,omitempty"`
+}
+
+type PromotionItem struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+}
+
+// AppliedPromotion adalah promosi yang terpakai pada sebuah transaksi.
+type AppliedPromotion struct {
+	PromotionID *int   `json:"promotion_id"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Discount    int    `json:"discount"`
+}
+
+type PromotionUsage struct {
+	PromotionID      *int   `json:"promotion_id"`
+	Name             string `json:"name"`
+	TransactionCount int    `json:"transaction_count"`
+	TotalDiscount    int    `json:"total_discount"`
+}
+
+// PromotionReport merangkum pemakaian promosi pada [PeriodStart, PeriodEnd).
+type PromotionReport struct {
+	PeriodStart   time.Time        `json:"period_start"`
+	PeriodEnd     time.Time        `json:"period_end"`
+	TotalDiscount int              `json:"total_discount"`
+	Promotions    []PromotionUsage `json:"promotions"`
+}
@@ -0,0 +1,116 @@
+package models
+
+import "time"
+
+const (
+	PurchaseOrderOpen      = "open"
+	PurchaseOrderPartial   = "partial"
+	PurchaseOrderReceived  = "received"
+	PurchaseOrderCancelled = "cancelled"
+
+	ReferenceGoodsReceipt = "goods_receipt"
+)
+
+type Supplier struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Phone   string `json:"phone"`
+	Email   string `json:"email"`
+	Address string `json:"address"`
+	Active  bool   `json:"active"`
+}
+
+// PurchaseOrder adalah pesanan ke supplier. Barang bisa diterima bertahap
+// lewat beberapa GoodsReceipt; Status menjadi partial lalu received.
+type PurchaseOrder struct {
+	ID           int                 `json:"id"`
+	SupplierID   int                 `json:"supplier_id"`
+	SupplierName string              `json:"supplier_name,omitempty"`
+	Status       string              `json:"status"`
+	OrderDate    time.Time           `json:"order_date"`
+	ExpectedDate *string             `json:"expected_date,omitempty"`
+	Note         string              `json:"note"`
+	Total        int                 `json:"total"`
+	CreatedBy    *int                `json:"created_by,omitempty"`
+	Lines        []PurchaseOrderLine `json:"lines,omitempty"`
+	Receipts     []GoodsReceipt      `json:"receipts,omitempty"`
+}
+
+// PurchaseOrderLine dipesan dalam Unit (kosong berarti satuan dasar produk);
+// Quantity, UnitCost dan ReceivedQuantity dalam satuan itu, dan UnitFactor
+// adalah isi satuan dasar per Unit.
+type PurchaseOrderLine struct {
+	ID               int      `json:"id"`
+	ProductID        int      `json:"product_id"`
+	ProductName      string   `json:"product_name,omitempty"`
+	Quantity         Quantity `json:"quantity" swaggertype:"number"`
+	Unit             string   `json:"unit"`
+	UnitFactor       Quantity `json:"unit_factor" swaggertype:"number"`
+	UnitCost         int      `json:"unit_cost"`
+	ReceivedQuantity Quantity `json:"received_quantity" swaggertype:"number"`
+}
+
+type GoodsReceipt struct {
+	ID              int                `json:"id"`
+	PurchaseOrderID int                `json:"purchase_order_id"`
+	ReceivedAt      time.Time          `json:"received_at"`
+	ReceivedBy      *int               `json:"received_by,omitempty"`
+	Note            string             `json:"note"`
+	Lines           []GoodsReceiptLine `json:"lines"`
+}
+
+// GoodsReceiptLine adalah qty yang diterima untuk satu baris PO, dalam satuan
+// baris PO itu. UnitCost kosong berarti sama dengan harga di PO.
+type GoodsReceiptLine struct {
+	ID                  int      `json:"id"`
+	PurchaseOrderLineID int      `json:"purchase_order_line_id"`
+	ProductID           int      `json:"product_id"`
+	Quantity            Quantity `json:"quantity" swaggertype:"number"`
+	UnitCost            *int     `json:"unit_cost,omitempty"`
+}
+
+// OutstandingPurchaseLine adalah sisa qty PO yang belum diterima.
+type OutstandingPurchaseLine struct {
+	PurchaseOrderID  int       `json:"purchase_order_id"`
+	SupplierID       int       `json:"supplier_id"`
+	SupplierName     string    `json:"supplier_name"`
+	OrderDate        time.Time `json:"order_date"`
+	ExpectedDate     *string   `json:"expected_date,omitempty"`
+	ProductID        int       `json:"product_id"`
+	ProductName      string    `json:"product_name"`
+	Unit             string    `json:"unit"`
+	Ordered          Quantity  `json:"ordered" swaggertype:"number"`
+	Received         Quantity  `json:"received" swaggertype:"number"`
+	Outstanding      Quantity  `json:"outstanding" swaggertype:"number"`
+	OutstandingValue int       `json:"outstanding_value"`
+}
+
+type OutstandingPurchaseReport struct {
+	TotalValue int                       `json:"total_value"`
+	Lines      []OutstandingPurchaseLine `json:"lines"`
+}
+
+type SupplierProductPurchase struct {
+	ProductID   int      `json:"product_id"`
+	ProductName string   `json:"product_name"`
+	Quantity    Quantity `json:"quantity" swaggertype:"number"`
+	Value       int      `json:"value"`
+	AverageCost int      `json:"average_cost"`
+}
+
+type SupplierPurchaseSummary struct {
+	SupplierID   int                       `json:"supplier_id"`
+	SupplierName string                    `json:"supplier_name"`
+	ReceiptCount int                       `json:"receipt_count"`
+	TotalValue   int                       `json:"total_value"`
+	Products     []SupplierProductPurchase `json:"products"`
+}
+
+// SupplierPurchaseReport merangkum barang yang diterima dari supplier pada
+// [PeriodStart, PeriodEnd).
+type SupplierPurchaseReport struct {
+	PeriodStart time.Time                 `json:"period_start"`
+	PeriodEnd   time.Time                 `json:"period_end"`
+	TotalValue  int                       `json:"total_value"`
+	Suppliers   []SupplierPurchaseSummary `json:"suppliers"`
+}
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// QuantityScale adalah jumlah langkah terkecil per satu unit: qty disimpan
+// dengan tiga desimal.
+const QuantityScale = 1000
+
+// Quantity adalah jumlah barang dengan presisi tiga desimal, disimpan sebagai
+// bilangan bulat seperseribu supaya penjumlahan stok tetap eksak. Di JSON
+// ditulis sebagai angka desimal (0.75) dan di database sebagai NUMERIC(14,3).
+type Quantity int64
+
+// Units mengubah jumlah unit bulat menjadi Quantity.
+func Units(n int) Quantity {
+	return Quantity(n) * QuantityScale
+}
+
+// ParseQuantity membaca angka desimal dengan paling banyak tiga desimal.
+func ParseQuantity(s string) (Quantity, error) {
+	s = strings.TrimSpace(s)
+	sign := Quantity(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	frac = strings.TrimRight(frac, "0")
+	if whole == "" && frac == "" || len(frac) > 3 || strings.ContainsAny(whole+frac, "+-") {
+		return 0, fmt.Errorf("invalid quantity %q, use at most 3 decimals", s)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	w, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || w > 1e11 {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	var f int64
+	if frac != "" {
+		f, err = strconv.ParseInt(frac+strings.Repeat("0", 3-len(frac)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid quantity %q", s)
+		}
+	}
+	return sign * Quantity(w*QuantityScale+f), nil
+}
+
+// String menulis qty tanpa nol di belakang koma, mis. 24, 0.75, -1.5.
+func (q Quantity) String() string {
+	sign := ""
+	if q < 0 {
+		sign, q = "-", -q
+	}
+	whole, frac := q/QuantityScale, q%QuantityScale
+	if frac == 0 {
+		return sign + strconv.FormatInt(int64(whole), 10)
+	}
+	return sign + strconv.FormatInt(int64(whole), 10) + "." + strings.TrimRight(fmt.Sprintf("%03d", frac), "0")
+}
+
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalJSON menerima angka maupun string angka.
+func (q *Quantity) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("invalid quantity %s", data)
+		}
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	v, err := ParseQuantity(s)
+	if err != nil {
+		return err
+	}
+	*q = v
+	return nil
+}
+
+// Scan membaca kolom NUMERIC (string dari driver) atau integer.
+func (q *Quantity) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*q = 0
+		return nil
+	case int64:
+		*q = Units(int(v))
+		return nil
+	case float64:
+		return q.UnmarshalJSON([]byte(strconv.FormatFloat(v, 'f', -1, 64)))
+	case []byte:
+		return q.Scan(string(v))
+	case string:
+		v2, err := ParseQuantity(v)
+		if err != nil {
+			return err
+		}
+		*q = v2
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into Quantity", src)
+}
+
+func (q Quantity) Value() (driver.Value, error) {
+	return q.String(), nil
+}
+
+// Amount mengalikan harga per unit dengan qty, dibulatkan ke rupiah terdekat.
+// ok false bila hasilnya tidak muat di int64.
+func (q Quantity) Amount(price int) (int, bool) {
+	amount, ok := mulDiv(int64(price), int64(q), QuantityScale, true)
+	return int(amount), ok
+}
+
+// PerUnit membagi amount dengan qty, mis. nilai pembelian menjadi harga per
+// unit, dibulatkan ke rupiah terdekat. Qty nol menghasilkan 0. ok false bila
+// hasilnya tidak muat di int64.
+func (q Quantity) PerUnit(amount int) (int, bool) {
+	if q == 0 {
+		return 0, true
+	}
+	if q < 0 {
+		amount, q = -amount, -q
+	}
+	perUnit, ok := mulDiv(int64(amount), QuantityScale, int64(q), true)
+	return int(perUnit), ok
+}
+
+// Mul mengalikan dua qty, mis. qty dalam karton kali isi per karton. ok false
+// bila hasilnya butuh lebih dari tiga desimal atau tidak muat di int64.
+func (q Quantity) Mul(factor Quantity) (Quantity, bool) {
+	product := new(big.Int).Mul(big.NewInt(int64(q)), big.NewInt(int64(factor)))
+	product, rem := product.QuoRem(product, big.NewInt(QuantityScale), new(big.Int))
+	if rem.Sign() != 0 || !product.IsInt64() {
+		return 0, false
+	}
+	return Quantity(product.Int64()), true
+}
+
+// Fits melaporkan apakah qty bisa ditulis dengan decimals desimal.
+func (q Quantity) Fits(decimals int) bool {
+	step := Quantity(1)
+	for i := decimals; i < 3; i++ {
+		step *= 10
+	}
+	return q%step == 0
+}
+
+// Round membulatkan qty ke decimals desimal.
+func (q Quantity) Round(decimals int) Quantity {
+	step := Quantity(1)
+	for i := decimals; i < 3; i++ {
+		step *= 10
+	}
+	return Quantity(roundDiv(int64(q), int64(step))) * step
+}
+
+// Share mengembalikan bagian amount sebesar part/whole, dibulatkan ke bawah
+// seperti pembagian bilangan bulat. Selama part tidak melebihi whole hasilnya
+// selalu muat; ok false bila tidak muat di int64.
+func Share(amount int, part, whole Quantity) (int, bool) {
+	if whole == 0 {
+		return 0, true
+	}
+	if whole < 0 {
+		part, whole = -part, -whole
+	}
+	share, ok := mulDiv(int64(amount), int64(part), int64(whole), false)
+	return int(share), ok
+}
+
+// mulDiv menghitung a*b/c (c positif) tanpa overflow pada hasil kali. round
+// membulatkan setengah menjauhi nol; tanpa round hasilnya dipotong ke arah
+// nol seperti pembagian bilangan bulat. ok false bila hasilnya tidak muat di
+// int64.
+func mulDiv(a, b, c int64, round bool) (int64, bool) {
+	n := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	if round {
+		half := big.NewInt(c / 2)
+		if n.Sign() < 0 {
+			n.Sub(n, half)
+		} else {
+			n.Add(n, half)
+		}
+	}
+	n.Quo(n, big.NewInt(c))
+	if !n.IsInt64() {
+		return 0, false
+	}
+	return n.Int64(), true
+}
+
+func roundDiv(a, b int64) int64 {
+	if a < 0 {
+		return -((-a + b/2) / b)
+	}
+	return (a + b/2) / b
+}
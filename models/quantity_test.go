@@ -0,0 +1,242 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Quantity
+		wantErr bool
+	}{
+		{in: "1", want: 1000},
+		{in: "0.75", want: 750},
+		{in: ".5", want: 500},
+		{in: "2.", want: 2000},
+		{in: "1.2500", want: 1250},
+		{in: " 3 ", want: 3000},
+		{in: "+2", want: 2000},
+		{in: "-1.5", want: -1500},
+		{in: "-0.001", want: -1},
+		{in: "-0", want: 0},
+		{in: "100000000000", want: 100000000000 * QuantityScale},
+		{in: "-100000000000", want: -100000000000 * QuantityScale},
+		{in: "1.2345", wantErr: true},
+		{in: "0.0001", wantErr: true},
+		{in: "100000000001", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "--1", wantErr: true},
+		{in: "+-1", wantErr: true},
+		{in: "1.-5", wantErr: true},
+		{in: "1.a", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "1,5", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseQuantity(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseQuantity(%q) = %d, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseQuantity(%q) = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestQuantityString(t *testing.T) {
+	tests := []struct {
+		q    Quantity
+		want string
+	}{
+		{0, "0"},
+		{24000, "24"},
+		{750, "0.75"},
+		{1, "0.001"},
+		{-1500, "-1.5"},
+		{-5, "-0.005"},
+		{1010, "1.01"},
+	}
+	for _, tt := range tests {
+		if got := tt.q.String(); got != tt.want {
+			t.Errorf("Quantity(%d).String() = %q, want %q", int64(tt.q), got, tt.want)
+		}
+		if back, err := ParseQuantity(tt.want); err != nil || back != tt.q {
+			t.Errorf("ParseQuantity(%q) = %d, %v; want %d", tt.want, back, err, tt.q)
+		}
+	}
+}
+
+func TestQuantityUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Quantity
+		wantErr bool
+	}{
+		{in: `1.5`, want: 1500},
+		{in: `"2.25"`, want: 2250},
+		{in: `1e3`, want: 1000000},
+		{in: `1.5e-1`, want: 150},
+		{in: `-0.25`, want: -250},
+		{in: `null`, want: 7},
+		{in: `1.0005`, wantErr: true},
+		{in: `1e-4`, wantErr: true},
+		{in: `1e30`, wantErr: true},
+		{in: `"x"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		q := Quantity(7)
+		err := json.Unmarshal([]byte(tt.in), &q)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("unmarshal %s = %d, want error", tt.in, q)
+			}
+			continue
+		}
+		if err != nil || q != tt.want {
+			t.Errorf("unmarshal %s = %d, %v; want %d", tt.in, q, err, tt.want)
+		}
+	}
+}
+
+func TestQuantityMul(t *testing.T) {
+	tests := []struct {
+		name      string
+		q, factor Quantity
+		want      Quantity
+		wantOK    bool
+	}{
+		{"whole units", Units(2), Units(12), Units(24), true},
+		{"decimal factor", 1500, 250, 375, true},
+		{"negative", -1500, Units(2), -3000, true},
+		{"both negative", -500, -500, 250, true},
+		{"zero", 0, Units(12), 0, true},
+		{"too precise", 1, 1, 0, false},
+		{"too precise negative", -1, 500, 0, false},
+		{"overflow", Units(100000000000), Units(100000000000), 0, false},
+		{"negative overflow", -Units(100000000000), Units(100000000000), 0, false},
+		{"min int64", -1, math.MinInt64, 0, false},
+		{"min int64 times one", math.MinInt64, Units(1), math.MinInt64, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.q.Mul(tt.factor)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("%d.Mul(%d) = %d, %v; want %d, %v", tt.q, tt.factor, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestQuantityAmount(t *testing.T) {
+	tests := []struct {
+		q      Quantity
+		price  int
+		want   int
+		wantOK bool
+	}{
+		{Units(3), 2500, 7500, true},
+		{333, 1000, 333, true},
+		{1500, 1001, 1502, true},
+		{1499, 1, 1, true},
+		{1, 499, 0, true},
+		{1, 500, 1, true},
+		{-1500, 1001, -1502, true},
+		{-1, 500, -1, true},
+		{0, 1000, 0, true},
+		{Units(100000000000), 50000, 5000000000000000, true},
+		{Units(10000000), 1000000000, 10000000000000000, true},
+		{Units(10000000000), 1000000000, 0, false},
+		{-Units(10000000000), 1000000000, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.q.Amount(tt.price)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("Quantity(%d).Amount(%d) = %d, %v; want %d, %v", int64(tt.q), tt.price, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestQuantityPerUnit(t *testing.T) {
+	tests := []struct {
+		q      Quantity
+		amount int
+		want   int
+		wantOK bool
+	}{
+		{Units(3), 7500, 2500, true},
+		{Units(3), 1000, 333, true},
+		{1500, 1000, 667, true},
+		{-Units(2), 1000, -500, true},
+		{0, 1000, 0, true},
+		{1, 1000000000, 1000000000000, true},
+		{1, math.MaxInt / 100, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.q.PerUnit(tt.amount)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("Quantity(%d).PerUnit(%d) = %d, %v; want %d, %v", int64(tt.q), tt.amount, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestShare(t *testing.T) {
+	tests := []struct {
+		amount      int
+		part, whole Quantity
+		want        int
+		wantOK      bool
+	}{
+		{1000, Units(1), Units(3), 333, true},
+		{1000, Units(2), Units(3), 666, true},
+		{1000, Units(3), Units(3), 1000, true},
+		{1000, 500, 1500, 333, true},
+		{-1000, Units(1), Units(3), -333, true},
+		{1000, 0, Units(3), 0, true},
+		{1000, Units(1), 0, 0, true},
+		{1000, Units(1), -Units(3), -333, true},
+		{5000000000, Units(100000000000), Units(100000000000), 5000000000, true},
+		{math.MaxInt, Units(2), Units(1), 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := Share(tt.amount, tt.part, tt.whole)
+		if ok != tt.wantOK || (ok && got != tt.want) {
+			t.Errorf("Share(%d, %d, %d) = %d, %v; want %d, %v", tt.amount, int64(tt.part), int64(tt.whole), got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestQuantityFitsAndRound(t *testing.T) {
+	tests := []struct {
+		q        Quantity
+		decimals int
+		fits     bool
+		rounded  Quantity
+	}{
+		{Units(2), 0, true, Units(2)},
+		{1500, 0, false, 2000},
+		{1499, 0, false, 1000},
+		{-1500, 0, false, -2000},
+		{1250, 1, false, 1300},
+		{1250, 2, true, 1250},
+		{1, 3, true, 1},
+		{5, 2, false, 10},
+	}
+	for _, tt := range tests {
+		if got := tt.q.Fits(tt.decimals); got != tt.fits {
+			t.Errorf("Quantity(%d).Fits(%d) = %v, want %v", int64(tt.q), tt.decimals, got, tt.fits)
+		}
+		if got := tt.q.Round(tt.decimals); got != tt.rounded {
+			t.Errorf("Quantity(%d).Round(%d) = %d, want %d", int64(tt.q), tt.decimals, got, tt.rounded)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+type BestSellingProduct struct {
+	ProductID  int      `json:"product_id"`
+	Name       string   `json:"nama"`
+	QtyTerjual Quantity `json:"qty_terjual" swaggertype:"number"`
+	Revenue    int      `json:"revenue"`
+}
+
+// DailyReport adalah ringkasan penjualan untuk satu periode [PeriodStart, PeriodEnd).
+type DailyReport struct {
+	PeriodStart    time.Time            `json:"period_start"`
+	PeriodEnd      time.Time            `json:"period_end"`
+	TotalRevenue   int                  `json:"total_revenue"`
+	TotalTransaksi int                  `json:"total_transaksi"`
+	TotalCost      int                  `json:"total_cost"`
+	GrossProfit    int                  `json:"gross_profit"`
+	ProdukTerlaris BestSellingProduct   `json:"produk_terlaris"`
+	TopProducts    []BestSellingProduct `json:"top_products,omitempty"`
+	Payments       []PaymentSummary     `json:"payments,omitempty"`
+}
+
+const (
+	GroupByDay      = "day"
+	GroupByWeek     = "week"
+	GroupByMonth    = "month"
+	GroupByProduct  = "product"
+	GroupByCategory = "category"
+	GroupByParent   = "parent"
+)
+
+// SalesReport berisi ringkasan seluruh rentang plus satu DailyReport per bucket.
+type SalesReport struct {
+	GroupBy string        `json:"group_by"`
+	Summary DailyReport   `json:"summary"`
+	Periods []DailyReport `json:"periods"`
+}
+
+// MarginLine adalah penjualan bersih (tanpa pajak), harga pokok dan laba kotor
+// satu produk, kategori atau hari. ID berisi product_id atau category_id;
+// Date diisi untuk group_by=day.
+type MarginLine struct {
+	ID            *int       `json:"id,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	Date          *time.Time `json:"date,omitempty"`
+	Quantity      Quantity   `json:"quantity" swaggertype:"number"`
+	NetSales      int        `json:"net_sales"`
+	Cost          int        `json:"cost"`
+	GrossProfit   int        `json:"gross_profit"`
+	MarginPercent float64    `json:"margin_percent"`
+}
+
+type MarginReport struct {
+	PeriodStart   time.Time    `json:"period_start"`
+	PeriodEnd     time.Time    `json:"period_end"`
+	GroupBy       string       `json:"group_by"`
+	NetSales      int          `json:"net_sales"`
+	Cost          int          `json:"cost"`
+	GrossProfit   int          `json:"gross_profit"`
+	MarginPercent float64      `json:"margin_percent"`
+	Lines         []MarginLine `json:"lines"`
+}
@@ -0,0 +1,117 @@
+package models
+
+import "time"
+
+const (
+	ShiftOpen   = "open"
+	ShiftClosed = "closed"
+
+	CashIn  = "cash_in"
+	CashOut = "cash_out"
+
+	ShiftReportX = "x"
+	ShiftReportZ = "z"
+)
+
+// Shift adalah shift kasir dengan laci uangnya. Selama open, transaksi dan
+// pembayaran kasbon yang dibuat kasir itu ditautkan ke shift. ExpectedCash,
+// CountedCash dan Variance (hitung dikurangi seharusnya) diisi saat ditutup.
+type Shift struct {
+	ID            int                `json:"id"`
+	CashierID     int                `json:"cashier_id"`
+	CashierName   string             `json:"cashier_name"`
+	Status        string             `json:"status"`
+	OpeningFloat  int                `json:"opening_float"`
+	Note          string             `json:"note,omitempty"`
+	OpenedAt      time.Time          `json:"opened_at"`
+	ClosedBy      *int               `json:"closed_by,omitempty"`
+	ClosedAt      *time.Time         `json:"closed_at,omitempty"`
+	CloseNote     string             `json:"close_note,omitempty"`
+	ExpectedCash  *int               `json:"expected_cash,omitempty"`
+	CountedCash   *int               `json:"counted_cash,omitempty"`
+	Variance      *int               `json:"variance,omitempty"`
+	CashMovements []CashMovement     `json:"cash_movements,omitempty"`
+	Denominations []CashDenomination `json:"denominations,omitempty"`
+}
+
+// CashMovement adalah uang kas kecil yang dimasukkan (cash_in) atau diambil
+// (cash_out) dari laci selama shift.
+type CashMovement struct {
+	ID        int       `json:"id"`
+	ShiftID   int       `json:"shift_id"`
+	Type      string    `json:"type"`
+	Amount    int       `json:"amount"`
+	Reason    string    `json:"reason"`
+	UserID    *int      `json:"user_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CashDenomination adalah jumlah lembar/keping satu pecahan uang yang dihitung
+// saat tutup shift. Amount diisi server.
+type CashDenomination struct {
+	Denomination int `json:"denomination"`
+	Count        int `json:"count"`
+	Amount       int `json:"amount"`
+}
+
+type OpenShiftRequest struct {
+	OpeningFloat int    `json:"opening_float"`
+	Note         string `json:"note"`
+}
+
+type CashMovementRequest struct {
+	Type   string `json:"type"`
+	Amount int    `json:"amount"`
+	Reason string `json:"reason"`
+}
+
+// CloseShiftRequest berisi uang di laci per pecahan; pecahan yang tidak
+// disebut dianggap nol.
+type CloseShiftRequest struct {
+	Denominations []CashDenomination `json:"denominations"`
+	Note          string             `json:"note"`
+}
+
+// ShiftFilter adalah filter untuk GET /api/shifts.
+type ShiftFilter struct {
+	CashierID *int
+	Status    string
+	Page      int
+	PageSize  int
+}
+
+type ShiftList struct {
+	Data       []Shift    `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
+
+// ShiftReport adalah laporan X (selama shift berjalan) atau Z (setelah shift
+// ditutup) untuk transaksi yang ditautkan ke shift. Nilai void dan retur
+// negatif. ExpectedCash adalah modal awal ditambah tunai bersih dari
+// transaksi dan pembayaran kasbon serta kas masuk, dikurangi kas keluar.
+type ShiftReport struct {
+	Type             string           `json:"type"`
+	Shift            Shift            `json:"shift"`
+	GeneratedAt      time.Time        `json:"generated_at"`
+	Sales            int              `json:"sales"`
+	SalesAmount      int              `json:"sales_amount"`
+	Voids            int              `json:"voids"`
+	VoidAmount       int              `json:"void_amount"`
+	Returns          int              `json:"returns"`
+	ReturnAmount     int              `json:"return_amount"`
+	NetSales         int              `json:"net_sales"`
+	Tax              int              `json:"tax"`
+	Payments         []PaymentSummary `json:"payments"`
+	CreditRepayments []PaymentSummary `json:"credit_repayments"`
+	CashSales        int              `json:"cash_sales"`
+	CashRepayments   int              `json:"cash_repayments"`
+	CashIn           int              `json:"cash_in"`
+	CashOut          int              `json:"cash_out"`
+	ExpectedCash     int              `json:"expected_cash"`
+
+	// CashOutsideShifts adalah tunai bersih dari transaksi dan pembayaran
+	// kasbon tanpa shift yang dicatat selama shift ini open. Sejak tender
+	// tunai wajib shift nilainya hanya berasal dari data lama; tidak termasuk
+	// ExpectedCash.
+	CashOutsideShifts int `json:"cash_outside_shifts"`
+}
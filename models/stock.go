@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+const (
+	StockOpening    = "opening"
+	StockSale       = "sale"
+	StockVoid       = "void"
+	StockReturn     = "return"
+	StockPurchase   = "purchase"
+	StockAdjustment = "adjustment"
+	StockTransfer   = "transfer"
+	StockWaste      = "waste"
+
+	ReferenceTransaction = "transaction"
+)
+
+// StockMovement adalah satu entri ledger stok. Quantity bertanda (negatif
+// untuk barang keluar) dan BalanceAfter adalah stok produk setelah entri ini.
+type StockMovement struct {
+	ID            int       `json:"id"`
+	ProductID     int       `json:"product_id"`
+	Type          string    `json:"type"`
+	Quantity      Quantity  `json:"quantity" swaggertype:"number"`
+	BalanceAfter  Quantity  `json:"balance_after" swaggertype:"number"`
+	ReferenceType string    `json:"reference_type,omitempty"`
+	ReferenceID   *int      `json:"reference_id,omitempty"`
+	Note          string    `json:"note,omitempty"`
+	UserID        *int      `json:"user_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// StockMovementFilter adalah filter riwayat stok satu produk. Tanggalnya
+// diperlakukan sama seperti TransactionFilter.
+type StockMovementFilter struct {
+	ProductID int
+	StartDate *time.Time
+	EndDate   *time.Time
+	Type      string
+	Page      int
+	PageSize  int
+}
+
+type StockMovementList struct {
+	Data       []StockMovement `json:"data"`
+	Pagination Pagination      `json:"pagination"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+const (
+	StockCountOpen      = "open"
+	StockCountPosted    = "posted"
+	StockCountCancelled = "cancelled"
+
+	ReferenceStockCount = "stock_count"
+)
+
+// StockCount adalah sesi stock opname. Selama open, Lines adalah pratinjau
+// selisih; setelah posted, Lines adalah selisih yang sudah dibukukan.
+type StockCount struct {
+	ID       int              `json:"id"`
+	Status   string           `json:"status"`
+	Note     string           `json:"note"`
+	OpenedBy *int             `json:"opened_by,omitempty"`
+	OpenedAt time.Time        `json:"opened_at"`
+	ClosedBy *int             `json:"closed_by,omitempty"`
+	ClosedAt *time.Time       `json:"closed_at,omitempty"`
+	Lines    []StockCountLine `json:"lines,omitempty"`
+
+	// Nilai selisih pada harga pokok: kurang (negatif), lebih, dan bersihnya.
+	ShortageValue int `json:"shortage_value"`
+	SurplusValue  int `json:"surplus_value"`
+	VarianceValue int `json:"variance_value"`
+}
+
+// StockCountLine membandingkan hasil hitung dengan stok sistem pada saat
+// produk itu pertama kali dihitung.
+type StockCountLine struct {
+	ProductID       *int     `json:"product_id"`
+	ProductName     string   `json:"product_name"`
+	SystemQuantity  Quantity `json:"system_quantity" swaggertype:"number"`
+	CountedQuantity Quantity `json:"counted_quantity" swaggertype:"number"`
+	Variance        Quantity `json:"variance" swaggertype:"number"`
+	Cost            int      `json:"cost"`
+	VarianceValue   int      `json:"variance_value"`
+}
+
+type OpenStockCountRequest struct {
+	Note string `json:"note"`
+}
+
+// StockCountItem adalah hasil hitung satu produk. Unit kosong berarti satuan
+// dasar produk; qty satuan lain dikonversi ke satuan dasar.
+type StockCountItem struct {
+	ProductID int      `json:"product_id"`
+	Quantity  Quantity `json:"quantity" swaggertype:"number"`
+	Unit      string   `json:"unit,omitempty"`
+}
+
+// StockCountEntry adalah kiriman hasil hitung dari satu perangkat.
+type StockCountEntry struct {
+	Device string           `json:"device"`
+	Items  []StockCountItem `json:"items"`
+}
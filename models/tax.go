@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// TaxRate adalah tarif pajak, mis. PPN 11%. Rate dalam persen. Inclusive
+// berarti harga produk sudah termasuk pajak; pajaknya diambil dari dalam
+// harga, bukan ditambahkan di atasnya.
+type TaxRate struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Rate      float64 `json:"rate"`
+	Inclusive bool    `json:"inclusive"`
+}
+
+// TaxSummary adalah dasar pengenaan pajak (DPP) dan pajak satu tarif.
+type TaxSummary struct {
+	TaxRateID     *int    `json:"tax_rate_id"`
+	Name          string  `json:"name"`
+	Rate          float64 `json:"rate"`
+	TaxableAmount int     `json:"taxable_amount"`
+	Tax           int     `json:"tax"`
+}
+
+// TaxReport merangkum pajak keluaran pada [PeriodStart, PeriodEnd), sudah
+// bersih dari void dan retur.
+type TaxReport struct {
+	PeriodStart      time.Time    `json:"period_start"`
+	PeriodEnd        time.Time    `json:"period_end"`
+	TaxableAmount    int          `json:"taxable_amount"`
+	Tax              int          `json:"tax"`
+	NonTaxableAmount int          `json:"non_taxable_amount"`
+	Rates            []TaxSummary `json:"rates"`
+}
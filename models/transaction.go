@@ -2,28 +2,152 @@ package models
 
 import "time"
 
+const (
+	TransactionTypeSale   = "sale"
+	TransactionTypeVoid   = "void"
+	TransactionTypeReturn = "return"
+)
+
+// Transaction adalah dokumen penjualan. Void dan retur disimpan sebagai
+// dokumen bernilai negatif dengan ReferenceID ke penjualan aslinya.
 type Transaction struct {
-	ID        int                 `json:"id"`
-	Date      time.Time           `json:"date"`
-	Total     int                 `json:"total"`
-	Details   []TransactionDetail `json:"details"`
+	ID          int                 `json:"id"`
+	Type        string              `json:"type"`
+	ReferenceID *int                `json:"reference_id,omitempty"`
+	CashierID   *int                `json:"cashier_id,omitempty"`
+	CustomerID  *int                `json:"customer_id,omitempty"`
+	ShiftID     *int                `json:"shift_id,omitempty"`
+	Date        time.Time           `json:"date"`
+	Discount    int                 `json:"discount"`
+	Tax         int                 `json:"tax"`
+	Total       int                 `json:"total"`
+	Reason      string              `json:"reason,omitempty"`
+	ApprovedBy  string              `json:"approved_by,omitempty"`
+	Details     []TransactionDetail `json:"details,omitempty"`
+	Promotions  []AppliedPromotion  `json:"promotions,omitempty"`
+	Payments    []Payment           `json:"payments,omitempty"`
+	Change      int                 `json:"change"`
+	// PointsEarned dan PointsRedeemed adalah poin loyalty yang didapat dan
+	// dipakai pelanggan, diisi server. Pada void/retur keduanya negatif:
+	// poin yang ditarik kembali dan poin yang dikembalikan.
+	PointsEarned   int `json:"points_earned,omitempty"`
+	PointsRedeemed int `json:"points_redeemed,omitempty"`
 }
 
 type TransactionDetail struct {
-	ID            int `json:"id"`
-	TransactionID int `json:"transaction_id"`
-	ProductID     int `json:"product_id"`
-	Quantity      int `json:"quantity"`
-	Subtotal      int `json:"subtotal"`
+	ID                int      `json:"id"`
+	TransactionID     int      `json:"transaction_id"`
+	ReferenceDetailID *int     `json:"reference_detail_id,omitempty"`
+	ProductID         int      `json:"product_id"`
+	ParentProductID   *int     `json:"parent_product_id,omitempty"`
+	ProductName       string   `json:"product_name"`
+	UnitPrice         int      `json:"unit_price"`
+	Quantity          Quantity `json:"quantity" swaggertype:"number"`
+	// Unit adalah satuan baris (kosong di checkout berarti satuan dasar);
+	// UnitPrice dan UnitCost per Unit, UnitFactor isi satuan dasar per Unit.
+	Unit         string   `json:"unit"`
+	UnitFactor   Quantity `json:"unit_factor" swaggertype:"number"`
+	Discount     int      `json:"discount"`
+	Subtotal     int      `json:"subtotal"`
+	TaxRateID    *int     `json:"tax_rate_id,omitempty"`
+	TaxName      string   `json:"tax_name,omitempty"`
+	TaxRate      float64  `json:"tax_rate"`
+	TaxInclusive bool     `json:"tax_inclusive"`
+	Tax          int      `json:"tax"`
+	// UnitCost adalah harga pokok per unit saat terjual. Tidak ikut di JSON
+	// transaksi; margin hanya terlihat di laporan.
+	UnitCost int `json:"-"`
+	// VariantID, atau Options bersama product_id induk, memilih varian saat
+	// checkout. Setelah itu ProductID berisi varian dan keduanya dikosongkan.
+	VariantID *int            `json:"variant_id,omitempty"`
+	Options   []VariantOption `json:"options,omitempty"`
+	// Modifiers dipilih per baris dan berlaku untuk setiap unit; harganya
+	// sudah termasuk dalam UnitPrice.
+	Modifiers []DetailModifier `json:"modifiers,omitempty"`
+}
+
+// BaseQuantity adalah qty baris dalam satuan dasar produk, yang dipakai untuk
+// stok.
+func (d TransactionDetail) BaseQuantity() Quantity {
+	q, _ := d.Quantity.Mul(d.UnitFactor)
+	return q
+}
+
+// ModifierPrice adalah total harga modifier untuk satu unit baris ini.
+func (d TransactionDetail) ModifierPrice() int {
+	total := 0
+	for _, m := range d.Modifiers {
+		total += m.Price
+	}
+	return total
+}
+
+// Amount adalah nilai yang dibayar untuk baris ini: subtotal, ditambah pajak
+// bila harganya belum termasuk pajak.
+func (d TransactionDetail) Amount() int {
+	if d.TaxInclusive {
+		return d.Subtotal
+	}
+	return d.Subtotal + d.Tax
+}
+
+// VoidRequest membatalkan seluruh sisa penjualan pada hari bisnis yang sama.
+// Persetujuan manager dicatat dari user yang login.
+type VoidRequest struct {
+	Reason       string `json:"reason"`
+	RefundMethod string `json:"refund_method"`
+}
+
+// ReturnItem mengembalikan Quantity dalam satuan baris penjualannya.
+type ReturnItem struct {
+	DetailID int      `json:"detail_id"`
+	Quantity Quantity `json:"quantity" swaggertype:"number"`
+}
+
+// ReturnRequest mengembalikan sebagian item dari sebuah penjualan.
+type ReturnRequest struct {
+	Reason       string       `json:"reason"`
+	RefundMethod string       `json:"refund_method"`
+	Items        []ReturnItem `json:"items"`
+}
+
+// TransactionFilter adalah filter untuk GET /api/transactions. Service
+// menerima tanggal bisnis inklusif dan meneruskannya ke repository sebagai
+// rentang waktu half-open: StartDate <= date < EndDate.
+type TransactionFilter struct {
+	StartDate  *time.Time
+	EndDate    *time.Time
+	ProductID  *int
+	CustomerID *int
+	ShiftID    *int
+	Type       string
+	MinTotal   *int
+	MaxTotal   *int
+	Page       int
+	PageSize   int
+}
+
+// Pagination menjelaskan halaman hasil. Pada pagination cursor, Page 0 dan
+// NextCursor berisi cursor halaman berikutnya (kosong di halaman terakhir).
+type Pagination struct {
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+	TotalItems int              `json:"total_items"`
+	TotalPages int              `json:"total_pages"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	Links      *PaginationLinks `json:"links,omitempty"`
 }
 
-type BestSellingProduct struct {
-	Name       string `json:"nama"`
-	QtyTerjual int    `json:"qty_terjual"`
+// PaginationLinks berisi URL relatif ke halaman lain dengan filter yang sama.
+type PaginationLinks struct {
+	Self  string `json:"self"`
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
 }
 
-type DailyReport struct {
-	TotalRevenue    int                `json:"total_revenue"`
-	TotalTransaksi  int                `json:"total_transaksi"`
-	ProdukTerlaris  BestSellingProduct `json:"produk_terlaris"`
+type TransactionList struct {
+	Data       []Transaction `json:"data"`
+	Pagination Pagination    `json:"pagination"`
 }
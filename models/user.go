@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+const (
+	RoleOwner   = "owner"
+	RoleManager = "manager"
+	RoleCashier = "cashier"
+)
+
+var (
+	AllRoles     = []string{RoleOwner, RoleManager, RoleCashier}
+	ManagerRoles = []string{RoleOwner, RoleManager}
+	OwnerRoles   = []string{RoleOwner}
+)
+
+type User struct {
+	ID           int       `json:"id"`
+	Username     string    `json:"username"`
+	Name         string    `json:"name"`
+	Role         string    `json:"role"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	PasswordHash string    `json:"-"`
+}
+
+// UserInput adalah payload create/update user. Password kosong saat update
+// berarti password tidak diubah.
+type UserInput struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Password string `json:"password"`
+	Active   *bool  `json:"active"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      User      `json:"user"`
+}
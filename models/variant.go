@@ -0,0 +1,20 @@
+package models
+
+// ProductOptionGroup adalah satu dimensi varian produk induk, mis. Size dengan
+// nilai Large dan Medium.
+type ProductOptionGroup struct {
+	ID     int                  `json:"id"`
+	Name   string               `json:"name"`
+	Values []ProductOptionValue `json:"values"`
+}
+
+type ProductOptionValue struct {
+	ID    int    `json:"id"`
+	Value string `json:"value"`
+}
+
+// VariantOption adalah nilai satu grup opsi pada varian, mis. Size = Large.
+type VariantOption struct {
+	Group string `json:"group"`
+	Value string `json:"value"`
+}
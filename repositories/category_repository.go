@@ -2,7 +2,6 @@ package repositories
 
 import (
 	"database/sql"
-	"errors"
 	"go-kasir-api/models"
 )
 
@@ -15,7 +14,7 @@ func NewCategoryRepository(db *sql.DB) *CategoryRepository {
 }
 
 func (repo *CategoryRepository) GetAll() ([]models.Category, error) {
-	query := "SELECT id, name FROM categories"
+	query := "SELECT id, name, tax_rate_id FROM categories ORDER BY id"
 	rows, err := repo.db.Query(query)
 	if err != nil {
 		return nil, err
@@ -25,7 +24,7 @@ func (repo *CategoryRepository) GetAll() ([]models.Category, error) {
 	categories := make([]models.Category, 0)
 	for rows.Next() {
 		var c models.Category
-		err := rows.Scan(&c.ID, &c.Name)
+		err := rows.Scan(&c.ID, &c.Name, &c.TaxRateID)
 		if err != nil {
 			return nil, err
 		}
@@ -36,17 +35,17 @@ func (repo *CategoryRepository) GetAll() ([]models.Category, error) {
 }
 
 func (repo *CategoryRepository) Create(category *models.Category) error {
-	query := "INSERT INTO categories (name) VALUES ($1) RETURNING id"
-	err := repo.db.QueryRow(query, category.Name).Scan(&category.ID)
+	query := "INSERT INTO categories (name, tax_rate_id) VALUES ($1, $2) RETURNING id"
+	err := repo.db.QueryRow(query, category.Name, category.TaxRateID).Scan(&category.ID)
 	return err
 }
 
 func (repo *CategoryRepository) GetByID(id int) (*models.Category, error) {
-	query := "SELECT id, name FROM categories WHERE id = $1"
+	query := "SELECT id, name, tax_rate_id FROM categories WHERE id = $1"
 	var c models.Category
-	err := repo.db.QueryRow(query, id).Scan(&c.ID, &c.Name)
+	err := repo.db.QueryRow(query, id).Scan(&c.ID, &c.Name, &c.TaxRateID)
 	if err == sql.ErrNoRows {
-		return nil, errors.New("category not found")
+		return nil, models.ErrCategoryNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -55,8 +54,8 @@ func (repo *CategoryRepository) GetByID(id int) (*models.Category, error) {
 }
 
 func (repo *CategoryRepository) Update(category *models.Category) error {
-	query := "UPDATE categories SET name = $1 WHERE id = $2"
-	result, err := repo.db.Exec(query, category.Name, category.ID)
+	query := "UPDATE categories SET name = $1, tax_rate_id = $2 WHERE id = $3"
+	result, err := repo.db.Exec(query, category.Name, category.TaxRateID, category.ID)
 	if err != nil {
 		return err
 	}
@@ -66,7 +65,7 @@ func (repo *CategoryRepository) Update(category *models.Category) error {
 		return err
 	}
 	if rows == 0 {
-		return errors.New("category not found")
+		return models.ErrCategoryNotFound
 	}
 	return nil
 }
@@ -74,6 +73,9 @@ func (repo *CategoryRepository) Update(category *models.Category) error {
 func (repo *CategoryRepository) Delete(id int) error {
 	query := "DELETE FROM categories WHERE id = $1"
 	result, err := repo.db.Exec(query, id)
+	if isForeignKeyViolation(err) {
+		return models.ErrCategoryInUse
+	}
 	if err != nil {
 		return err
 	}
@@ -82,7 +84,45 @@ func (repo *CategoryRepository) Delete(id int) error {
 		return err
 	}
 	if rows == 0 {
-		return errors.New("category not found")
+		return models.ErrCategoryNotFound
 	}
 	return nil
 }
+
+// ReassignAndDelete memindahkan semua produk ke kategori lain lalu menghapus
+// kategori, dalam satu transaksi.
+func (repo *CategoryRepository) ReassignAndDelete(id int, targetID int) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM categories WHERE id = $1)", targetID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return models.NewValidationError("target category %d not found", targetID)
+	}
+
+	_, err = tx.Exec("UPDATE products SET category_id = $1 WHERE category_id = $2", targetID, id)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec("DELETE FROM categories WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrCategoryNotFound
+	}
+
+	return tx.Commit()
+}
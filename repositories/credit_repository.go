@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+	"time"
+)
+
+type CreditRepository struct {
+	db *sql.DB
+}
+
+func NewCreditRepository(db *sql.DB) *CreditRepository {
+	return &CreditRepository{db: db}
+}
+
+// moveCredit adalah satu-satunya jalan untuk mengubah customers.credit_balance:
+// saldo diubah sebesar e.Amount dan entri ledger ditulis di tx yang sama, lalu
+// BalanceAfter, ID dan CreatedAt diisi. Baris pelanggan terkunci sampai tx
+// selesai. Charge tidak boleh melewati credit_limit dan pembayaran tidak boleh
+// melebihi utang; void/retur boleh membuat saldo titipan (minus).
+func moveCredit(tx *sql.Tx, e *models.CreditEntry) error {
+	var limit int
+	err := tx.QueryRow("UPDATE customers SET credit_balance = credit_balance + $1 WHERE id = $2 RETURNING credit_balance, credit_limit",
+		e.Amount, e.CustomerID).Scan(&e.BalanceAfter, &limit)
+	if err == sql.ErrNoRows {
+		return models.ErrCustomerNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if e.Type == models.CreditCharge && e.BalanceAfter > limit {
+		return models.NewValidationError("credit of %d exceeds the limit of customer %d, %d is available",
+			e.Amount, e.CustomerID, max(limit-(e.BalanceAfter-e.Amount), 0))
+	}
+	if e.Type == models.CreditPayment && e.BalanceAfter < 0 {
+		return models.NewValidationError("payment of %d exceeds the %d customer %d owes",
+			-e.Amount, max(e.BalanceAfter-e.Amount, 0), e.CustomerID)
+	}
+
+	query := `
+		INSERT INTO credit_entries (customer_id, type, amount, balance_after, transaction_id, method, reference, note, user_id, shift_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at`
+	return tx.QueryRow(query, e.CustomerID, e.Type, e.Amount, e.BalanceAfter, e.TransactionID, e.Method, e.Reference, e.Note, e.UserID,
+		e.ShiftID).Scan(&e.ID, &e.CreatedAt)
+}
+
+// Pay mencatat pembayaran kasbon, ditautkan ke shift yang sedang dibuka oleh
+// user penerimanya bila ada. Pembayaran tunai butuh shift.
+func (r *CreditRepository) Pay(e *models.CreditEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if e.ShiftID, err = openShiftID(tx, e.UserID); err != nil {
+		return err
+	}
+	if e.ShiftID == nil && e.Method == models.PaymentCash {
+		return models.NewValidationError("open a shift before taking a cash repayment")
+	}
+	if err := moveCredit(tx, e); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetEntries mengembalikan entri kasbon pelanggan pada [start, end) urut
+// waktu (start nol berarti sejak awal), beserta jumlah amount entri yang
+// dibuat sejak end untuk menghitung saldo di akhir periode.
+func (r *CreditRepository) GetEntries(customerID int, start, end time.Time) ([]models.CreditEntry, int, error) {
+	var after int
+	err := r.db.QueryRow("SELECT COALESCE(SUM(amount), 0) FROM credit_entries WHERE customer_id = $1 AND created_at >= $2",
+		customerID, end).Scan(&after)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, customer_id, type, amount, balance_after, transaction_id, method, reference, note, user_id, shift_id, created_at
+		FROM credit_entries
+		WHERE customer_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at, id`, customerID, start, end)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := make([]models.CreditEntry, 0)
+	for rows.Next() {
+		var e models.CreditEntry
+		err := rows.Scan(&e.ID, &e.CustomerID, &e.Type, &e.Amount, &e.BalanceAfter, &e.TransactionID,
+			&e.Method, &e.Reference, &e.Note, &e.UserID, &e.ShiftID, &e.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, after, rows.Err()
+}
+
+// GetOpenCharges mengembalikan charge yang belum terbayar milik satu
+// pelanggan, tertua dulu.
+func (r *CreditRepository) GetOpenCharges(customerID int) ([]models.OpenCharge, error) {
+	return openCharges(r.db, &customerID)
+}
+
+// openCharges mengembalikan bagian charge yang belum terbayar, tertua dulu
+// per pelanggan. Saldo utang dibagi ke charge terbaru ke belakang (FIFO:
+// pembayaran melunasi yang tertua), jadi jumlah per pelanggan sama dengan
+// credit_balance-nya.
+func openCharges(db queryer, customerID *int) ([]models.OpenCharge, error) {
+	rows, err := db.Query(`
+		SELECT customer_id, transaction_id, created_at, LEAST(amount, balance - (running - amount))
+		FROM (
+			SELECT e.customer_id, e.transaction_id, e.created_at, e.amount, c.credit_balance AS balance,
+				SUM(e.amount) OVER (PARTITION BY e.customer_id ORDER BY e.id DESC) AS running
+			FROM credit_entries e
+			JOIN customers c ON c.id = e.customer_id
+			WHERE e.type = 'charge' AND c.credit_balance > 0
+				AND ($1::int IS NULL OR e.customer_id = $1)
+		) o
+		WHERE running - amount < balance
+		ORDER BY customer_id, created_at`, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	charges := make([]models.OpenCharge, 0)
+	for rows.Next() {
+		var c models.OpenCharge
+		if err := rows.Scan(&c.CustomerID, &c.TransactionID, &c.ChargedAt, &c.Amount); err != nil {
+			return nil, err
+		}
+		charges = append(charges, c)
+	}
+	return charges, rows.Err()
+}
@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"go-kasir-api/models"
+)
+
+type CustomerRepository struct {
+	db *sql.DB
+}
+
+func NewCustomerRepository(db *sql.DB) *CustomerRepository {
+	return &CustomerRepository{db: db}
+}
+
+// customerSelect memilih pelanggan beserta nilai belanja seumur hidupnya
+// (penjualan dikurangi void dan retur), jumlah kunjungan dan tier-nya;
+// dipakai bersama scanCustomer.
+const customerSelect = `
+	SELECT c.id, c.name, c.phone, c.member_card, c.email, c.points, c.credit_limit, c.credit_balance, c.active, c.created_at,
+		COALESCE(s.spend, 0), COALESCE(s.visits, 0), s.last_purchase,
+		lt.id, COALESCE(lt.name, ''), COALESCE(lt.min_spend, 0), COALESCE(lt.bonus_percent, 0)
+	FROM customers c
+	LEFT JOIN LATERAL (
+		SELECT SUM(t.total_amount) AS spend,
+			COUNT(*) FILTER (WHERE t.type = 'sale') AS visits,
+			MAX(t.date) FILTER (WHERE t.type = 'sale') AS last_purchase
+		FROM transactions t
+		WHERE t.customer_id = c.id
+	) s ON TRUE
+	LEFT JOIN LATERAL (
+		SELECT id, name, min_spend, bonus_percent FROM loyalty_tiers
+		WHERE min_spend <= COALESCE(s.spend, 0)
+		ORDER BY min_spend DESC
+		LIMIT 1
+	) lt ON TRUE`
+
+func scanCustomer(row rowScanner) (*models.Customer, error) {
+	var c models.Customer
+	var tierID sql.NullInt64
+	var tier models.LoyaltyTier
+	err := row.Scan(&c.ID, &c.Name, &c.Phone, &c.MemberCard, &c.Email, &c.Points, &c.CreditLimit, &c.CreditBalance, &c.Active, &c.CreatedAt,
+		&c.LifetimeValue, &c.Visits, &c.LastPurchaseAt, &tierID, &tier.Name, &tier.MinSpend, &tier.BonusPercent)
+	if err != nil {
+		return nil, err
+	}
+	if tierID.Valid {
+		tier.ID = int(tierID.Int64)
+		c.Tier = &tier
+	}
+	return &c, nil
+}
+
+// List mengembalikan pelanggan sesuai filter, urut nama, beserta jumlah totalnya.
+func (r *CustomerRepository) List(filter models.CustomerFilter) ([]models.Customer, int, error) {
+	where := ""
+	var args []interface{}
+	if filter.Query != "" {
+		args = append(args, filter.Query, "%"+likeEscaper.Replace(filter.Query)+"%", likeEscaper.Replace(filter.Query)+"%")
+		where = " WHERE c.name ILIKE $2 OR c.phone LIKE $3 OR c.member_card = $1"
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM customers c"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	query := fmt.Sprintf(`%s%s
+		ORDER BY c.name, c.id
+		LIMIT $%d OFFSET $%d`, customerSelect, where, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	customers := make([]models.Customer, 0)
+	for rows.Next() {
+		c, err := scanCustomer(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		customers = append(customers, *c)
+	}
+	return customers, total, rows.Err()
+}
+
+func (r *CustomerRepository) GetByID(id int) (*models.Customer, error) {
+	c, err := scanCustomer(r.db.QueryRow(customerSelect+" WHERE c.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrCustomerNotFound
+	}
+	return c, err
+}
+
+// Create mendaftarkan pelanggan dengan limit kasbon 0; limit diatur lewat
+// Update.
+func (r *CustomerRepository) Create(c *models.Customer) error {
+	query := `
+		INSERT INTO customers (name, phone, member_card, email, active)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, points, credit_limit, credit_balance, created_at`
+	err := r.db.QueryRow(query, c.Name, c.Phone, c.MemberCard, c.Email, c.Active).
+		Scan(&c.ID, &c.Points, &c.CreditLimit, &c.CreditBalance, &c.CreatedAt)
+	if isUniqueViolation(err) {
+		return models.ErrCustomerTaken
+	}
+	return err
+}
+
+// Update mengubah data dan limit kasbon pelanggan; saldo poin dan kasbon
+// tidak ikut diubah.
+func (r *CustomerRepository) Update(c *models.Customer) error {
+	query := "UPDATE customers SET name = $1, phone = $2, member_card = $3, email = $4, active = $5, credit_limit = $6 WHERE id = $7"
+	result, err := r.db.Exec(query, c.Name, c.Phone, c.MemberCard, c.Email, c.Active, c.CreditLimit, c.ID)
+	if isUniqueViolation(err) {
+		return models.ErrCustomerTaken
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrCustomerNotFound
+	}
+	return nil
+}
+
+// Delete menghapus pelanggan yang belum punya transaksi. Pelanggan dengan
+// riwayat belanja cukup dinonaktifkan.
+func (r *CustomerRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM customers WHERE id = $1", id)
+	if isForeignKeyViolation(err) {
+		return models.ErrCustomerInUse
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrCustomerNotFound
+	}
+	return nil
+}
+
+// movePoints adalah satu-satunya jalan untuk mengubah customers.points: saldo
+// diubah sebesar e.Points dan entri ledger ditulis di tx yang sama, lalu
+// BalanceAfter, ID dan CreatedAt diisi. Baris pelanggan terkunci sampai tx
+// selesai. Penukaran dan penyesuaian manual tidak boleh membuat saldo minus;
+// penarikan poin karena void/retur boleh.
+func movePoints(tx *sql.Tx, e *models.LoyaltyPointEntry) error {
+	err := tx.QueryRow("UPDATE customers SET points = points + $1 WHERE id = $2 RETURNING points", e.Points, e.CustomerID).
+		Scan(&e.BalanceAfter)
+	if err == sql.ErrNoRows {
+		return models.ErrCustomerNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if e.BalanceAfter < 0 && e.Points < 0 && (e.Type == models.PointsRedeem || e.Type == models.PointsAdjustment) {
+		return models.NewValidationError("customer %d has only %d points", e.CustomerID, e.BalanceAfter-e.Points)
+	}
+
+	query := `
+		INSERT INTO loyalty_point_entries (customer_id, type, points, balance_after, transaction_id, note, user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+	return tx.QueryRow(query, e.CustomerID, e.Type, e.Points, e.BalanceAfter, e.TransactionID, e.Note, e.UserID).
+		Scan(&e.ID, &e.CreatedAt)
+}
+
+// AdjustPoints mencatat penyesuaian poin manual.
+func (r *CustomerRepository) AdjustPoints(e *models.LoyaltyPointEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := movePoints(tx, e); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListPoints mengembalikan ledger poin satu pelanggan, terbaru dulu.
+func (r *CustomerRepository) ListPoints(customerID, page, pageSize int) ([]models.LoyaltyPointEntry, int, error) {
+	var total int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM loyalty_point_entries WHERE customer_id = $1", customerID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, customer_id, type, points, balance_after, transaction_id, note, user_id, created_at
+		FROM loyalty_point_entries
+		WHERE customer_id = $1
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3`, customerID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := make([]models.LoyaltyPointEntry, 0)
+	for rows.Next() {
+		var e models.LoyaltyPointEntry
+		err := rows.Scan(&e.ID, &e.CustomerID, &e.Type, &e.Points, &e.BalanceAfter, &e.TransactionID, &e.Note, &e.UserID, &e.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, total, rows.Err()
+}
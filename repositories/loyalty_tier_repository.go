@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+)
+
+type LoyaltyTierRepository struct {
+	db *sql.DB
+}
+
+func NewLoyaltyTierRepository(db *sql.DB) *LoyaltyTierRepository {
+	return &LoyaltyTierRepository{db: db}
+}
+
+const loyaltyTierSelect = "SELECT id, name, min_spend, bonus_percent FROM loyalty_tiers"
+
+func scanLoyaltyTier(row rowScanner) (*models.LoyaltyTier, error) {
+	var t models.LoyaltyTier
+	if err := row.Scan(&t.ID, &t.Name, &t.MinSpend, &t.BonusPercent); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetAll mengembalikan semua tier, dari min_spend terendah.
+func (r *LoyaltyTierRepository) GetAll() ([]models.LoyaltyTier, error) {
+	rows, err := r.db.Query(loyaltyTierSelect + " ORDER BY min_spend")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tiers := make([]models.LoyaltyTier, 0)
+	for rows.Next() {
+		t, err := scanLoyaltyTier(rows)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, *t)
+	}
+	return tiers, rows.Err()
+}
+
+func (r *LoyaltyTierRepository) GetByID(id int) (*models.LoyaltyTier, error) {
+	t, err := scanLoyaltyTier(r.db.QueryRow(loyaltyTierSelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrLoyaltyTierNotFound
+	}
+	return t, err
+}
+
+func (r *LoyaltyTierRepository) Create(t *models.LoyaltyTier) error {
+	query := "INSERT INTO loyalty_tiers (name, min_spend, bonus_percent) VALUES ($1, $2, $3) RETURNING id"
+	err := r.db.QueryRow(query, t.Name, t.MinSpend, t.BonusPercent).Scan(&t.ID)
+	if isUniqueViolation(err) {
+		return models.ErrLoyaltyTierTaken
+	}
+	return err
+}
+
+func (r *LoyaltyTierRepository) Update(t *models.LoyaltyTier) error {
+	result, err := r.db.Exec("UPDATE loyalty_tiers SET name = $1, min_spend = $2, bonus_percent = $3 WHERE id = $4",
+		t.Name, t.MinSpend, t.BonusPercent, t.ID)
+	if isUniqueViolation(err) {
+		return models.ErrLoyaltyTierTaken
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrLoyaltyTierNotFound
+	}
+	return nil
+}
+
+// Delete menghapus tier; pelanggannya otomatis masuk tier di bawahnya.
+func (r *LoyaltyTierRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM loyalty_tiers WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrLoyaltyTierNotFound
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+)
+
+type ModifierGroupRepository struct {
+	db *sql.DB
+}
+
+func NewModifierGroupRepository(db *sql.DB) *ModifierGroupRepository {
+	return &ModifierGroupRepository{db: db}
+}
+
+// modifierGroupSelect memilih grup beserta modifier-nya, satu baris per
+// modifier; dipakai bersama scanModifierGroups.
+const modifierGroupSelect = `
+	SELECT g.id, g.name, g.required, g.min_select, g.max_select, m.id, m.name, m.price, m.active
+	FROM modifier_groups g
+	LEFT JOIN modifiers m ON m.group_id = g.id`
+
+// scanModifierGroups menggabungkan baris-baris modifierGroupSelect yang
+// berurutan per grup.
+func scanModifierGroups(rows *sql.Rows) ([]models.ModifierGroup, error) {
+	defer rows.Close()
+
+	groups := make([]models.ModifierGroup, 0)
+	for rows.Next() {
+		var g models.ModifierGroup
+		var modifierID, price sql.NullInt64
+		var name sql.NullString
+		var active sql.NullBool
+		err := rows.Scan(&g.ID, &g.Name, &g.Required, &g.MinSelect, &g.MaxSelect, &modifierID, &name, &price, &active)
+		if err != nil {
+			return nil, err
+		}
+		if len(groups) == 0 || groups[len(groups)-1].ID != g.ID {
+			g.Modifiers = make([]models.Modifier, 0)
+			groups = append(groups, g)
+		}
+		if modifierID.Valid {
+			last := &groups[len(groups)-1]
+			last.Modifiers = append(last.Modifiers, models.Modifier{
+				ID: int(modifierID.Int64), Name: name.String, Price: int(price.Int64), Active: active.Bool,
+			})
+		}
+	}
+	return groups, rows.Err()
+}
+
+func (repo *ModifierGroupRepository) GetAll() ([]models.ModifierGroup, error) {
+	rows, err := repo.db.Query(modifierGroupSelect + " ORDER BY g.name, g.id, m.sort_order, m.id")
+	if err != nil {
+		return nil, err
+	}
+	return scanModifierGroups(rows)
+}
+
+func (repo *ModifierGroupRepository) GetByID(id int) (*models.ModifierGroup, error) {
+	rows, err := repo.db.Query(modifierGroupSelect+" WHERE g.id = $1 ORDER BY m.sort_order, m.id", id)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := scanModifierGroups(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, models.ErrModifierGroupNotFound
+	}
+	return &groups[0], nil
+}
+
+// Create menyimpan grup beserta modifier-nya.
+func (repo *ModifierGroupRepository) Create(g *models.ModifierGroup) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO modifier_groups (name, required, min_select, max_select) VALUES ($1, $2, $3, $4) RETURNING id"
+	if err := tx.QueryRow(query, g.Name, g.Required, g.MinSelect, g.MaxSelect).Scan(&g.ID); err != nil {
+		return err
+	}
+	for i := range g.Modifiers {
+		g.Modifiers[i].ID = 0
+	}
+	if err := saveModifiers(tx, g); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update menyimpan grup. Modifier dengan id diubah, tanpa id ditambahkan, dan
+// yang tidak dikirim lagi dihapus; baris transaksi lama tetap menyimpan nama
+// dan harganya.
+func (repo *ModifierGroupRepository) Update(g *models.ModifierGroup) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "UPDATE modifier_groups SET name = $1, required = $2, min_select = $3, max_select = $4 WHERE id = $5"
+	result, err := tx.Exec(query, g.Name, g.Required, g.MinSelect, g.MaxSelect, g.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrModifierGroupNotFound
+	}
+
+	if err := saveModifiers(tx, g); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func saveModifiers(tx *sql.Tx, g *models.ModifierGroup) error {
+	keep := make([]int, 0, len(g.Modifiers))
+	for _, m := range g.Modifiers {
+		if m.ID != 0 {
+			keep = append(keep, m.ID)
+		}
+	}
+	if _, err := tx.Exec("DELETE FROM modifiers WHERE group_id = $1 AND id <> ALL($2)", g.ID, keep); err != nil {
+		return err
+	}
+
+	for i := range g.Modifiers {
+		m := &g.Modifiers[i]
+		if m.ID == 0 {
+			query := "INSERT INTO modifiers (group_id, name, price, active, sort_order) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+			if err := tx.QueryRow(query, g.ID, m.Name, m.Price, m.Active, i).Scan(&m.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		query := "UPDATE modifiers SET name = $1, price = $2, active = $3, sort_order = $4 WHERE id = $5 AND group_id = $6"
+		result, err := tx.Exec(query, m.Name, m.Price, m.Active, i, m.ID, g.ID)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return models.NewValidationError("modifier %d is not part of group %d", m.ID, g.ID)
+		}
+	}
+	return nil
+}
+
+// Delete menghapus grup dan modifier-nya, juga dari semua produk yang
+// memakainya. Riwayat transaksi tetap menyimpan salinan nama dan harga.
+func (repo *ModifierGroupRepository) Delete(id int) error {
+	result, err := repo.db.Exec("DELETE FROM modifier_groups WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrModifierGroupNotFound
+	}
+	return nil
+}
+
+// GetForProducts mengembalikan grup modifier yang berlaku per produk: grup
+// milik produk induk dulu, lalu grup milik produk itu sendiri.
+func (repo *ModifierGroupRepository) GetForProducts(productIDs []int) (map[int][]models.ModifierGroup, error) {
+	attached, err := productModifierGroups(repo.db, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[int][]models.ModifierGroup, len(attached))
+	for id, a := range attached {
+		groups[id] = a.groups
+	}
+	return groups, nil
+}
+
+// attachedModifiers adalah grup modifier satu produk: ownIDs dipasang langsung,
+// groups adalah semua yang berlaku termasuk dari induk.
+type attachedModifiers struct {
+	ownIDs []int
+	groups []models.ModifierGroup
+}
+
+func productModifierGroups(q queryer, productIDs []int) (map[int]*attachedModifiers, error) {
+	rows, err := q.Query(`
+		SELECT p.id, pmg.product_id = p.id, g.id, g.name, g.required, g.min_select, g.max_select,
+			m.id, m.name, m.price, m.active
+		FROM products p
+		JOIN product_modifier_groups pmg ON pmg.product_id = p.id OR pmg.product_id = p.parent_id
+		JOIN modifier_groups g ON g.id = pmg.group_id
+		LEFT JOIN modifiers m ON m.group_id = g.id
+		WHERE p.id = ANY($1)
+		ORDER BY p.id, pmg.product_id = p.id, pmg.sort_order, g.id, m.sort_order, m.id`, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[int]*attachedModifiers)
+	var lastProduct, lastGroup int
+	var lastOwn, skipping bool
+	for rows.Next() {
+		var productID int
+		var own bool
+		var g models.ModifierGroup
+		var modifierID, price sql.NullInt64
+		var name sql.NullString
+		var active sql.NullBool
+		err := rows.Scan(&productID, &own, &g.ID, &g.Name, &g.Required, &g.MinSelect, &g.MaxSelect,
+			&modifierID, &name, &price, &active)
+		if err != nil {
+			return nil, err
+		}
+
+		a, ok := result[productID]
+		if !ok {
+			a = &attachedModifiers{ownIDs: make([]int, 0), groups: make([]models.ModifierGroup, 0)}
+			result[productID] = a
+		}
+		if productID != lastProduct || g.ID != lastGroup || own != lastOwn {
+			lastProduct, lastGroup, lastOwn = productID, g.ID, own
+			if own {
+				a.ownIDs = append(a.ownIDs, g.ID)
+			}
+			// Grup yang dipasang di induk dan di varian sekaligus cukup sekali.
+			skipping = containsGroup(a.groups, g.ID)
+			if !skipping {
+				g.Modifiers = make([]models.Modifier, 0)
+				a.groups = append(a.groups, g)
+			}
+		}
+		if skipping || !modifierID.Valid {
+			continue
+		}
+		last := &a.groups[len(a.groups)-1]
+		last.Modifiers = append(last.Modifiers, models.Modifier{
+			ID: int(modifierID.Int64), Name: name.String, Price: int(price.Int64), Active: active.Bool,
+		})
+	}
+	return result, rows.Err()
+}
+
+func containsGroup(groups []models.ModifierGroup, id int) bool {
+	for _, g := range groups {
+		if g.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// saveProductModifierGroups mengganti grup modifier yang dipasang langsung
+// pada produk, dengan urutan sesuai ModifierGroupIDs.
+func saveProductModifierGroups(tx *sql.Tx, product *models.Product) error {
+	if _, err := tx.Exec("DELETE FROM product_modifier_groups WHERE product_id = $1", product.ID); err != nil {
+		return err
+	}
+	for i, groupID := range product.ModifierGroupIDs {
+		_, err := tx.Exec("INSERT INTO product_modifier_groups (product_id, group_id, sort_order) VALUES ($1, $2, $3)",
+			product.ID, groupID, i)
+		if isForeignKeyViolation(err) {
+			return models.NewValidationError("modifier group %d not found", groupID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+)
+
+type ProductImportRepository struct {
+	db *sql.DB
+}
+
+func NewProductImportRepository(db *sql.DB) *ProductImportRepository {
+	return &ProductImportRepository{db: db}
+}
+
+const importJobSelect = `
+	SELECT id, status, dry_run, file_name, format, total_rows, processed_rows, created_rows, updated_rows, failed_rows,
+		message, created_by, created_at, started_at, finished_at
+	FROM product_import_jobs`
+
+func scanImportJob(row rowScanner) (*models.ProductImportJob, error) {
+	var j models.ProductImportJob
+	err := row.Scan(&j.ID, &j.Status, &j.DryRun, &j.FileName, &j.Format, &j.TotalRows, &j.Processed, &j.Created, &j.Updated, &j.Failed,
+		&j.Message, &j.CreatedBy, &j.CreatedAt, &j.StartedAt, &j.FinishedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// GetAll mengembalikan job impor terbaru lebih dulu, tanpa error per baris.
+func (r *ProductImportRepository) GetAll() ([]models.ProductImportJob, error) {
+	rows, err := r.db.Query(importJobSelect + " ORDER BY id DESC LIMIT 100")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]models.ProductImportJob, 0)
+	for rows.Next() {
+		j, err := scanImportJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *j)
+	}
+	return jobs, rows.Err()
+}
+
+// GetByID mengambil job beserta semua error per barisnya.
+func (r *ProductImportRepository) GetByID(id int) (*models.ProductImportJob, error) {
+	j, err := scanImportJob(r.db.QueryRow(importJobSelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrImportJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT row_number, column_name, message FROM product_import_errors
+		WHERE job_id = $1 ORDER BY row_number, id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	j.Errors = make([]models.ImportRowError, 0)
+	for rows.Next() {
+		var e models.ImportRowError
+		if err := rows.Scan(&e.Row, &e.Column, &e.Message); err != nil {
+			return nil, err
+		}
+		j.Errors = append(j.Errors, e)
+	}
+	return j, rows.Err()
+}
+
+// Create mencatat job baru dengan status pending.
+func (r *ProductImportRepository) Create(j *models.ProductImportJob) error {
+	query := `
+		INSERT INTO product_import_jobs (dry_run, file_name, format, total_rows, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, created_at`
+	return r.db.QueryRow(query, j.DryRun, j.FileName, j.Format, j.TotalRows, j.CreatedBy).Scan(&j.ID, &j.Status, &j.CreatedAt)
+}
+
+// Start menandai job mulai diproses.
+func (r *ProductImportRepository) Start(j *models.ProductImportJob) error {
+	return r.db.QueryRow(`
+		UPDATE product_import_jobs SET status = $2, started_at = CURRENT_TIMESTAMP
+		WHERE id = $1 RETURNING started_at`, j.ID, models.ImportRunning).Scan(&j.StartedAt)
+}
+
+// SaveProgress menyimpan penghitung job dan menambahkan error baris baru.
+func (r *ProductImportRepository) SaveProgress(j *models.ProductImportJob, rowErrors []models.ImportRowError) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := saveImportProgress(tx, j, rowErrors); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Finish menyimpan hasil akhir job: status completed atau failed, penghitung,
+// pesan dan error baris yang tersisa.
+func (r *ProductImportRepository) Finish(j *models.ProductImportJob, rowErrors []models.ImportRowError) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := saveImportProgress(tx, j, rowErrors); err != nil {
+		return err
+	}
+	err = tx.QueryRow(`
+		UPDATE product_import_jobs SET status = $2, message = $3, finished_at = CURRENT_TIMESTAMP
+		WHERE id = $1 RETURNING finished_at`, j.ID, j.Status, j.Message).Scan(&j.FinishedAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func saveImportProgress(tx *sql.Tx, j *models.ProductImportJob, rowErrors []models.ImportRowError) error {
+	_, err := tx.Exec(`
+		UPDATE product_import_jobs
+		SET processed_rows = $2, created_rows = $3, updated_rows = $4, failed_rows = $5
+		WHERE id = $1`, j.ID, j.Processed, j.Created, j.Updated, j.Failed)
+	if err != nil {
+		return err
+	}
+	for _, e := range rowErrors {
+		_, err := tx.Exec(`
+			INSERT INTO product_import_errors (job_id, row_number, column_name, message)
+			VALUES ($1, $2, $3, $4)`, j.ID, e.Row, e.Column, e.Message)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FailUnfinished menandai job yang masih pending atau running sebagai failed;
+// dipanggil saat server mulai karena job itu terputus oleh restart.
+func (r *ProductImportRepository) FailUnfinished(message string) error {
+	_, err := r.db.Exec(`
+		UPDATE product_import_jobs SET status = $1, message = $2, finished_at = CURRENT_TIMESTAMP
+		WHERE status IN ($3, $4)`, models.ImportFailed, message, models.ImportPending, models.ImportRunning)
+	return err
+}
@@ -2,8 +2,9 @@ package repositories
 
 import (
 	"database/sql"
-	"errors"
+	"fmt"
 	"go-kasir-api/models"
+	"strings"
 )
 
 type ProductRepository struct {
@@ -14,9 +15,38 @@ func NewProductRepository(db *sql.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-func (repo *ProductRepository) GetAll() ([]models.Product, error) {
-	query := "SELECT id, name, price, stock FROM products"
-	rows, err := repo.db.Query(query)
+// productSelect memilih kolom produk beserta kategorinya; dipakai bersama scanProduct.
+const productSelect = `
+	SELECT p.id, p.name, COALESCE(p.sku, ''), p.price, p.cost, p.stock, p.min_stock, p.reorder_quantity,
+		p.unit, p.decimals, p.category_id, c.name, p.tax_rate_id, c.tax_rate_id, p.parent_id,
+		EXISTS (SELECT 1 FROM products v WHERE v.parent_id = p.id)
+	FROM products p
+	LEFT JOIN categories c ON c.id = p.category_id`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProduct(row rowScanner) (*models.Product, error) {
+	var p models.Product
+	var categoryID sql.NullInt64
+	var categoryName sql.NullString
+	var categoryTaxRateID *int
+	err := row.Scan(&p.ID, &p.Name, &p.SKU, &p.Price, &p.Cost, &p.Stock, &p.MinStock, &p.ReorderQuantity, &p.Unit, &p.Decimals, &categoryID, &categoryName,
+		&p.TaxRateID, &categoryTaxRateID, &p.ParentID, &p.HasVariants)
+	if err != nil {
+		return nil, err
+	}
+	if categoryID.Valid {
+		id := int(categoryID.Int64)
+		p.CategoryID = &id
+		p.Category = &models.Category{ID: id, Name: categoryName.String, TaxRateID: categoryTaxRateID}
+	}
+	return &p, nil
+}
+
+func (repo *ProductRepository) queryProducts(query string, args ...interface{}) ([]models.Product, error) {
+	rows, err := repo.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -24,61 +54,521 @@ func (repo *ProductRepository) GetAll() ([]models.Product, error) {
 
 	products := make([]models.Product, 0)
 	for rows.Next() {
-		var p models.Product
-		err := rows.Scan(&p.ID, &p.Name, &p.Price, &p.Stock)
+		p, err := scanProduct(rows)
 		if err != nil {
 			return nil, err
 		}
-		products = append(products, p)
+		products = append(products, *p)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
 
+	if err := repo.attachDetails(products); err != nil {
+		return nil, err
+	}
 	return products, nil
 }
 
-func (repo *ProductRepository) Create(product *models.Product) error {
-	query := "INSERT INTO products (name, price, stock) VALUES ($1, $2, $3) RETURNING id"
-	err := repo.db.QueryRow(query, product.Name, product.Price, product.Stock).Scan(&product.ID)
+// productSortColumns memetakan urutan daftar produk ke kolom dan tipe nilai
+// cursor-nya.
+var productSortColumns = map[string]struct{ column, cast string }{
+	models.ProductSortID:    {"p.id", ""},
+	models.ProductSortName:  {"p.name", "text"},
+	models.ProductSortPrice: {"p.price", "int"},
+	models.ProductSortStock: {"p.stock", "numeric"},
+}
+
+// likeEscaper meloloskan karakter wildcard LIKE dari kata kunci pencarian.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// List mengembalikan produk tingkat atas (varian ada di dalam induknya) sesuai
+// filter beserta jumlah totalnya. Query dicocokkan sebagai potongan atau
+// awalan nama, nama yang mirip (salah ketik), awalan SKU atau barcode persis,
+// termasuk SKU dan barcode variannya. Dengan cursor, satu baris lebih diambil
+// untuk menandai masih ada halaman berikutnya.
+func (repo *ProductRepository) List(filter models.ProductFilter) ([]models.Product, int, error) {
+	conditions := []string{"p.parent_id IS NULL"}
+	var args []interface{}
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	var relevance string
+	if filter.Query != "" {
+		args = append(args, filter.Query, "%"+likeEscaper.Replace(filter.Query)+"%", likeEscaper.Replace(filter.Query)+"%")
+		q, contains, prefix := len(args)-2, len(args)-1, len(args)
+		conditions = append(conditions, fmt.Sprintf(`(
+			p.name ILIKE $%[2]d OR $%[1]d <%% p.name OR p.sku ILIKE $%[3]d
+			OR EXISTS (SELECT 1 FROM product_barcodes b WHERE b.product_id = p.id AND b.code = $%[1]d)
+			OR EXISTS (
+				SELECT 1 FROM products v
+				LEFT JOIN product_barcodes vb ON vb.product_id = v.id
+				WHERE v.parent_id = p.id AND (v.sku ILIKE $%[3]d OR vb.code = $%[1]d)
+			))`, q, contains, prefix))
+		relevance = fmt.Sprintf("p.name ILIKE $%[2]d DESC, word_similarity($%[1]d, p.name) DESC, p.id", q, prefix)
+	}
+	if filter.CategoryID != nil {
+		addCondition("p.category_id = $%d", *filter.CategoryID)
+	}
+	if filter.MinPrice != nil {
+		addCondition("p.price >= $%d", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		addCondition("p.price <= $%d", *filter.MaxPrice)
+	}
+	if filter.InStock {
+		conditions = append(conditions, "(p.stock > 0 OR EXISTS (SELECT 1 FROM products v WHERE v.parent_id = p.id AND v.stock > 0))")
+	}
+
+	var total int
+	where := " WHERE " + strings.Join(conditions, " AND ")
+	if err := repo.db.QueryRow("SELECT COUNT(*) FROM products p"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order := relevance
+	if filter.Sort != models.ProductSortRelevance {
+		key := strings.TrimPrefix(filter.Sort, "-")
+		sort := productSortColumns[key]
+		direction, compare := "", ">"
+		if key != filter.Sort {
+			direction, compare = " DESC", "<"
+		}
+		order = sort.column + direction
+		if sort.column != "p.id" {
+			order += ", p.id" + direction
+		}
+
+		if filter.After != nil {
+			if sort.column == "p.id" {
+				addCondition("p.id "+compare+" $%d", filter.After.ID)
+			} else {
+				args = append(args, filter.After.Value, filter.After.ID)
+				conditions = append(conditions, fmt.Sprintf("(%s, p.id) %s ($%d::%s, $%d)",
+					sort.column, compare, len(args)-1, sort.cast, len(args)))
+			}
+			where = " WHERE " + strings.Join(conditions, " AND ")
+		}
+	}
+
+	if filter.After != nil {
+		args = append(args, filter.PageSize+1)
+		products, err := repo.queryProducts(fmt.Sprintf("%s%s ORDER BY %s LIMIT $%d", productSelect, where, order, len(args)), args...)
+		return products, total, err
+	}
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	query := fmt.Sprintf("%s%s ORDER BY %s LIMIT $%d OFFSET $%d", productSelect, where, order, len(args)-1, len(args))
+	products, err := repo.queryProducts(query, args...)
+	return products, total, err
+}
+
+// GetByCategoryID - ambil semua produk dalam satu kategori
+func (repo *ProductRepository) GetByCategoryID(categoryID int) ([]models.Product, error) {
+	return repo.queryProducts(productSelect+" WHERE p.category_id = $1 AND p.parent_id IS NULL ORDER BY p.id", categoryID)
+}
+
+// attachDetails mengisi barcode, satuan, opsi dan varian, serta grup modifier
+// untuk produk yang sudah di-scan.
+func (repo *ProductRepository) attachDetails(products []models.Product) error {
+	if err := repo.attachBarcodes(products); err != nil {
+		return err
+	}
+	if err := repo.attachUnits(products); err != nil {
+		return err
+	}
+	if err := repo.attachModifierGroups(products); err != nil {
+		return err
+	}
+	return repo.attachVariants(products)
+}
+
+func (repo *ProductRepository) attachModifierGroups(products []models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	ids := make([]int, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+	}
+
+	attached, err := productModifierGroups(repo.db, ids)
+	if err != nil {
+		return err
+	}
+	for i := range products {
+		p := &products[i]
+		p.ModifierGroupIDs = make([]int, 0)
+		if a, ok := attached[p.ID]; ok {
+			p.ModifierGroupIDs, p.ModifierGroups = a.ownIDs, a.groups
+		}
+	}
+	return nil
+}
+
+// attachBarcodes mengisi Barcodes setiap produk dengan satu query.
+func (repo *ProductRepository) attachBarcodes(products []models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	ids := make([]int, len(products))
+	index := make(map[int]int, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+		index[products[i].ID] = i
+		products[i].Barcodes = make([]models.ProductBarcode, 0)
+	}
+
+	rows, err := repo.db.Query("SELECT product_id, id, code, type FROM product_barcodes WHERE product_id = ANY($1) ORDER BY id", ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID int
+		var b models.ProductBarcode
+		if err := rows.Scan(&productID, &b.ID, &b.Code, &b.Type); err != nil {
+			return err
+		}
+		p := &products[index[productID]]
+		p.Barcodes = append(p.Barcodes, b)
+	}
+
+	return rows.Err()
+}
+
+// saveBarcodes mengganti semua barcode produk di dalam tx. Kode yang sudah
+// dipakai produk lain ditolak dengan ErrBarcodeTaken.
+func saveBarcodes(tx *sql.Tx, product *models.Product) error {
+	if _, err := tx.Exec("DELETE FROM product_barcodes WHERE product_id = $1", product.ID); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO product_barcodes (product_id, code, type) VALUES ($1, $2, $3) RETURNING id"
+	for i := range product.Barcodes {
+		b := &product.Barcodes[i]
+		err := tx.QueryRow(query, product.ID, b.Code, b.Type).Scan(&b.ID)
+		if uniqueConstraint(err) == "product_barcodes_code_key" {
+			return fmt.Errorf("%s: %w", b.Code, models.ErrBarcodeTaken)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// productWriteError memetakan pelanggaran unique pada SKU.
+func productWriteError(err error) error {
+	if uniqueConstraint(err) == "products_sku_key" {
+		return models.ErrSKUTaken
+	}
 	return err
 }
 
+// Create menyimpan produk baru. Stok awalnya masuk lewat ledger sebagai
+// entri opening atas nama userID.
+func (repo *ProductRepository) Create(product *models.Product, userID *int) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := createProduct(tx, product, userID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return repo.attachCreated(product)
+}
+
+// attachCreated mengisi grup modifier yang berlaku untuk response produk baru.
+func (repo *ProductRepository) attachCreated(product *models.Product) error {
+	products := []models.Product{*product}
+	if err := repo.attachModifierGroups(products); err != nil {
+		return err
+	}
+	product.ModifierGroups = products[0].ModifierGroups
+	return nil
+}
+
+// createProduct menyisipkan baris produk (atau varian bila ParentID terisi),
+// barcode-nya, dan stok awal di dalam tx.
+func createProduct(tx *sql.Tx, product *models.Product, userID *int) error {
+	query := `
+		INSERT INTO products (name, sku, price, cost, stock, min_stock, reorder_quantity, unit, decimals, category_id, tax_rate_id, parent_id)
+		VALUES ($1, NULLIF($2, ''), $3, $4, 0, $5, $6, $7, $8, $9, $10, $11) RETURNING id`
+	err := tx.QueryRow(query, product.Name, product.SKU, product.Price, product.Cost, product.MinStock, product.ReorderQuantity,
+		product.Unit, product.Decimals, product.CategoryID, product.TaxRateID, product.ParentID).Scan(&product.ID)
+	if err != nil {
+		return productWriteError(err)
+	}
+
+	if product.Barcodes == nil {
+		product.Barcodes = make([]models.ProductBarcode, 0)
+	}
+	if err := saveBarcodes(tx, product); err != nil {
+		return err
+	}
+	if product.Units == nil {
+		product.Units = make([]models.ProductUnit, 0)
+	}
+	if err := saveUnits(tx, product); err != nil {
+		return err
+	}
+	if product.ModifierGroupIDs == nil {
+		product.ModifierGroupIDs = make([]int, 0)
+	}
+	if err := saveProductModifierGroups(tx, product); err != nil {
+		return err
+	}
+
+	if product.Stock != 0 {
+		err := moveStock(tx, &models.StockMovement{
+			ProductID: product.ID,
+			Type:      models.StockOpening,
+			Quantity:  product.Stock,
+			Note:      "initial stock",
+			UserID:    userID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetByID - ambil produk by ID
 func (repo *ProductRepository) GetByID(id int) (*models.Product, error) {
-	query := "SELECT id, name, price, stock FROM products WHERE id = $1"
+	return repo.getOne(productSelect+" WHERE p.id = $1", id)
+}
 
-	var p models.Product
-	err := repo.db.QueryRow(query, id).Scan(&p.ID, &p.Name, &p.Price, &p.Stock)
+// GetByIDs mengambil beberapa produk sekaligus; ID yang tidak ada dilewati.
+func (repo *ProductRepository) GetByIDs(ids []int) ([]models.Product, error) {
+	return repo.queryProducts(productSelect+" WHERE p.id = ANY($1) ORDER BY p.id", ids)
+}
+
+// GetBySKU mencari produk (termasuk varian) lewat SKU persis.
+func (repo *ProductRepository) GetBySKU(sku string) (*models.Product, error) {
+	return repo.getOne(productSelect+" WHERE p.sku = $1", sku)
+}
+
+// GetCatalog mengambil semua produk untuk ekspor: setiap induk langsung
+// diikuti variannya.
+func (repo *ProductRepository) GetCatalog() ([]models.Product, error) {
+	return repo.queryProducts(productSelect + " ORDER BY COALESCE(p.parent_id, p.id), p.parent_id NULLS FIRST, p.id")
+}
+
+// GetByCode mencari produk lewat barcode atau SKU.
+func (repo *ProductRepository) GetByCode(code string) (*models.Product, error) {
+	return repo.getOne(productSelect+`
+		WHERE p.sku = $1
+			OR EXISTS (SELECT 1 FROM product_barcodes b WHERE b.product_id = p.id AND b.code = $1)
+		LIMIT 1`, code)
+}
+
+// GetByPLU mencari produk timbang lewat kode PLU di label timbangan.
+func (repo *ProductRepository) GetByPLU(plu string) (*models.Product, error) {
+	return repo.getOne(productSelect+`
+		WHERE EXISTS (SELECT 1 FROM product_barcodes b WHERE b.product_id = p.id AND b.code = $1 AND b.type = 'plu')`, plu)
+}
+
+// getOne mengambil satu produk beserta barcodenya.
+func (repo *ProductRepository) getOne(query string, args ...interface{}) (*models.Product, error) {
+	p, err := scanProduct(repo.db.QueryRow(query, args...))
 	if err == sql.ErrNoRows {
-		return nil, errors.New("produk tidak ditemukan")
+		return nil, models.ErrProductNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &p, nil
+	products := []models.Product{*p}
+	if err := repo.attachDetails(products); err != nil {
+		return nil, err
+	}
+	return &products[0], nil
 }
 
+// GetByIDForUpdate - ambil produk by ID dan kunci barisnya sampai tx selesai.
+// Kategori ikut dibaca (tanpa dikunci) untuk tarif pajaknya.
+func (repo *ProductRepository) GetByIDForUpdate(tx *sql.Tx, id int) (*models.Product, error) {
+	p, err := scanProduct(tx.QueryRow(productSelect+" WHERE p.id = $1 FOR UPDATE OF p", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Update menyimpan perubahan produk. Stok tidak ikut diubah: perubahan stok
+// hanya lewat ledger, dan product.Stock diisi stok saat ini.
 func (repo *ProductRepository) Update(product *models.Product) error {
-	query := "UPDATE products SET name = $1, price = $2, stock = $3 WHERE id = $4"
-	result, err := repo.db.Exec(query, product.Name, product.Price, product.Stock, product.ID)
+	tx, err := repo.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rows, err := result.RowsAffected()
+	if err := updateProduct(tx, product); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	products := []models.Product{*product}
+	if err := repo.attachBarcodes(products); err != nil {
+		return err
+	}
+	if err := repo.attachUnits(products); err != nil {
+		return err
+	}
+	if err := repo.attachModifierGroups(products); err != nil {
+		return err
+	}
+	product.Barcodes, product.Units = products[0].Barcodes, products[0].Units
+	product.ModifierGroupIDs, product.ModifierGroups = products[0].ModifierGroupIDs, products[0].ModifierGroups
+	return nil
+}
+
+// SaveImported menyimpan satu baris import dalam satu tx. Bila newCategory
+// diisi, kategori itu dibuat lebih dulu dan dipasang ke produk, sehingga ikut
+// batal bila produknya gagal disimpan. Produk tanpa ID dibuat dengan stock
+// sebagai stok awal; produk yang sudah ada diperbarui dan selisih stoknya
+// terhadap stock dicatat sebagai penyesuaian di ledger atas nama userID.
+func (repo *ProductRepository) SaveImported(product *models.Product, stock models.Quantity, newCategory string, userID *int) error {
+	tx, err := repo.db.Begin()
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	if rows == 0 {
-		return errors.New("produk tidak ditemukan")
+	if newCategory != "" {
+		var categoryID int
+		if err := tx.QueryRow("INSERT INTO categories (name) VALUES ($1) RETURNING id", newCategory).Scan(&categoryID); err != nil {
+			return err
+		}
+		product.CategoryID = &categoryID
 	}
 
+	if product.ID == 0 {
+		product.Stock = stock
+		if err := createProduct(tx, product, userID); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	if err := updateProduct(tx, product); err != nil {
+		return err
+	}
+	if stock != product.Stock {
+		err := moveStock(tx, &models.StockMovement{
+			ProductID: product.ID,
+			Type:      models.StockAdjustment,
+			Quantity:  stock - product.Stock,
+			Note:      "stock set by import",
+			UserID:    userID,
+		})
+		if err != nil {
+			return err
+		}
+		product.Stock = stock
+	}
+	return tx.Commit()
+}
+
+// updateProduct menulis field produk di tx dan mengisi product.Stock dengan
+// stok saat ini. Baris produk terkunci sampai tx selesai.
+func updateProduct(tx *sql.Tx, product *models.Product) error {
+	query := `
+		UPDATE products
+		SET name = $1, sku = NULLIF($2, ''), price = $3, cost = $4, min_stock = $5, reorder_quantity = $6,
+			unit = $7, decimals = $8, category_id = $9, tax_rate_id = $10
+		WHERE id = $11
+		RETURNING stock`
+	err := tx.QueryRow(query, product.Name, product.SKU, product.Price, product.Cost, product.MinStock, product.ReorderQuantity,
+		product.Unit, product.Decimals, product.CategoryID, product.TaxRateID, product.ID).Scan(&product.Stock)
+	if err == sql.ErrNoRows {
+		return models.ErrProductNotFound
+	}
+	if err != nil {
+		return productWriteError(err)
+	}
+
+	// Varian selalu mengikuti kategori dan tarif pajak induknya.
+	_, err = tx.Exec("UPDATE products SET category_id = $1, tax_rate_id = $2 WHERE parent_id = $3",
+		product.CategoryID, product.TaxRateID, product.ID)
+	if err != nil {
+		return err
+	}
+
+	if product.Barcodes != nil {
+		if err := saveBarcodes(tx, product); err != nil {
+			return err
+		}
+	}
+	if product.Units != nil {
+		if err := saveUnits(tx, product); err != nil {
+			return err
+		}
+	}
+	if product.ModifierGroupIDs != nil {
+		if err := saveProductModifierGroups(tx, product); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// GetLowStock mengembalikan produk dengan stok di bawah min_stock beserta qty
+// yang masih dipesan di PO open/partial (dalam satuan dasar), yang paling
+// kritis dulu.
+func (repo *ProductRepository) GetLowStock() ([]models.LowStockItem, error) {
+	query := `
+		SELECT p.id, p.name, p.stock, p.min_stock, p.reorder_quantity,
+			COALESCE(SUM((l.quantity - l.received_quantity) * l.unit_factor) FILTER (WHERE po.status IN ('open', 'partial')), 0)
+		FROM products p
+		LEFT JOIN purchase_order_lines l ON l.product_id = p.id
+		LEFT JOIN purchase_orders po ON po.id = l.purchase_order_id
+		WHERE p.stock < p.min_stock
+			AND NOT EXISTS (SELECT 1 FROM products v WHERE v.parent_id = p.id)
+		GROUP BY p.id
+		ORDER BY p.stock::float8 / p.min_stock, p.id`
+
+	rows, err := repo.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]models.LowStockItem, 0)
+	for rows.Next() {
+		var item models.LowStockItem
+		err := rows.Scan(&item.ProductID, &item.Name, &item.Stock, &item.MinStock, &item.ReorderQuantity, &item.OnOrder)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
 func (repo *ProductRepository) Delete(id int) error {
 	query := "DELETE FROM products WHERE id = $1"
 	result, err := repo.db.Exec(query, id)
+	if isForeignKeyViolation(err) {
+		return models.ErrProductInUse
+	}
 	if err != nil {
 		return err
 	}
@@ -88,8 +578,8 @@ func (repo *ProductRepository) Delete(id int) error {
 	}
 
 	if rows == 0 {
-		return errors.New("produk tidak ditemukan")
+		return models.ErrProductNotFound
 	}
 
 	return err
-}
\ No newline at end of file
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+)
+
+// attachUnits mengisi Units setiap produk dengan satu query.
+func (repo *ProductRepository) attachUnits(products []models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	ids := make([]int, len(products))
+	for i := range products {
+		ids[i] = products[i].ID
+		products[i].Units = make([]models.ProductUnit, 0)
+	}
+
+	units, err := productUnits(repo.db, ids)
+	if err != nil {
+		return err
+	}
+	for i := range products {
+		if u, ok := units[products[i].ID]; ok {
+			products[i].Units = u
+		}
+	}
+	return nil
+}
+
+// GetUnits mengembalikan semua satuan yang bisa dipakai per produk, satuan
+// dasar (ID 0, isi 1) lebih dulu, untuk mengonversi qty checkout ke satuan
+// dasar sebelum stok dikunci.
+func (repo *ProductRepository) GetUnits(productIDs []int) (map[int][]models.ProductUnit, error) {
+	rows, err := repo.db.Query(`
+		SELECT id, 0, unit, 1::numeric, NULL::int FROM products WHERE id = ANY($1)
+		UNION ALL
+		SELECT product_id, id, name, factor, price FROM product_units WHERE product_id = ANY($1)
+		ORDER BY 1, 2`, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	return scanProductUnits(rows)
+}
+
+func productUnits(q queryer, productIDs []int) (map[int][]models.ProductUnit, error) {
+	rows, err := q.Query("SELECT product_id, id, name, factor, price FROM product_units WHERE product_id = ANY($1) ORDER BY factor, id", productIDs)
+	if err != nil {
+		return nil, err
+	}
+	return scanProductUnits(rows)
+}
+
+func scanProductUnits(rows *sql.Rows) (map[int][]models.ProductUnit, error) {
+	defer rows.Close()
+
+	units := make(map[int][]models.ProductUnit)
+	for rows.Next() {
+		var productID int
+		var u models.ProductUnit
+		if err := rows.Scan(&productID, &u.ID, &u.Name, &u.Factor, &u.Price); err != nil {
+			return nil, err
+		}
+		units[productID] = append(units[productID], u)
+	}
+	return units, rows.Err()
+}
+
+// saveUnits mengganti semua satuan lain produk di dalam tx. Baris transaksi
+// dan PO menyimpan salinan nama dan isi satuannya sendiri.
+func saveUnits(tx *sql.Tx, product *models.Product) error {
+	if _, err := tx.Exec("DELETE FROM product_units WHERE product_id = $1", product.ID); err != nil {
+		return err
+	}
+
+	query := "INSERT INTO product_units (product_id, name, factor, price) VALUES ($1, $2, $3, $4) RETURNING id"
+	for i := range product.Units {
+		u := &product.Units[i]
+		err := tx.QueryRow(query, product.ID, u.Name, u.Factor, u.Price).Scan(&u.ID)
+		if uniqueConstraint(err) == "product_units_product_id_name_key" {
+			return models.NewValidationError("unit %q is listed twice", u.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
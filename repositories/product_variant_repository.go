@@ -0,0 +1,272 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+	"sort"
+	"strings"
+)
+
+// attachVariants mengisi Options untuk baris varian, serta OptionGroups dan
+// Variants untuk produk induk.
+func (repo *ProductRepository) attachVariants(products []models.Product) error {
+	var variantIDs, parentIDs []int
+	variantIndex := make(map[int][]int)
+	parentIndex := make(map[int][]int)
+	for i := range products {
+		p := &products[i]
+		if p.ParentID != nil {
+			p.Options = make([]models.VariantOption, 0)
+			variantIDs = append(variantIDs, p.ID)
+			variantIndex[p.ID] = append(variantIndex[p.ID], i)
+			continue
+		}
+		if p.HasVariants {
+			p.OptionGroups = make([]models.ProductOptionGroup, 0)
+			p.Variants = make([]models.Product, 0)
+			parentIDs = append(parentIDs, p.ID)
+			parentIndex[p.ID] = append(parentIndex[p.ID], i)
+		}
+	}
+
+	if len(variantIDs) > 0 {
+		rows, err := repo.db.Query(`
+			SELECT vo.variant_id, g.name, v.value
+			FROM product_variant_options vo
+			JOIN product_option_values v ON v.id = vo.value_id
+			JOIN product_option_groups g ON g.id = v.group_id
+			WHERE vo.variant_id = ANY($1)
+			ORDER BY vo.variant_id, g.id`, variantIDs)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var variantID int
+			var o models.VariantOption
+			if err := rows.Scan(&variantID, &o.Group, &o.Value); err != nil {
+				return err
+			}
+			for _, i := range variantIndex[variantID] {
+				products[i].Options = append(products[i].Options, o)
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		rows.Close()
+	}
+
+	if len(parentIDs) == 0 {
+		return nil
+	}
+
+	groups, err := repo.optionGroups(parentIDs)
+	if err != nil {
+		return err
+	}
+	for parentID, g := range groups {
+		for _, i := range parentIndex[parentID] {
+			products[i].OptionGroups = g
+		}
+	}
+
+	variants, err := repo.queryProducts(productSelect+" WHERE p.parent_id = ANY($1) ORDER BY p.id", parentIDs)
+	if err != nil {
+		return err
+	}
+	for _, v := range variants {
+		for _, i := range parentIndex[*v.ParentID] {
+			products[i].Variants = append(products[i].Variants, v)
+		}
+	}
+	return nil
+}
+
+// optionGroups mengembalikan grup opsi beserta nilainya per produk induk.
+func (repo *ProductRepository) optionGroups(parentIDs []int) (map[int][]models.ProductOptionGroup, error) {
+	rows, err := repo.db.Query(`
+		SELECT g.product_id, g.id, g.name, v.id, v.value
+		FROM product_option_groups g
+		JOIN product_option_values v ON v.group_id = g.id
+		WHERE g.product_id = ANY($1)
+		ORDER BY g.product_id, g.id, v.id`, parentIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make(map[int][]models.ProductOptionGroup)
+	for rows.Next() {
+		var parentID, groupID int
+		var groupName string
+		var v models.ProductOptionValue
+		if err := rows.Scan(&parentID, &groupID, &groupName, &v.ID, &v.Value); err != nil {
+			return nil, err
+		}
+		list := groups[parentID]
+		if len(list) == 0 || list[len(list)-1].ID != groupID {
+			list = append(list, models.ProductOptionGroup{ID: groupID, Name: groupName})
+		}
+		last := &list[len(list)-1]
+		last.Values = append(last.Values, v)
+		groups[parentID] = list
+	}
+	return groups, rows.Err()
+}
+
+// CreateVariant menyimpan varian baru di bawah parentID. Induk dikunci
+// selama transaksi supaya grup opsi dan kombinasi nilai tetap konsisten:
+// varian pertama menentukan grup opsi induk, varian berikutnya harus memakai
+// grup yang sama (nama dicocokkan tanpa memandang huruf besar/kecil) dengan
+// kombinasi nilai yang belum dipakai.
+func (repo *ProductRepository) CreateVariant(parentID int, variant *models.Product, userID *int) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var grandParentID *int
+	var stock models.Quantity
+	err = tx.QueryRow("SELECT parent_id, stock FROM products WHERE id = $1 FOR UPDATE", parentID).Scan(&grandParentID, &stock)
+	if err == sql.ErrNoRows {
+		return models.ErrProductNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if grandParentID != nil {
+		return models.NewValidationError("product %d is itself a variant", parentID)
+	}
+	if stock != 0 {
+		return models.NewValidationError("product %d still has %s in stock; move it to a variant before adding variants", parentID, stock)
+	}
+
+	existing, err := lockedOptionGroups(tx, parentID)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 && len(existing) != len(variant.Options) {
+		return models.NewValidationError("variants of product %d must set exactly these options: %s", parentID, groupNames(existing))
+	}
+
+	valueIDs := make([]int, 0, len(variant.Options))
+	for i := range variant.Options {
+		o := &variant.Options[i]
+		var groupID int
+		if len(existing) > 0 {
+			g, ok := existing[strings.ToLower(o.Group)]
+			if !ok {
+				return models.NewValidationError("variants of product %d must set exactly these options: %s", parentID, groupNames(existing))
+			}
+			groupID, o.Group = g.ID, g.Name
+			if valueID, ok := matchValue(g.Values, o); ok {
+				valueIDs = append(valueIDs, valueID)
+				continue
+			}
+		} else {
+			err := tx.QueryRow("INSERT INTO product_option_groups (product_id, name) VALUES ($1, $2) RETURNING id",
+				parentID, o.Group).Scan(&groupID)
+			if err != nil {
+				return err
+			}
+		}
+
+		var valueID int
+		err := tx.QueryRow("INSERT INTO product_option_values (group_id, value) VALUES ($1, $2) RETURNING id",
+			groupID, o.Value).Scan(&valueID)
+		if err != nil {
+			return err
+		}
+		valueIDs = append(valueIDs, valueID)
+	}
+
+	sort.Ints(valueIDs)
+	var taken bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM product_variant_options vo
+			JOIN products p ON p.id = vo.variant_id
+			WHERE p.parent_id = $1
+			GROUP BY vo.variant_id
+			HAVING array_agg(vo.value_id ORDER BY vo.value_id) = $2::int[]
+		)`, parentID, valueIDs).Scan(&taken)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return models.ErrVariantExists
+	}
+
+	variant.ParentID = &parentID
+	if err := createProduct(tx, variant, userID); err != nil {
+		return err
+	}
+	for _, valueID := range valueIDs {
+		_, err := tx.Exec("INSERT INTO product_variant_options (variant_id, value_id) VALUES ($1, $2)", variant.ID, valueID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return repo.attachCreated(variant)
+}
+
+// lockedOptionGroups membaca grup opsi induk di dalam tx, dengan key nama
+// grup dalam huruf kecil.
+func lockedOptionGroups(tx *sql.Tx, parentID int) (map[string]models.ProductOptionGroup, error) {
+	rows, err := tx.Query(`
+		SELECT g.id, g.name, v.id, v.value
+		FROM product_option_groups g
+		LEFT JOIN product_option_values v ON v.group_id = g.id
+		WHERE g.product_id = $1
+		ORDER BY g.id, v.id`, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make(map[string]models.ProductOptionGroup)
+	for rows.Next() {
+		var g models.ProductOptionGroup
+		var valueID sql.NullInt64
+		var value sql.NullString
+		if err := rows.Scan(&g.ID, &g.Name, &valueID, &value); err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(g.Name)
+		if current, ok := groups[key]; ok {
+			g = current
+		}
+		if valueID.Valid {
+			g.Values = append(g.Values, models.ProductOptionValue{ID: int(valueID.Int64), Value: value.String})
+		}
+		groups[key] = g
+	}
+	return groups, rows.Err()
+}
+
+// matchValue mencari nilai opsi yang sudah ada dan menyeragamkan ejaannya.
+func matchValue(values []models.ProductOptionValue, o *models.VariantOption) (int, bool) {
+	for _, v := range values {
+		if strings.EqualFold(v.Value, o.Value) {
+			o.Value = v.Value
+			return v.ID, true
+		}
+	}
+	return 0, false
+}
+
+func groupNames(groups map[string]models.ProductOptionGroup) string {
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+	"time"
+)
+
+var errUnknownPromotionTarget = models.NewValidationError("promotion refers to a product or category that does not exist")
+
+type PromotionRepository struct {
+	db *sql.DB
+}
+
+func NewPromotionRepository(db *sql.DB) *PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+const promotionSelect = `
+	SELECT id, name, type, scope, product_id, category_id, value, buy_quantity, get_quantity,
+		get_product_id, min_subtotal, starts_at, ends_at, days_mask,
+		COALESCE(to_char(start_time, 'HH24:MI'), ''), COALESCE(to_char(end_time, 'HH24:MI'), ''), active
+	FROM promotions`
+
+func scanPromotion(row rowScanner) (*models.Promotion, error) {
+	var p models.Promotion
+	var daysMask int
+	err := row.Scan(&p.ID, &p.Name, &p.Type, &p.Scope, &p.ProductID, &p.CategoryID, &p.Value, &p.BuyQuantity,
+		&p.GetQuantity, &p.GetProductID, &p.MinSubtotal, &p.StartsAt, &p.EndsAt, &daysMask,
+		&p.StartTime, &p.EndTime, &p.Active)
+	if err != nil {
+		return nil, err
+	}
+	for day := 0; day < 7; day++ {
+		if daysMask&(1<<day) != 0 {
+			p.DaysOfWeek = append(p.DaysOfWeek, day)
+		}
+	}
+	return &p, nil
+}
+
+func daysMask(days []int) int {
+	mask := 0
+	for _, day := range days {
+		mask |= 1 << day
+	}
+	return mask
+}
+
+func (repo *PromotionRepository) queryPromotions(query string, args ...interface{}) ([]models.Promotion, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := make([]models.Promotion, 0)
+	index := make(map[int]int)
+	for rows.Next() {
+		p, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		index[p.ID] = len(promotions)
+		promotions = append(promotions, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	itemRows, err := repo.db.Query("SELECT promotion_id, product_id, quantity FROM promotion_items ORDER BY promotion_id, product_id")
+	if err != nil {
+		return nil, err
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var promotionID int
+		var item models.PromotionItem
+		if err := itemRows.Scan(&promotionID, &item.ProductID, &item.Quantity); err != nil {
+			return nil, err
+		}
+		if i, ok := index[promotionID]; ok {
+			promotions[i].Items = append(promotions[i].Items, item)
+		}
+	}
+
+	return promotions, itemRows.Err()
+}
+
+func (repo *PromotionRepository) GetAll() ([]models.Promotion, error) {
+	return repo.queryPromotions(promotionSelect + " ORDER BY id")
+}
+
+// GetActive mengembalikan promosi aktif yang masa berlakunya mencakup now.
+// Filter hari dan jam dievaluasi oleh service di zona waktu toko.
+func (repo *PromotionRepository) GetActive(now time.Time) ([]models.Promotion, error) {
+	return repo.queryPromotions(promotionSelect+`
+		WHERE active
+			AND (starts_at IS NULL OR starts_at <= $1)
+			AND (ends_at IS NULL OR ends_at > $1)
+		ORDER BY id`, now)
+}
+
+func (repo *PromotionRepository) GetByID(id int) (*models.Promotion, error) {
+	promotions, err := repo.queryPromotions(promotionSelect+" WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(promotions) == 0 {
+		return nil, models.ErrPromotionNotFound
+	}
+	return &promotions[0], nil
+}
+
+func (repo *PromotionRepository) Create(p *models.Promotion) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO promotions (name, type, scope, product_id, category_id, value, buy_quantity, get_quantity,
+			get_product_id, min_subtotal, starts_at, ends_at, days_mask, start_time, end_time, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NULLIF($14, '')::time, NULLIF($15, '')::time, $16)
+		RETURNING id`
+	err = tx.QueryRow(query, p.Name, p.Type, p.Scope, p.ProductID, p.CategoryID, p.Value, p.BuyQuantity, p.GetQuantity,
+		p.GetProductID, p.MinSubtotal, p.StartsAt, p.EndsAt, daysMask(p.DaysOfWeek), p.StartTime, p.EndTime, p.Active).Scan(&p.ID)
+	if isForeignKeyViolation(err) {
+		return errUnknownPromotionTarget
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := insertPromotionItems(tx, p); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (repo *PromotionRepository) Update(p *models.Promotion) error {
+	tx, err := repo.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE promotions SET name = $1, type = $2, scope = $3, product_id = $4, category_id = $5, value = $6,
+			buy_quantity = $7, get_quantity = $8, get_product_id = $9, min_subtotal = $10, starts_at = $11,
+			ends_at = $12, days_mask = $13, start_time = NULLIF($14, '')::time, end_time = NULLIF($15, '')::time,
+			active = $16
+		WHERE id = $17`
+	result, err := tx.Exec(query, p.Name, p.Type, p.Scope, p.ProductID, p.CategoryID, p.Value, p.BuyQuantity, p.GetQuantity,
+		p.GetProductID, p.MinSubtotal, p.StartsAt, p.EndsAt, daysMask(p.DaysOfWeek), p.StartTime, p.EndTime, p.Active, p.ID)
+	if isForeignKeyViolation(err) {
+		return errUnknownPromotionTarget
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrPromotionNotFound
+	}
+
+	if _, err := tx.Exec("DELETE FROM promotion_items WHERE promotion_id = $1", p.ID); err != nil {
+		return err
+	}
+	if err := insertPromotionItems(tx, p); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (repo *PromotionRepository) Delete(id int) error {
+	result, err := repo.db.Exec("DELETE FROM promotions WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrPromotionNotFound
+	}
+	return nil
+}
+
+func insertPromotionItems(tx *sql.Tx, p *models.Promotion) error {
+	for _, item := range p.Items {
+		_, err := tx.Exec("INSERT INTO promotion_items (promotion_id, product_id, quantity) VALUES ($1, $2, $3)",
+			p.ID, item.ProductID, item.Quantity)
+		if isForeignKeyViolation(err) {
+			return errUnknownPromotionTarget
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
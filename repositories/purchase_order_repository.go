@@ -0,0 +1,388 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"go-kasir-api/models"
+	"math"
+	"sort"
+	"strings"
+)
+
+type PurchaseOrderRepository struct {
+	db *sql.DB
+}
+
+func NewPurchaseOrderRepository(db *sql.DB) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{db: db}
+}
+
+const purchaseOrderSelect = `
+	SELECT po.id, po.supplier_id, s.name, po.status, po.order_date, to_char(po.expected_date, 'YYYY-MM-DD'),
+		po.note, po.total_amount, po.created_by
+	FROM purchase_orders po
+	JOIN suppliers s ON s.id = po.supplier_id`
+
+func scanPurchaseOrder(row rowScanner) (*models.PurchaseOrder, error) {
+	var po models.PurchaseOrder
+	err := row.Scan(&po.ID, &po.SupplierID, &po.SupplierName, &po.Status, &po.OrderDate, &po.ExpectedDate,
+		&po.Note, &po.Total, &po.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	return &po, nil
+}
+
+// GetAll mengembalikan header PO, terbaru dulu. status dan supplierID opsional.
+func (r *PurchaseOrderRepository) GetAll(status string, supplierID *int) ([]models.PurchaseOrder, error) {
+	conditions := []string{}
+	args := []interface{}{}
+	if status != "" {
+		args = append(args, status)
+		conditions = append(conditions, fmt.Sprintf("po.status = $%d", len(args)))
+	}
+	if supplierID != nil {
+		args = append(args, *supplierID)
+		conditions = append(conditions, fmt.Sprintf("po.supplier_id = $%d", len(args)))
+	}
+	query := purchaseOrderSelect
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := r.db.Query(query+" ORDER BY po.id DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]models.PurchaseOrder, 0)
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, *po)
+	}
+
+	return orders, rows.Err()
+}
+
+// GetByID mengembalikan PO lengkap dengan baris dan penerimaan barangnya.
+func (r *PurchaseOrderRepository) GetByID(id int) (*models.PurchaseOrder, error) {
+	po, err := scanPurchaseOrder(r.db.QueryRow(purchaseOrderSelect+" WHERE po.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if po.Lines, err = r.getLines(id); err != nil {
+		return nil, err
+	}
+	if po.Receipts, err = r.getReceipts(id); err != nil {
+		return nil, err
+	}
+	return po, nil
+}
+
+func (r *PurchaseOrderRepository) getLines(id int) ([]models.PurchaseOrderLine, error) {
+	query := `
+		SELECT l.id, l.product_id, p.name, l.quantity, l.unit, l.unit_factor, l.unit_cost, l.received_quantity
+		FROM purchase_order_lines l
+		JOIN products p ON p.id = l.product_id
+		WHERE l.purchase_order_id = $1
+		ORDER BY l.id`
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := make([]models.PurchaseOrderLine, 0)
+	for rows.Next() {
+		var l models.PurchaseOrderLine
+		err := rows.Scan(&l.ID, &l.ProductID, &l.ProductName, &l.Quantity, &l.Unit, &l.UnitFactor, &l.UnitCost, &l.ReceivedQuantity)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
+
+func (r *PurchaseOrderRepository) getReceipts(id int) ([]models.GoodsReceipt, error) {
+	query := `
+		SELECT g.id, g.received_at, g.received_by, g.note,
+			gl.id, gl.purchase_order_line_id, gl.product_id, gl.quantity, gl.unit_cost
+		FROM goods_receipts g
+		JOIN goods_receipt_lines gl ON gl.goods_receipt_id = g.id
+		WHERE g.purchase_order_id = $1
+		ORDER BY g.id, gl.id`
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	receipts := make([]models.GoodsReceipt, 0)
+	for rows.Next() {
+		var g models.GoodsReceipt
+		var l models.GoodsReceiptLine
+		var unitCost int
+		err := rows.Scan(&g.ID, &g.ReceivedAt, &g.ReceivedBy, &g.Note,
+			&l.ID, &l.PurchaseOrderLineID, &l.ProductID, &l.Quantity, &unitCost)
+		if err != nil {
+			return nil, err
+		}
+		l.UnitCost = &unitCost
+
+		if n := len(receipts); n == 0 || receipts[n-1].ID != g.ID {
+			g.PurchaseOrderID = id
+			receipts = append(receipts, g)
+		}
+		last := &receipts[len(receipts)-1]
+		last.Lines = append(last.Lines, l)
+	}
+
+	return receipts, rows.Err()
+}
+
+// Create menyimpan PO beserta barisnya. Produk yang tidak ada ditolak
+// sebagai ValidationError.
+func (r *PurchaseOrderRepository) Create(po *models.PurchaseOrder) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO purchase_orders (supplier_id, expected_date, note, total_amount, created_by)
+		VALUES ($1, $2::date, $3, $4, $5)
+		RETURNING id, status, order_date`
+	err = tx.QueryRow(query, po.SupplierID, po.ExpectedDate, po.Note, po.Total, po.CreatedBy).
+		Scan(&po.ID, &po.Status, &po.OrderDate)
+	if isForeignKeyViolation(err) {
+		return models.NewValidationError("supplier %d not found", po.SupplierID)
+	}
+	if err != nil {
+		return err
+	}
+
+	lineQuery := `
+		INSERT INTO purchase_order_lines (purchase_order_id, product_id, quantity, unit, unit_factor, unit_cost)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+	for i := range po.Lines {
+		l := &po.Lines[i]
+		err := tx.QueryRow(lineQuery, po.ID, l.ProductID, l.Quantity, l.Unit, l.UnitFactor, l.UnitCost).Scan(&l.ID)
+		if isForeignKeyViolation(err) {
+			return models.NewValidationError("product %d not found", l.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// lockReceivable mengunci header PO dan memastikan masih ada barang yang
+// boleh diterima.
+func lockReceivable(tx *sql.Tx, id int) error {
+	var status string
+	err := tx.QueryRow("SELECT status FROM purchase_orders WHERE id = $1 FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return models.ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != models.PurchaseOrderOpen && status != models.PurchaseOrderPartial {
+		return models.ErrPurchaseOrderClosed
+	}
+	return nil
+}
+
+// Receive mencatat penerimaan barang untuk PO g.PurchaseOrderID. Setiap baris
+// menambah stok lewat ledger (tipe purchase), dikonversi ke satuan dasar, dan
+// memperbarui harga pokok produk dengan rata-rata bergerak. Status PO menjadi partial, atau received
+// bila semua baris sudah diterima penuh.
+func (r *PurchaseOrderRepository) Receive(g *models.GoodsReceipt) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockReceivable(tx, g.PurchaseOrderID); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, product_id, quantity, unit_factor, unit_cost, received_quantity
+		FROM purchase_order_lines
+		WHERE purchase_order_id = $1`, g.PurchaseOrderID)
+	if err != nil {
+		return err
+	}
+	lines := make(map[int]*models.PurchaseOrderLine)
+	for rows.Next() {
+		var l models.PurchaseOrderLine
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.Quantity, &l.UnitFactor, &l.UnitCost, &l.ReceivedQuantity); err != nil {
+			rows.Close()
+			return err
+		}
+		lines[l.ID] = &l
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	factors := make(map[int]models.Quantity, len(g.Lines))
+	for i := range g.Lines {
+		item := &g.Lines[i]
+		line, ok := lines[item.PurchaseOrderLineID]
+		if !ok {
+			return models.NewValidationError("line %d is not part of purchase order %d", item.PurchaseOrderLineID, g.PurchaseOrderID)
+		}
+		if remaining := line.Quantity - line.ReceivedQuantity; item.Quantity > remaining {
+			return models.NewValidationError("line %d has only %s left to receive", line.ID, remaining)
+		}
+		if _, exact := item.Quantity.Mul(line.UnitFactor); !exact {
+			return models.NewValidationError("line %d: %s does not convert to a whole number of base units", line.ID, item.Quantity)
+		}
+		factors[item.PurchaseOrderLineID] = line.UnitFactor
+		item.ProductID = line.ProductID
+		if item.UnitCost == nil {
+			unitCost := line.UnitCost
+			item.UnitCost = &unitCost
+		}
+		line.ReceivedQuantity += item.Quantity
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO goods_receipts (purchase_order_id, received_by, note)
+		VALUES ($1, $2, $3)
+		RETURNING id, received_at`, g.PurchaseOrderID, g.ReceivedBy, g.Note).Scan(&g.ID, &g.ReceivedAt)
+	if err != nil {
+		return err
+	}
+
+	// Lock product rows in ID order, the same order as checkout.
+	items := make([]*models.GoodsReceiptLine, len(g.Lines))
+	for i := range g.Lines {
+		items[i] = &g.Lines[i]
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].ProductID < items[j].ProductID })
+
+	for _, item := range items {
+		if err := receiveLine(tx, g, item, factors[item.PurchaseOrderLineID]); err != nil {
+			return err
+		}
+	}
+
+	status := models.PurchaseOrderReceived
+	for _, l := range lines {
+		if l.ReceivedQuantity < l.Quantity {
+			status = models.PurchaseOrderPartial
+			break
+		}
+	}
+	if _, err := tx.Exec("UPDATE purchase_orders SET status = $1 WHERE id = $2", status, g.PurchaseOrderID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// receiveLine menambah stok dan menghitung ulang harga pokok satu produk.
+// factor adalah isi satuan dasar per satuan baris PO.
+func receiveLine(tx *sql.Tx, g *models.GoodsReceipt, item *models.GoodsReceiptLine, factor models.Quantity) error {
+	var stock models.Quantity
+	var cost int
+	err := tx.QueryRow("SELECT stock, cost FROM products WHERE id = $1 FOR UPDATE", item.ProductID).Scan(&stock, &cost)
+	if err == sql.ErrNoRows {
+		return models.ErrProductNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	base, _ := item.Quantity.Mul(factor)
+	err = moveStock(tx, &models.StockMovement{
+		ProductID:     item.ProductID,
+		Type:          models.StockPurchase,
+		Quantity:      base,
+		ReferenceType: models.ReferenceGoodsReceipt,
+		ReferenceID:   &g.ID,
+		Note:          fmt.Sprintf("purchase order #%d", g.PurchaseOrderID),
+		UserID:        g.ReceivedBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	value, ok := item.Quantity.Amount(*item.UnitCost)
+	if !ok {
+		return models.NewValidationError("line %d: value of %s at %d is too large", item.PurchaseOrderLineID, item.Quantity, *item.UnitCost)
+	}
+	newCost, ok := movingAverageCost(stock, cost, base, value)
+	if !ok {
+		return models.NewValidationError("stock value of product %d is too large", item.ProductID)
+	}
+	if _, err := tx.Exec("UPDATE products SET cost = $1 WHERE id = $2", newCost, item.ProductID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE purchase_order_lines SET received_quantity = received_quantity + $1 WHERE id = $2",
+		item.Quantity, item.PurchaseOrderLineID)
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRow(`
+		INSERT INTO goods_receipt_lines (goods_receipt_id, purchase_order_line_id, product_id, quantity, unit_cost)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`, g.ID, item.PurchaseOrderLineID, item.ProductID, item.Quantity, *item.UnitCost).Scan(&item.ID)
+}
+
+// movingAverageCost menggabungkan harga pokok per satuan dasar stok yang ada
+// dengan barang yang baru masuk senilai value. Stok minus dianggap nol supaya
+// harga pokok tidak terbalik. ok false bila nilai stok tidak muat di int64.
+func movingAverageCost(stock models.Quantity, cost int, quantity models.Quantity, value int) (int, bool) {
+	if stock < 0 {
+		stock = 0
+	}
+	units := stock + quantity
+	if units <= 0 {
+		return cost, true
+	}
+	stockValue, ok := stock.Amount(cost)
+	if !ok || value > math.MaxInt-stockValue {
+		return 0, false
+	}
+	return units.PerUnit(stockValue + value)
+}
+
+// Cancel menutup PO. Barang yang sudah diterima tetap di stok; sisa yang
+// belum diterima tidak lagi ditunggu.
+func (r *PurchaseOrderRepository) Cancel(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockReceivable(tx, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("UPDATE purchase_orders SET status = $1 WHERE id = $2", models.PurchaseOrderCancelled, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
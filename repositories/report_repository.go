@@ -0,0 +1,559 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"go-kasir-api/models"
+	"time"
+)
+
+type ReportRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// Void dan retur tersimpan sebagai transaksi bernilai negatif, jadi SUM sudah
+// bersih. Jumlah transaksi = penjualan dikurangi penjualan yang di-void;
+// retur sebagian tidak mengurangi jumlah transaksi.
+const transactionCount = "COUNT(id) FILTER (WHERE type = 'sale') - COUNT(id) FILTER (WHERE type = 'void')"
+
+// soldQuantity adalah qty baris dalam satuan dasar produk, untuk dijumlahkan
+// lintas satuan jual.
+const soldQuantity = "td.quantity * td.unit_factor"
+
+// lineCost menambahkan kolom line_cost.cost (harga pokok semua baris) ke setiap
+// baris transactions. unit_cost per satuan baris, dan qty desimal dibulatkan
+// per baris ke rupiah.
+const lineCost = `
+	LEFT JOIN LATERAL (
+		SELECT SUM(ROUND(td.quantity * td.unit_cost))::bigint AS cost
+		FROM transaction_details td
+		WHERE td.transaction_id = transactions.id
+	) line_cost ON TRUE`
+
+// costColumns adalah total harga pokok dan laba kotor. Laba kotor dihitung dari
+// penjualan tanpa pajak, jadi PPN tidak terbaca sebagai laba.
+const costColumns = `COALESCE(SUM(line_cost.cost), 0)::bigint,
+	COALESCE(SUM(total_amount - tax_amount), 0) - COALESCE(SUM(line_cost.cost), 0)::bigint`
+
+// GetSummary menghitung total pendapatan, jumlah transaksi, harga pokok dan
+// laba kotor pada [start, end).
+func (r *ReportRepository) GetSummary(start, end time.Time) (*models.DailyReport, error) {
+	query := `
+		SELECT COALESCE(SUM(total_amount), 0), ` + transactionCount + `, ` + costColumns + `
+		FROM transactions` + lineCost + `
+		WHERE date >= $1 AND date < $2`
+
+	report := &models.DailyReport{PeriodStart: start, PeriodEnd: end}
+	err := r.db.QueryRow(query, start, end).
+		Scan(&report.TotalRevenue, &report.TotalTransaksi, &report.TotalCost, &report.GrossProfit)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// soldProductJoin menggabungkan baris transaksi dengan produknya (p) dan
+// produk induk varian (pp); dipakai bersama soldProduct.
+const soldProductJoin = `
+	LEFT JOIN products p ON td.product_id = p.id
+	LEFT JOIN products pp ON td.parent_product_id = pp.id`
+
+// soldProduct mengembalikan ekspresi key dan nama untuk laporan per produk.
+// Dengan rollup, penjualan varian dijumlahkan ke produk induknya.
+func soldProduct(rollup bool) (key, name string) {
+	if rollup {
+		return "COALESCE(td.parent_product_id, td.product_id)", "COALESCE(MAX(pp.name), MAX(p.name), MAX(td.product_name))"
+	}
+	return "td.product_id", "COALESCE(MAX(p.name), MAX(td.product_name))"
+}
+
+// GetTopProducts mengembalikan produk terlaris berdasarkan qty bersih (setelah
+// void dan retur, dalam satuan dasar) pada [start, end).
+func (r *ReportRepository) GetTopProducts(start, end time.Time, limit int, rollup bool) ([]models.BestSellingProduct, error) {
+	key, name := soldProduct(rollup)
+	query := `
+		SELECT
+			` + key + ` AS product_key,
+			` + name + `,
+			SUM(` + soldQuantity + `) AS total_qty,
+			SUM(td.subtotal)
+		FROM transaction_details td
+		JOIN transactions t ON td.transaction_id = t.id` + soldProductJoin + `
+		WHERE t.date >= $1 AND t.date < $2
+		GROUP BY product_key
+		HAVING SUM(` + soldQuantity + `) > 0
+		ORDER BY total_qty DESC, product_key
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]models.BestSellingProduct, 0)
+	for rows.Next() {
+		var p models.BestSellingProduct
+		if err := rows.Scan(&p.ProductID, &p.Name, &p.QtyTerjual, &p.Revenue); err != nil {
+			return nil, err
+		}
+		products = append(products, p)
+	}
+
+	return products, rows.Err()
+}
+
+// GetPaymentBreakdown menjumlahkan pembayaran per metode pada [start, end).
+// Refund dari void/retur bernilai negatif, jadi angkanya sudah bersih.
+func (r *ReportRepository) GetPaymentBreakdown(start, end time.Time) ([]models.PaymentSummary, error) {
+	query := `
+		SELECT p.method, SUM(p.amount), COUNT(DISTINCT p.transaction_id)
+		FROM transaction_payments p
+		JOIN transactions t ON p.transaction_id = t.id
+		WHERE t.date >= $1 AND t.date < $2
+		GROUP BY p.method
+		ORDER BY p.method`
+
+	rows, err := r.db.Query(query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := make([]models.PaymentSummary, 0)
+	for rows.Next() {
+		var p models.PaymentSummary
+		if err := rows.Scan(&p.Method, &p.Amount, &p.Count); err != nil {
+			return nil, err
+		}
+		payments = append(payments, p)
+	}
+
+	return payments, rows.Err()
+}
+
+// GetModifierUsage menjumlahkan unit bersih (setelah void dan retur, dalam
+// satuan baris) dan pendapatan harga modifier per modifier pada [start, end). Modifier yang
+// sudah dihapus tetap muncul dengan nama saat terjual dan modifier_id null.
+func (r *ReportRepository) GetModifierUsage(start, end time.Time) ([]models.ModifierUsage, error) {
+	query := `
+		SELECT m.modifier_id, m.group_name, m.name, SUM(td.quantity), SUM(ROUND(td.quantity * m.price))::bigint
+		FROM transaction_detail_modifiers m
+		JOIN transaction_details td ON m.detail_id = td.id
+		JOIN transactions t ON td.transaction_id = t.id
+		WHERE t.date >= $1 AND t.date < $2
+		GROUP BY m.modifier_id, m.group_name, m.name
+		HAVING SUM(td.quantity) > 0
+		ORDER BY SUM(td.quantity) DESC, m.group_name, m.name`
+
+	rows, err := r.db.Query(query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usage := make([]models.ModifierUsage, 0)
+	for rows.Next() {
+		var u models.ModifierUsage
+		if err := rows.Scan(&u.ModifierID, &u.GroupName, &u.Name, &u.Quantity, &u.Revenue); err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+
+	return usage, rows.Err()
+}
+
+// GetPromotionUsage menjumlahkan diskon per promosi pada penjualan [start, end)
+// yang tidak di-void. Promosi yang sudah dihapus tetap muncul dengan nama saat
+// dipakai dan promotion_id null.
+func (r *ReportRepository) GetPromotionUsage(start, end time.Time) ([]models.PromotionUsage, error) {
+	query := `
+		SELECT tp.promotion_id, tp.name, COUNT(DISTINCT tp.transaction_id), SUM(tp.discount)
+		FROM transaction_promotions tp
+		JOIN transactions t ON tp.transaction_id = t.id
+		WHERE t.date >= $1 AND t.date < $2
+			AND NOT EXISTS (SELECT 1 FROM transactions v WHERE v.reference_id = t.id AND v.type = 'void')
+		GROUP BY tp.promotion_id, tp.name
+		ORDER BY SUM(tp.discount) DESC, tp.name`
+
+	rows, err := r.db.Query(query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usage := make([]models.PromotionUsage, 0)
+	for rows.Next() {
+		var u models.PromotionUsage
+		if err := rows.Scan(&u.PromotionID, &u.Name, &u.TransactionCount, &u.TotalDiscount); err != nil {
+			return nil, err
+		}
+		usage = append(usage, u)
+	}
+
+	return usage, rows.Err()
+}
+
+// GetTaxSummary menjumlahkan DPP dan pajak per tarif pada [start, end). Baris
+// void/retur bernilai negatif sehingga angkanya sudah bersih. Baris tanpa
+// pajak dikembalikan dengan Name kosong.
+func (r *ReportRepository) GetTaxSummary(start, end time.Time) ([]models.TaxSummary, error) {
+	query := `
+		SELECT td.tax_rate_id, COALESCE(td.tax_name, ''), td.tax_rate::float8,
+			SUM(CASE WHEN td.tax_inclusive THEN td.subtotal - td.tax ELSE td.subtotal END),
+			SUM(td.tax)
+		FROM transaction_details td
+		JOIN transactions t ON td.transaction_id = t.id
+		WHERE t.date >= $1 AND t.date < $2
+		GROUP BY td.tax_rate_id, td.tax_name, td.tax_rate
+		ORDER BY td.tax_rate DESC, 2`
+
+	rows, err := r.db.Query(query, start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make([]models.TaxSummary, 0)
+	for rows.Next() {
+		var t models.TaxSummary
+		if err := rows.Scan(&t.TaxRateID, &t.Name, &t.Rate, &t.TaxableAmount, &t.Tax); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, t)
+	}
+
+	return summaries, rows.Err()
+}
+
+// GetPeriodTotals mengelompokkan penjualan [start, end) per date_trunc(groupBy)
+// atas tanggal bisnis: waktu lokal di zona timezone dikurangi cutoff. Hanya
+// bucket yang ada transaksinya yang dikembalikan, dengan PeriodStart berisi
+// tanggal awal bucket dan ProdukTerlaris berisi produk terlaris di bucket itu.
+func (r *ReportRepository) GetPeriodTotals(start, end time.Time, groupBy string, timezone string, cutoff time.Duration, rollup bool) ([]models.DailyReport, error) {
+	query := `
+		SELECT ` + businessBucket("date") + ` AS bucket, COALESCE(SUM(total_amount), 0), ` + transactionCount + `,
+			` + costColumns + `
+		FROM transactions` + lineCost + `
+		WHERE date >= $1 AND date < $2
+		GROUP BY bucket
+		ORDER BY bucket`
+
+	cutoffSeconds := int(cutoff / time.Second)
+	rows, err := r.db.Query(query, start, end, groupBy, timezone, cutoffSeconds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := make([]models.DailyReport, 0)
+	index := make(map[int64]int)
+	for rows.Next() {
+		var report models.DailyReport
+		err := rows.Scan(&report.PeriodStart, &report.TotalRevenue, &report.TotalTransaksi, &report.TotalCost, &report.GrossProfit)
+		if err != nil {
+			return nil, err
+		}
+		index[report.PeriodStart.Unix()] = len(reports)
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	key, name := soldProduct(rollup)
+	bestQuery := `
+		SELECT DISTINCT ON (bucket) bucket, product_key, name, total_qty, revenue
+		FROM (
+			SELECT
+				` + businessBucket("t.date") + ` AS bucket,
+				` + key + ` AS product_key,
+				` + name + ` AS name,
+				SUM(` + soldQuantity + `) AS total_qty,
+				SUM(td.subtotal) AS revenue
+			FROM transaction_details td
+			JOIN transactions t ON td.transaction_id = t.id` + soldProductJoin + `
+			WHERE t.date >= $1 AND t.date < $2
+			GROUP BY bucket, product_key
+			HAVING SUM(` + soldQuantity + `) > 0
+		) per_product
+		ORDER BY bucket, total_qty DESC, product_key`
+
+	bestRows, err := r.db.Query(bestQuery, start, end, groupBy, timezone, cutoffSeconds)
+	if err != nil {
+		return nil, err
+	}
+	defer bestRows.Close()
+
+	for bestRows.Next() {
+		var bucket time.Time
+		var best models.BestSellingProduct
+		if err := bestRows.Scan(&bucket, &best.ProductID, &best.Name, &best.QtyTerjual, &best.Revenue); err != nil {
+			return nil, err
+		}
+		if i, ok := index[bucket.Unix()]; ok {
+			reports[i].ProdukTerlaris = best
+		}
+	}
+
+	return reports, bestRows.Err()
+}
+
+// businessBucket mengubah kolom TIMESTAMPTZ menjadi awal bucket tanggal bisnis.
+// Parameter: $3 group_by, $4 zona waktu toko, $5 cutoff dalam detik.
+func businessBucket(column string) string {
+	return "date_trunc($3, (" + column + " AT TIME ZONE $4::text) - $5::int * interval '1 second')"
+}
+
+// GetOutstandingPurchases mengembalikan baris PO open/partial yang masih punya
+// sisa qty, dalam satuan baris PO, diurutkan dari tanggal kedatangan terdekat.
+// supplierID opsional.
+func (r *ReportRepository) GetOutstandingPurchases(supplierID *int) ([]models.OutstandingPurchaseLine, error) {
+	query := `
+		SELECT po.id, po.supplier_id, s.name, po.order_date, to_char(po.expected_date, 'YYYY-MM-DD'),
+			l.product_id, p.name, l.unit, l.quantity, l.received_quantity, l.unit_cost
+		FROM purchase_order_lines l
+		JOIN purchase_orders po ON po.id = l.purchase_order_id
+		JOIN suppliers s ON s.id = po.supplier_id
+		JOIN products p ON p.id = l.product_id
+		WHERE po.status IN ('open', 'partial')
+			AND l.received_quantity < l.quantity
+			AND ($1::int IS NULL OR po.supplier_id = $1)
+		ORDER BY po.expected_date NULLS LAST, po.id, l.id`
+
+	rows, err := r.db.Query(query, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := make([]models.OutstandingPurchaseLine, 0)
+	for rows.Next() {
+		var l models.OutstandingPurchaseLine
+		var unitCost int
+		err := rows.Scan(&l.PurchaseOrderID, &l.SupplierID, &l.SupplierName, &l.OrderDate, &l.ExpectedDate,
+			&l.ProductID, &l.ProductName, &l.Unit, &l.Ordered, &l.Received, &unitCost)
+		if err != nil {
+			return nil, err
+		}
+		l.Outstanding = l.Ordered - l.Received
+		// Sisa tidak melebihi qty pesanan, yang nilainya sudah dicek saat PO
+		// dibuat.
+		l.OutstandingValue, _ = l.Outstanding.Amount(unitCost)
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
+
+// GetSupplierPurchases menjumlahkan barang yang diterima per supplier dan
+// produk pada [start, end), berdasarkan tanggal penerimaan. Qty dan harga
+// rata-rata dalam satuan dasar produk. supplierID opsional.
+func (r *ReportRepository) GetSupplierPurchases(start, end time.Time, supplierID *int) ([]models.SupplierPurchaseSummary, error) {
+	query := `
+		SELECT po.supplier_id, s.name, gl.product_id, p.name,
+			SUM(gl.quantity * l.unit_factor), SUM(ROUND(gl.quantity * gl.unit_cost))::bigint,
+			(
+				SELECT COUNT(*) FROM goods_receipts g2
+				JOIN purchase_orders po2 ON po2.id = g2.purchase_order_id
+				WHERE po2.supplier_id = po.supplier_id AND g2.received_at >= $1 AND g2.received_at < $2
+			)
+		FROM goods_receipt_lines gl
+		JOIN goods_receipts g ON g.id = gl.goods_receipt_id
+		JOIN purchase_order_lines l ON l.id = gl.purchase_order_line_id
+		JOIN purchase_orders po ON po.id = g.purchase_order_id
+		JOIN suppliers s ON s.id = po.supplier_id
+		JOIN products p ON p.id = gl.product_id
+		WHERE g.received_at >= $1 AND g.received_at < $2
+			AND ($3::int IS NULL OR po.supplier_id = $3)
+		GROUP BY po.supplier_id, s.name, gl.product_id, p.name
+		ORDER BY s.name, po.supplier_id, p.name`
+
+	rows, err := r.db.Query(query, start, end, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := make([]models.SupplierPurchaseSummary, 0)
+	for rows.Next() {
+		var s models.SupplierPurchaseSummary
+		var p models.SupplierProductPurchase
+		err := rows.Scan(&s.SupplierID, &s.SupplierName, &p.ProductID, &p.ProductName, &p.Quantity, &p.Value, &s.ReceiptCount)
+		if err != nil {
+			return nil, err
+		}
+		if p.Quantity > 0 {
+			var ok bool
+			if p.AverageCost, ok = p.Quantity.PerUnit(p.Value); !ok {
+				return nil, fmt.Errorf("average cost of product %d is too large", p.ProductID)
+			}
+		}
+
+		if n := len(suppliers); n == 0 || suppliers[n-1].SupplierID != s.SupplierID {
+			s.Products = make([]models.SupplierProductPurchase, 0)
+			suppliers = append(suppliers, s)
+		}
+		last := &suppliers[len(suppliers)-1]
+		last.Products = append(last.Products, p)
+		last.TotalValue += p.Value
+	}
+
+	return suppliers, rows.Err()
+}
+
+// GetMargin menjumlahkan penjualan bersih (tanpa pajak, setelah diskon) dan
+// harga pokok per produk, produk induk, kategori atau hari bisnis pada
+// [start, end). Void
+// dan retur bernilai negatif dan ikut mengurangi. Kategori diambil dari
+// kategori produk saat ini.
+func (r *ReportRepository) GetMargin(start, end time.Time, groupBy string, timezone string, cutoff time.Duration) ([]models.MarginLine, error) {
+	const netSales = "SUM(CASE WHEN td.tax_inclusive THEN td.subtotal - td.tax ELSE td.subtotal END)"
+	const cost = "SUM(ROUND(td.quantity * td.unit_cost))::bigint"
+
+	args := []interface{}{start, end}
+	key, name := soldProduct(groupBy == models.GroupByParent)
+	order := netSales + " - " + cost + " DESC, key"
+	switch groupBy {
+	case models.GroupByCategory:
+		key, name = "p.category_id", "COALESCE(MAX(c.name), '')"
+	case models.GroupByDay:
+		key, name, order = businessBucket("t.date"), "''", "key"
+		args = append(args, models.GroupByDay, timezone, int(cutoff/time.Second))
+	}
+
+	query := `
+		SELECT ` + key + ` AS key, ` + name + `, SUM(` + soldQuantity + `), ` + netSales + `, ` + cost + `
+		FROM transaction_details td
+		JOIN transactions t ON td.transaction_id = t.id` + soldProductJoin + `
+		LEFT JOIN categories c ON p.category_id = c.id
+		WHERE t.date >= $1 AND t.date < $2
+		GROUP BY key
+		ORDER BY ` + order
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := make([]models.MarginLine, 0)
+	for rows.Next() {
+		var l models.MarginLine
+		var keyDest interface{} = &l.ID
+		if groupBy == models.GroupByDay {
+			keyDest = &l.Date
+		}
+		if err := rows.Scan(keyDest, &l.Name, &l.Quantity, &l.NetSales, &l.Cost); err != nil {
+			return nil, err
+		}
+		l.GrossProfit = l.NetSales - l.Cost
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
+
+// GetCustomerTotals menjumlahkan penjualan bersih pada [start, end): semua
+// transaksi, transaksi pelanggan terdaftar, jumlah penjualan member dan
+// jumlah pelanggan yang berbelanja.
+func (r *ReportRepository) GetCustomerTotals(start, end time.Time) (*models.CustomerReport, error) {
+	report := &models.CustomerReport{}
+	err := r.db.QueryRow(`
+		SELECT COALESCE(SUM(total_amount), 0),
+			COALESCE(SUM(total_amount) FILTER (WHERE customer_id IS NOT NULL), 0),
+			COUNT(*) FILTER (WHERE customer_id IS NOT NULL AND type = 'sale'),
+			COUNT(DISTINCT customer_id)
+		FROM transactions
+		WHERE date >= $1 AND date < $2`, start, end).
+		Scan(&report.TotalSales, &report.MemberSales, &report.MemberTransactions, &report.ActiveCustomers)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetCustomerValues mengembalikan pelanggan dengan belanja bersih terbesar pada
+// [start, end), beserta poin periode itu dan nilai seumur hidupnya.
+func (r *ReportRepository) GetCustomerValues(start, end time.Time, limit int) ([]models.CustomerValue, error) {
+	query := `
+		SELECT c.id, c.name, COALESCE(lt.name, ''),
+			COUNT(*) FILTER (WHERE t.type = 'sale'), SUM(t.total_amount), SUM(t.points_earned), SUM(t.points_redeemed),
+			life.spend, life.first_purchase, life.last_purchase
+		FROM transactions t
+		JOIN customers c ON c.id = t.customer_id
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(SUM(l.total_amount), 0) AS spend,
+				MIN(l.date) FILTER (WHERE l.type = 'sale') AS first_purchase,
+				MAX(l.date) FILTER (WHERE l.type = 'sale') AS last_purchase
+			FROM transactions l
+			WHERE l.customer_id = c.id
+		) life
+		LEFT JOIN LATERAL (
+			SELECT name FROM loyalty_tiers
+			WHERE min_spend <= life.spend
+			ORDER BY min_spend DESC
+			LIMIT 1
+		) lt ON TRUE
+		WHERE t.date >= $1 AND t.date < $2
+		GROUP BY c.id, c.name, lt.name, life.spend, life.first_purchase, life.last_purchase
+		ORDER BY SUM(t.total_amount) DESC, c.id
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	customers := make([]models.CustomerValue, 0)
+	for rows.Next() {
+		var v models.CustomerValue
+		err := rows.Scan(&v.CustomerID, &v.Name, &v.Tier, &v.Transactions, &v.Spend, &v.PointsEarned, &v.PointsRedeemed,
+			&v.LifetimeValue, &v.FirstPurchaseAt, &v.LastPurchaseAt)
+		if err != nil {
+			return nil, err
+		}
+		if v.Transactions > 0 {
+			v.AverageBasket = v.Spend / v.Transactions
+		}
+		customers = append(customers, v)
+	}
+	return customers, rows.Err()
+}
+
+// GetReceivables mengembalikan pelanggan yang punya saldo kasbon (utang atau
+// titipan), utang terbesar dulu, beserta pembayaran terakhirnya.
+func (r *ReportRepository) GetReceivables() ([]models.CustomerReceivable, error) {
+	rows, err := r.db.Query(`
+		SELECT c.id, c.name, c.phone, c.credit_limit, c.credit_balance,
+			(SELECT MAX(created_at) FROM credit_entries e WHERE e.customer_id = c.id AND e.type = 'payment')
+		FROM customers c
+		WHERE c.credit_balance <> 0
+		ORDER BY c.credit_balance DESC, c.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	customers := make([]models.CustomerReceivable, 0)
+	for rows.Next() {
+		var c models.CustomerReceivable
+		if err := rows.Scan(&c.CustomerID, &c.Name, &c.Phone, &c.CreditLimit, &c.Balance, &c.LastPaymentAt); err != nil {
+			return nil, err
+		}
+		customers = append(customers, c)
+	}
+	return customers, rows.Err()
+}
+
+// GetOpenCharges mengembalikan charge kasbon yang belum terbayar milik semua
+// pelanggan.
+func (r *ReportRepository) GetOpenCharges() ([]models.OpenCharge, error) {
+	return openCharges(r.db, nil)
+}
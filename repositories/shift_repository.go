@@ -0,0 +1,357 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"go-kasir-api/models"
+	"strings"
+)
+
+type ShiftRepository struct {
+	db *sql.DB
+}
+
+func NewShiftRepository(db *sql.DB) *ShiftRepository {
+	return &ShiftRepository{db: db}
+}
+
+// shiftSelect memilih header shift beserta nama kasirnya; dipakai bersama
+// scanShift.
+const shiftSelect = `
+	SELECT s.id, s.cashier_id, u.name, s.status, s.opening_float, s.note, s.opened_at,
+		s.closed_by, s.closed_at, s.close_note, s.expected_cash, s.counted_cash, s.variance
+	FROM shifts s
+	JOIN users u ON u.id = s.cashier_id`
+
+func scanShift(row rowScanner) (*models.Shift, error) {
+	var s models.Shift
+	err := row.Scan(&s.ID, &s.CashierID, &s.CashierName, &s.Status, &s.OpeningFloat, &s.Note, &s.OpenedAt,
+		&s.ClosedBy, &s.ClosedAt, &s.CloseNote, &s.ExpectedCash, &s.CountedCash, &s.Variance)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// openShiftID mengembalikan ID shift yang sedang dibuka oleh userID (nil bila
+// tidak ada). Baris shift dikunci FOR SHARE sampai tx selesai, jadi shift
+// tidak bisa ditutup sebelum dokumen yang ditautkan ke sana tersimpan.
+func openShiftID(tx *sql.Tx, userID *int) (*int, error) {
+	if userID == nil {
+		return nil, nil
+	}
+	var id int
+	err := tx.QueryRow("SELECT id FROM shifts WHERE cashier_id = $1 AND status = $2 FOR SHARE", *userID, models.ShiftOpen).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// List mengembalikan shift sesuai filter, terbaru dulu, beserta jumlah totalnya.
+func (r *ShiftRepository) List(filter models.ShiftFilter) ([]models.Shift, int, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.CashierID != nil {
+		args = append(args, *filter.CashierID)
+		conditions = append(conditions, fmt.Sprintf("s.cashier_id = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("s.status = $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM shifts s"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	query := fmt.Sprintf(`%s%s
+		ORDER BY s.opened_at DESC, s.id DESC
+		LIMIT $%d OFFSET $%d`, shiftSelect, where, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	shifts := make([]models.Shift, 0)
+	for rows.Next() {
+		s, err := scanShift(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		shifts = append(shifts, *s)
+	}
+	return shifts, total, rows.Err()
+}
+
+// GetByID mengembalikan shift beserta kas masuk/keluar dan hasil hitung
+// pecahannya.
+func (r *ShiftRepository) GetByID(id int) (*models.Shift, error) {
+	s, err := scanShift(r.db.QueryRow(shiftSelect+" WHERE s.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrShiftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, shift_id, type, amount, reason, user_id, created_at
+		FROM shift_cash_movements
+		WHERE shift_id = $1
+		ORDER BY id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var m models.CashMovement
+		if err := rows.Scan(&m.ID, &m.ShiftID, &m.Type, &m.Amount, &m.Reason, &m.UserID, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		s.CashMovements = append(s.CashMovements, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	denominationRows, err := r.db.Query(`
+		SELECT denomination, count
+		FROM shift_denominations
+		WHERE shift_id = $1
+		ORDER BY denomination DESC`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer denominationRows.Close()
+	for denominationRows.Next() {
+		var d models.CashDenomination
+		if err := denominationRows.Scan(&d.Denomination, &d.Count); err != nil {
+			return nil, err
+		}
+		d.Amount = d.Denomination * d.Count
+		s.Denominations = append(s.Denominations, d)
+	}
+	return s, denominationRows.Err()
+}
+
+// GetOpen mengembalikan shift yang sedang dibuka oleh kasir.
+func (r *ShiftRepository) GetOpen(cashierID int) (*models.Shift, error) {
+	var id int
+	err := r.db.QueryRow("SELECT id FROM shifts WHERE cashier_id = $1 AND status = $2", cashierID, models.ShiftOpen).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, models.ErrShiftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+// Open membuka shift baru; satu kasir hanya boleh punya satu shift open.
+func (r *ShiftRepository) Open(s *models.Shift) error {
+	query := `
+		INSERT INTO shifts (cashier_id, opening_float, note)
+		VALUES ($1, $2, $3)
+		RETURNING id, status, opened_at`
+	err := r.db.QueryRow(query, s.CashierID, s.OpeningFloat, s.Note).Scan(&s.ID, &s.Status, &s.OpenedAt)
+	if isUniqueViolation(err) {
+		return models.ErrShiftAlreadyOpen
+	}
+	return err
+}
+
+// lockOpenShift mengunci shift dan memastikan statusnya masih open.
+func lockOpenShift(tx *sql.Tx, id int) (*models.Shift, error) {
+	var s models.Shift
+	err := tx.QueryRow("SELECT id, cashier_id, status, opening_float FROM shifts WHERE id = $1 FOR UPDATE", id).
+		Scan(&s.ID, &s.CashierID, &s.Status, &s.OpeningFloat)
+	if err == sql.ErrNoRows {
+		return nil, models.ErrShiftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s.Status != models.ShiftOpen {
+		return nil, models.ErrShiftClosed
+	}
+	return &s, nil
+}
+
+// AddCashMovement mencatat kas masuk/keluar pada shift yang masih open.
+func (r *ShiftRepository) AddCashMovement(m *models.CashMovement) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := lockOpenShift(tx, m.ShiftID); err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO shift_cash_movements (shift_id, type, amount, reason, user_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`
+	if err := tx.QueryRow(query, m.ShiftID, m.Type, m.Amount, m.Reason, m.UserID).Scan(&m.ID, &m.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Close menutup shift: uang yang seharusnya ada dihitung dari dokumen shift di
+// tx yang sama, lalu hasil hitung per pecahan dan selisihnya disimpan.
+// Checkout yang sedang berjalan di shift ini ditunggu lebih dulu.
+func (r *ShiftRepository) Close(id int, denominations []models.CashDenomination, note string, userID *int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	shift, err := lockOpenShift(tx, id)
+	if err != nil {
+		return err
+	}
+	report := &models.ShiftReport{Shift: *shift}
+	if err := shiftTotals(tx, report); err != nil {
+		return err
+	}
+
+	counted := 0
+	for _, d := range denominations {
+		_, err := tx.Exec("INSERT INTO shift_denominations (shift_id, denomination, count) VALUES ($1, $2, $3)",
+			id, d.Denomination, d.Count)
+		if err != nil {
+			return err
+		}
+		counted += d.Denomination * d.Count
+	}
+
+	_, err = tx.Exec(`
+		UPDATE shifts
+		SET status = $1, closed_by = $2, closed_at = CURRENT_TIMESTAMP, close_note = $3,
+			expected_cash = $4, counted_cash = $5, variance = $6
+		WHERE id = $7`, models.ShiftClosed, userID, note, report.ExpectedCash, counted, counted-report.ExpectedCash, id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetTotals mengisi angka laporan X/Z untuk report.Shift.
+func (r *ShiftRepository) GetTotals(report *models.ShiftReport) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := shiftTotals(tx, report); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// shiftTotals menjumlahkan transaksi, pembayaran kasbon dan kas masuk/keluar
+// yang ditautkan ke report.Shift, lalu menghitung ExpectedCash.
+func shiftTotals(tx *sql.Tx, report *models.ShiftReport) error {
+	id := report.Shift.ID
+	err := tx.QueryRow(`
+		SELECT COUNT(*) FILTER (WHERE type = 'sale'), COALESCE(SUM(total_amount) FILTER (WHERE type = 'sale'), 0),
+			COUNT(*) FILTER (WHERE type = 'void'), COALESCE(SUM(total_amount) FILTER (WHERE type = 'void'), 0),
+			COUNT(*) FILTER (WHERE type = 'return'), COALESCE(SUM(total_amount) FILTER (WHERE type = 'return'), 0),
+			COALESCE(SUM(total_amount), 0), COALESCE(SUM(tax_amount), 0)
+		FROM transactions
+		WHERE shift_id = $1`, id).
+		Scan(&report.Sales, &report.SalesAmount, &report.Voids, &report.VoidAmount, &report.Returns, &report.ReturnAmount,
+			&report.NetSales, &report.Tax)
+	if err != nil {
+		return err
+	}
+
+	if report.Payments, err = paymentSummaries(tx, `
+		SELECT p.method, SUM(p.amount), COUNT(*)
+		FROM transaction_payments p
+		JOIN transactions t ON t.id = p.transaction_id
+		WHERE t.shift_id = $1
+		GROUP BY p.method
+		ORDER BY p.method`, id); err != nil {
+		return err
+	}
+	if report.CreditRepayments, err = paymentSummaries(tx, `
+		SELECT method, -SUM(amount), COUNT(*)
+		FROM credit_entries
+		WHERE shift_id = $1 AND type = 'payment'
+		GROUP BY method
+		ORDER BY method`, id); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`
+		SELECT COALESCE(SUM(amount) FILTER (WHERE type = 'cash_in'), 0), COALESCE(SUM(amount) FILTER (WHERE type = 'cash_out'), 0)
+		FROM shift_cash_movements
+		WHERE shift_id = $1`, id).Scan(&report.CashIn, &report.CashOut)
+	if err != nil {
+		return err
+	}
+
+	// Tunai tanpa shift selama shift ini open tidak masuk laci mana pun.
+	err = tx.QueryRow(`
+		SELECT COALESCE((
+			SELECT SUM(p.amount)
+			FROM transaction_payments p
+			JOIN transactions t ON t.id = p.transaction_id
+			WHERE t.shift_id IS NULL AND p.method = $1 AND t.date >= $2 AND ($3::timestamptz IS NULL OR t.date < $3)
+		), 0) + COALESCE((
+			SELECT -SUM(amount)
+			FROM credit_entries
+			WHERE shift_id IS NULL AND type = 'payment' AND method = $1 AND created_at >= $2 AND ($3::timestamptz IS NULL OR created_at < $3)
+		), 0)`, models.PaymentCash, report.Shift.OpenedAt, report.Shift.ClosedAt).Scan(&report.CashOutsideShifts)
+	if err != nil {
+		return err
+	}
+
+	report.CashSales, report.CashRepayments = 0, 0
+	for _, p := range report.Payments {
+		if p.Method == models.PaymentCash {
+			report.CashSales = p.Amount
+		}
+	}
+	for _, p := range report.CreditRepayments {
+		if p.Method == models.PaymentCash {
+			report.CashRepayments = p.Amount
+		}
+	}
+	report.ExpectedCash = report.Shift.OpeningFloat + report.CashSales + report.CashRepayments + report.CashIn - report.CashOut
+	return nil
+}
+
+func paymentSummaries(q queryer, query string, args ...interface{}) ([]models.PaymentSummary, error) {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make([]models.PaymentSummary, 0)
+	for rows.Next() {
+		var p models.PaymentSummary
+		if err := rows.Scan(&p.Method, &p.Amount, &p.Count); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, p)
+	}
+	return summaries, rows.Err()
+}
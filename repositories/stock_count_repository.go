@@ -0,0 +1,293 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"go-kasir-api/models"
+	"time"
+)
+
+type StockCountRepository struct {
+	db *sql.DB
+}
+
+func NewStockCountRepository(db *sql.DB) *StockCountRepository {
+	return &StockCountRepository{db: db}
+}
+
+const stockCountSelect = `
+	SELECT id, status, note, opened_by, opened_at, closed_by, closed_at
+	FROM stock_counts`
+
+func scanStockCount(row rowScanner) (*models.StockCount, error) {
+	var c models.StockCount
+	if err := row.Scan(&c.ID, &c.Status, &c.Note, &c.OpenedBy, &c.OpenedAt, &c.ClosedBy, &c.ClosedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *StockCountRepository) GetAll() ([]models.StockCount, error) {
+	rows, err := r.db.Query(stockCountSelect + " ORDER BY id DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]models.StockCount, 0)
+	for rows.Next() {
+		c, err := scanStockCount(rows)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, *c)
+	}
+
+	return counts, rows.Err()
+}
+
+func (r *StockCountRepository) GetByID(id int) (*models.StockCount, error) {
+	c, err := scanStockCount(r.db.QueryRow(stockCountSelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrStockCountNotFound
+	}
+	return c, err
+}
+
+// Create membuka sesi hitung baru; hanya boleh ada satu sesi open.
+func (r *StockCountRepository) Create(c *models.StockCount) error {
+	query := "INSERT INTO stock_counts (note, opened_by) VALUES ($1, $2) RETURNING id, status, opened_at"
+	err := r.db.QueryRow(query, c.Note, c.OpenedBy).Scan(&c.ID, &c.Status, &c.OpenedAt)
+	if isUniqueViolation(err) {
+		return models.ErrStockCountOpen
+	}
+	return err
+}
+
+// lockOpen mengunci header sesi dan memastikan statusnya masih open.
+func lockOpen(tx *sql.Tx, id int) error {
+	var status string
+	err := tx.QueryRow("SELECT status FROM stock_counts WHERE id = $1 FOR UPDATE", id).Scan(&status)
+	if err == sql.ErrNoRows {
+		return models.ErrStockCountNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != models.StockCountOpen {
+		return models.ErrStockCountClosed
+	}
+	return nil
+}
+
+// SaveItems menyimpan hasil hitung satu perangkat beserta stok sistem saat
+// itu. Produk yang sudah pernah dikirim perangkat yang sama ditimpa.
+func (r *StockCountRepository) SaveItems(id int, entry models.StockCountEntry, userID *int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockOpen(tx, id); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO stock_count_items (count_id, product_id, device, quantity, system_quantity, counted_by, counted_at)
+		SELECT $1, p.id, $3, $4, p.stock, $5, CURRENT_TIMESTAMP
+		FROM products p
+		WHERE p.id = $2
+		ON CONFLICT (count_id, product_id, device)
+		DO UPDATE SET quantity = EXCLUDED.quantity, system_quantity = EXCLUDED.system_quantity,
+			counted_by = EXCLUDED.counted_by, counted_at = EXCLUDED.counted_at`
+	for _, item := range entry.Items {
+		result, err := tx.Exec(query, id, item.ProductID, entry.Device, item.Quantity, userID)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return models.NewValidationError("product %d not found", item.ProductID)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// countEntry adalah hasil hitung satu perangkat untuk satu produk.
+type countEntry struct {
+	ProductID      int
+	ProductName    string
+	Cost           int
+	Quantity       models.Quantity
+	SystemQuantity models.Quantity
+	CountedAt      time.Time
+}
+
+// countLines menjumlahkan hasil hitung per produk. entries harus urut per
+// produk. Stok sistem adalah saldo saat produk pertama kali dihitung: mutasi
+// sesudahnya dianggap mengenai stok yang sudah dihitung, sehingga penjualan di
+// antara hitungan dua perangkat tidak terbaca sebagai selisih.
+func countLines(entries []countEntry) ([]models.StockCountLine, error) {
+	lines := make([]models.StockCountLine, 0)
+	var first time.Time
+	for _, e := range entries {
+		n := len(lines)
+		if n == 0 || *lines[n-1].ProductID != e.ProductID {
+			productID := e.ProductID
+			lines = append(lines, models.StockCountLine{
+				ProductID:      &productID,
+				ProductName:    e.ProductName,
+				Cost:           e.Cost,
+				SystemQuantity: e.SystemQuantity,
+			})
+			first = e.CountedAt
+			n++
+		} else if e.CountedAt.Before(first) {
+			lines[n-1].SystemQuantity = e.SystemQuantity
+			first = e.CountedAt
+		}
+		lines[n-1].CountedQuantity += e.Quantity
+	}
+	for i := range lines {
+		l := &lines[i]
+		l.Variance = l.CountedQuantity - l.SystemQuantity
+		var ok bool
+		if l.VarianceValue, ok = l.Variance.Amount(l.Cost); !ok {
+			return nil, models.NewValidationError("variance value of product %d is too large", *l.ProductID)
+		}
+	}
+	return lines, nil
+}
+
+// countedLines menghitung selisih sesi id dari hasil hitung semua perangkat.
+func countedLines(q queryer, id int) ([]models.StockCountLine, error) {
+	query := `
+		SELECT i.product_id, p.name, p.cost, i.quantity, i.system_quantity, i.counted_at
+		FROM stock_count_items i
+		JOIN products p ON p.id = i.product_id
+		WHERE i.count_id = $1
+		ORDER BY i.product_id, i.counted_at`
+
+	rows, err := q.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []countEntry
+	for rows.Next() {
+		var e countEntry
+		if err := rows.Scan(&e.ProductID, &e.ProductName, &e.Cost, &e.Quantity, &e.SystemQuantity, &e.CountedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return countLines(entries)
+}
+
+// GetLines mengembalikan selisih yang sudah dibukukan untuk sesi posted, atau
+// pratinjau selisih untuk sesi lainnya.
+func (r *StockCountRepository) GetLines(c *models.StockCount) ([]models.StockCountLine, error) {
+	if c.Status != models.StockCountPosted {
+		return countedLines(r.db, c.ID)
+	}
+
+	query := `
+		SELECT product_id, product_name, system_quantity, counted_quantity, variance, cost, variance_value
+		FROM stock_count_lines
+		WHERE count_id = $1
+		ORDER BY product_name`
+	rows, err := r.db.Query(query, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := make([]models.StockCountLine, 0)
+	for rows.Next() {
+		var l models.StockCountLine
+		err := rows.Scan(&l.ProductID, &l.ProductName, &l.SystemQuantity, &l.CountedQuantity, &l.Variance, &l.Cost, &l.VarianceValue)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+
+	return lines, rows.Err()
+}
+
+// Post membukukan sesi: setiap selisih ditulis sebagai penyesuaian di ledger
+// stok dan disimpan di stock_count_lines, lalu sesi ditutup.
+func (r *StockCountRepository) Post(id int, userID *int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockOpen(tx, id); err != nil {
+		return err
+	}
+
+	lines, err := countedLines(tx, id)
+	if err != nil {
+		return err
+	}
+
+	lineQuery := `
+		INSERT INTO stock_count_lines (count_id, product_id, product_name, system_quantity, counted_quantity, variance, cost, variance_value)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	// Lines are ordered by product ID, so product rows are locked in the same
+	// order as checkout.
+	for _, l := range lines {
+		if l.Variance != 0 {
+			err := moveStock(tx, &models.StockMovement{
+				ProductID:     *l.ProductID,
+				Type:          models.StockAdjustment,
+				Quantity:      l.Variance,
+				ReferenceType: models.ReferenceStockCount,
+				ReferenceID:   &id,
+				Note:          fmt.Sprintf("stock count #%d", id),
+				UserID:        userID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		_, err := tx.Exec(lineQuery, id, l.ProductID, l.ProductName, l.SystemQuantity, l.CountedQuantity, l.Variance, l.Cost, l.VarianceValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.close(tx, id, models.StockCountPosted, userID)
+}
+
+func (r *StockCountRepository) Cancel(id int, userID *int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := lockOpen(tx, id); err != nil {
+		return err
+	}
+	return r.close(tx, id, models.StockCountCancelled, userID)
+}
+
+func (r *StockCountRepository) close(tx *sql.Tx, id int, status string, userID *int) error {
+	_, err := tx.Exec("UPDATE stock_counts SET status = $1, closed_by = $2, closed_at = CURRENT_TIMESTAMP WHERE id = $3",
+		status, userID, id)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
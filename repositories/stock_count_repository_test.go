@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"go-kasir-api/models"
+	"testing"
+	"time"
+)
+
+func TestCountLines(t *testing.T) {
+	t0 := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	entry := func(productID int, quantity, system models.Quantity, minutes int) countEntry {
+		return countEntry{
+			ProductID:      productID,
+			ProductName:    "product",
+			Cost:           1000,
+			Quantity:       quantity,
+			SystemQuantity: system,
+			CountedAt:      t0.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+
+	tests := []struct {
+		name         string
+		entries      []countEntry
+		wantSystem   models.Quantity
+		wantCounted  models.Quantity
+		wantVariance models.Quantity
+	}{
+		{
+			name:         "single device",
+			entries:      []countEntry{entry(1, models.Units(8), models.Units(10), 0)},
+			wantSystem:   models.Units(10),
+			wantCounted:  models.Units(8),
+			wantVariance: -models.Units(2),
+		},
+		{
+			name:         "two devices without sales",
+			entries:      []countEntry{entry(1, models.Units(6), models.Units(10), 0), entry(1, models.Units(4), models.Units(10), 5)},
+			wantSystem:   models.Units(10),
+			wantCounted:  models.Units(10),
+			wantVariance: 0,
+		},
+		{
+			// Rak depan dihitung 6, lalu satu terjual dari rak itu sebelum
+			// gudang dihitung 4 dengan saldo sistem 9.
+			name:         "sale between two devices' counts",
+			entries:      []countEntry{entry(1, models.Units(6), models.Units(10), 0), entry(1, models.Units(4), models.Units(9), 5)},
+			wantSystem:   models.Units(10),
+			wantCounted:  models.Units(10),
+			wantVariance: 0,
+		},
+		{
+			name:         "earliest figure sets the system quantity",
+			entries:      []countEntry{entry(1, models.Units(4), models.Units(9), 5), entry(1, models.Units(5), models.Units(10), 0)},
+			wantSystem:   models.Units(10),
+			wantCounted:  models.Units(9),
+			wantVariance: -models.Units(1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := countLines(tt.entries)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(lines) != 1 {
+				t.Fatalf("got %d lines, want 1", len(lines))
+			}
+			l := lines[0]
+			if l.SystemQuantity != tt.wantSystem || l.CountedQuantity != tt.wantCounted || l.Variance != tt.wantVariance {
+				t.Errorf("system/counted/variance = %s/%s/%s, want %s/%s/%s",
+					l.SystemQuantity, l.CountedQuantity, l.Variance, tt.wantSystem, tt.wantCounted, tt.wantVariance)
+			}
+			if want, _ := tt.wantVariance.Amount(1000); l.VarianceValue != want {
+				t.Errorf("variance value = %d, want %d", l.VarianceValue, want)
+			}
+		})
+	}
+}
+
+func TestCountLinesPerProduct(t *testing.T) {
+	lines, err := countLines([]countEntry{
+		{ProductID: 1, Quantity: models.Units(3), SystemQuantity: models.Units(3)},
+		{ProductID: 2, Quantity: models.Units(1), SystemQuantity: models.Units(2), Cost: 500},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 2 || *lines[0].ProductID != 1 || *lines[1].ProductID != 2 {
+		t.Fatalf("lines = %+v, want products 1 and 2", lines)
+	}
+	if lines[0].Variance != 0 || lines[1].Variance != -models.Units(1) || lines[1].VarianceValue != -500 {
+		t.Errorf("variances = %s/%s (%d), want 0/-1 (-500)", lines[0].Variance, lines[1].Variance, lines[1].VarianceValue)
+	}
+	if got, err := countLines(nil); err != nil || len(got) != 0 {
+		t.Errorf("no entries gives %d lines, %v", len(got), err)
+	}
+
+	huge := []countEntry{{ProductID: 1, Quantity: models.Units(100000000000), Cost: 1 << 62}}
+	if _, err := countLines(huge); err == nil {
+		t.Error("variance value overflow is not reported")
+	}
+}
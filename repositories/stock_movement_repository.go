@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"go-kasir-api/models"
+	"strings"
+)
+
+type StockMovementRepository struct {
+	db *sql.DB
+}
+
+func NewStockMovementRepository(db *sql.DB) *StockMovementRepository {
+	return &StockMovementRepository{db: db}
+}
+
+// moveStock adalah satu-satunya jalan untuk mengubah products.stock: stok
+// diubah sebesar m.Quantity dan entri ledger ditulis di tx yang sama, lalu
+// BalanceAfter, ID dan CreatedAt diisi. Baris produk terkunci sampai tx
+// selesai, jadi saldo di ledger selalu berurutan.
+func moveStock(tx *sql.Tx, m *models.StockMovement) error {
+	err := tx.QueryRow("UPDATE products SET stock = stock + $1 WHERE id = $2 RETURNING stock", m.Quantity, m.ProductID).
+		Scan(&m.BalanceAfter)
+	if err == sql.ErrNoRows {
+		return models.ErrProductNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if m.BalanceAfter < 0 {
+		return models.NewValidationError("stock of product %d cannot go below zero (would be %s)", m.ProductID, m.BalanceAfter)
+	}
+
+	query := `
+		INSERT INTO stock_movements (product_id, type, quantity, balance_after, reference_type, reference_id, note, user_id)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8)
+		RETURNING id, created_at`
+	return tx.QueryRow(query, m.ProductID, m.Type, m.Quantity, m.BalanceAfter, m.ReferenceType, m.ReferenceID,
+		m.Note, m.UserID).Scan(&m.ID, &m.CreatedAt)
+}
+
+// Create mencatat pergerakan stok manual (penyesuaian, transfer, dll.).
+func (r *StockMovementRepository) Create(m *models.StockMovement) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := moveStock(tx, m); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// List mengembalikan riwayat stok satu produk, terbaru dulu.
+func (r *StockMovementRepository) List(filter models.StockMovementFilter) ([]models.StockMovement, int, error) {
+	conditions := []string{"product_id = $1"}
+	args := []interface{}{filter.ProductID}
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.StartDate != nil {
+		addCondition("created_at >= $%d", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		addCondition("created_at < $%d", *filter.EndDate)
+	}
+	if filter.Type != "" {
+		addCondition("type = $%d", filter.Type)
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM stock_movements"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	query := fmt.Sprintf(`
+		SELECT id, product_id, type, quantity, balance_after, COALESCE(reference_type, ''), reference_id, note, user_id, created_at
+		FROM stock_movements%s
+		ORDER BY id DESC
+		LIMIT $%d OFFSET $%d`, where, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	movements := make([]models.StockMovement, 0)
+	for rows.Next() {
+		var m models.StockMovement
+		err := rows.Scan(&m.ID, &m.ProductID, &m.Type, &m.Quantity, &m.BalanceAfter, &m.ReferenceType, &m.ReferenceID,
+			&m.Note, &m.UserID, &m.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		movements = append(movements, m)
+	}
+
+	return movements, total, rows.Err()
+}
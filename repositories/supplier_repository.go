@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+)
+
+type SupplierRepository struct {
+	db *sql.DB
+}
+
+func NewSupplierRepository(db *sql.DB) *SupplierRepository {
+	return &SupplierRepository{db: db}
+}
+
+const supplierSelect = "SELECT id, name, phone, email, address, active FROM suppliers"
+
+func scanSupplier(row rowScanner) (*models.Supplier, error) {
+	var s models.Supplier
+	if err := row.Scan(&s.ID, &s.Name, &s.Phone, &s.Email, &s.Address, &s.Active); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (repo *SupplierRepository) GetAll() ([]models.Supplier, error) {
+	rows, err := repo.db.Query(supplierSelect + " ORDER BY name, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppliers := make([]models.Supplier, 0)
+	for rows.Next() {
+		s, err := scanSupplier(rows)
+		if err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, *s)
+	}
+
+	return suppliers, rows.Err()
+}
+
+func (repo *SupplierRepository) GetByID(id int) (*models.Supplier, error) {
+	s, err := scanSupplier(repo.db.QueryRow(supplierSelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrSupplierNotFound
+	}
+	return s, err
+}
+
+func (repo *SupplierRepository) Create(s *models.Supplier) error {
+	query := "INSERT INTO suppliers (name, phone, email, address, active) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+	return repo.db.QueryRow(query, s.Name, s.Phone, s.Email, s.Address, s.Active).Scan(&s.ID)
+}
+
+func (repo *SupplierRepository) Update(s *models.Supplier) error {
+	query := "UPDATE suppliers SET name = $1, phone = $2, email = $3, address = $4, active = $5 WHERE id = $6"
+	result, err := repo.db.Exec(query, s.Name, s.Phone, s.Email, s.Address, s.Active, s.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrSupplierNotFound
+	}
+	return nil
+}
+
+// Delete menghapus supplier yang belum punya PO. Supplier dengan riwayat
+// pembelian cukup dinonaktifkan.
+func (repo *SupplierRepository) Delete(id int) error {
+	result, err := repo.db.Exec("DELETE FROM suppliers WHERE id = $1", id)
+	if isForeignKeyViolation(err) {
+		return models.ErrSupplierInUse
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrSupplierNotFound
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+)
+
+type TaxRateRepository struct {
+	db *sql.DB
+}
+
+func NewTaxRateRepository(db *sql.DB) *TaxRateRepository {
+	return &TaxRateRepository{db: db}
+}
+
+func (repo *TaxRateRepository) GetAll() ([]models.TaxRate, error) {
+	rows, err := repo.db.Query("SELECT id, name, rate::float8, inclusive FROM tax_rates ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := make([]models.TaxRate, 0)
+	for rows.Next() {
+		var t models.TaxRate
+		if err := rows.Scan(&t.ID, &t.Name, &t.Rate, &t.Inclusive); err != nil {
+			return nil, err
+		}
+		rates = append(rates, t)
+	}
+
+	return rates, rows.Err()
+}
+
+func (repo *TaxRateRepository) GetByID(id int) (*models.TaxRate, error) {
+	var t models.TaxRate
+	err := repo.db.QueryRow("SELECT id, name, rate::float8, inclusive FROM tax_rates WHERE id = $1", id).
+		Scan(&t.ID, &t.Name, &t.Rate, &t.Inclusive)
+	if err == sql.ErrNoRows {
+		return nil, models.ErrTaxRateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (repo *TaxRateRepository) Create(t *models.TaxRate) error {
+	query := "INSERT INTO tax_rates (name, rate, inclusive) VALUES ($1, $2, $3) RETURNING id"
+	return repo.db.QueryRow(query, t.Name, t.Rate, t.Inclusive).Scan(&t.ID)
+}
+
+func (repo *TaxRateRepository) Update(t *models.TaxRate) error {
+	query := "UPDATE tax_rates SET name = $1, rate = $2, inclusive = $3 WHERE id = $4"
+	result, err := repo.db.Exec(query, t.Name, t.Rate, t.Inclusive, t.ID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrTaxRateNotFound
+	}
+	return nil
+}
+
+func (repo *TaxRateRepository) Delete(id int) error {
+	result, err := repo.db.Exec("DELETE FROM tax_rates WHERE id = $1", id)
+	if isForeignKeyViolation(err) {
+		return models.ErrTaxRateInUse
+	}
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrTaxRateNotFound
+	}
+	return nil
+}
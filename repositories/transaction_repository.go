@@ -2,50 +2,635 @@ package repositories
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	"go-kasir-api/models"
+	"sort"
+	"strings"
 	"time"
 )
 
 type TransactionRepository struct {
-	db *sql.DB
+	db          *sql.DB
+	productRepo *ProductRepository
 }
 
-func NewTransactionRepository(db *sql.DB) *TransactionRepository {
-	return &TransactionRepository{db: db}
+func NewTransactionRepository(db *sql.DB, productRepo *ProductRepository) *TransactionRepository {
+	return &TransactionRepository{db: db, productRepo: productRepo}
 }
 
-func (r *TransactionRepository) CreateTransaction(transaction *models.Transaction) error {
+// transactionSelect memilih kolom header transaksi; dipakai bersama scanTransaction.
+const transactionSelect = `
+	SELECT t.id, t.type, t.reference_id, t.cashier_id, t.customer_id, t.shift_id, t.date, t.discount_amount, t.tax_amount, t.total_amount,
+		t.reason, t.approved_by, t.points_earned, t.points_redeemed
+	FROM transactions t`
+
+func scanTransaction(row rowScanner) (*models.Transaction, error) {
+	var t models.Transaction
+	err := row.Scan(&t.ID, &t.Type, &t.ReferenceID, &t.CashierID, &t.CustomerID, &t.ShiftID, &t.Date, &t.Discount, &t.Tax, &t.Total,
+		&t.Reason, &t.ApprovedBy, &t.PointsEarned, &t.PointsRedeemed)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// detailSelect memilih kolom detail transaksi; dipakai bersama scanDetail.
+const detailSelect = `
+	SELECT td.id, td.transaction_id, td.reference_detail_id, COALESCE(td.product_id, 0), td.parent_product_id,
+		td.product_name, td.unit_price, td.quantity, td.unit, td.unit_factor, td.discount, td.subtotal,
+		td.tax_rate_id, COALESCE(td.tax_name, ''), td.tax_rate::float8, td.tax_inclusive, td.tax,
+		td.unit_cost
+	FROM transaction_details td`
+
+func scanDetail(row rowScanner) (*models.TransactionDetail, error) {
+	var d models.TransactionDetail
+	err := row.Scan(&d.ID, &d.TransactionID, &d.ReferenceDetailID, &d.ProductID, &d.ParentProductID, &d.ProductName, &d.UnitPrice, &d.Quantity,
+		&d.Unit, &d.UnitFactor, &d.Discount, &d.Subtotal, &d.TaxRateID, &d.TaxName, &d.TaxRate, &d.TaxInclusive, &d.Tax, &d.UnitCost)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// CreateTransaction menyimpan transaksi beserta detail dan pembayarannya.
+// Produk dikunci dan stok dicek di dalam tx, lalu price dipanggil dengan
+// produk yang sudah terkunci untuk mengisi subtotal, total dan pembayaran
+// sebelum semuanya ditulis. Dengan begitu harga selalu dari database dan stok
+// tidak bisa terjual dua kali oleh checkout paralel. Poin yang dipakai dan
+// didapat pelanggan serta tender credit ke kasbonnya dibukukan di tx yang
+// sama. Produk yang stoknya turun ke
+// bawah min_stock karena checkout ini dikembalikan sebagai peringatan.
+func (r *TransactionRepository) CreateTransaction(transaction *models.Transaction, price func(products map[int]*models.Product) error) ([]models.LowStockAlert, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// 1. Lock products, always in ascending ID order to avoid deadlocks
+	// between concurrent checkouts touching the same items.
+	requested := make(map[int]models.Quantity)
+	for _, detail := range transaction.Details {
+		requested[detail.ProductID] += detail.BaseQuantity()
+	}
+	productIDs := make([]int, 0, len(requested))
+	for id := range requested {
+		productIDs = append(productIDs, id)
+	}
+	sort.Ints(productIDs)
+
+	products := make(map[int]*models.Product, len(productIDs))
+	var shortages []models.InsufficientStockItem
+	for _, id := range productIDs {
+		product, err := r.productRepo.GetByIDForUpdate(tx, id)
+		if err != nil {
+			if errors.Is(err, models.ErrProductNotFound) {
+				return nil, fmt.Errorf("product %d: %w", id, err)
+			}
+			return nil, err
+		}
+		if product.HasVariants {
+			return nil, models.NewValidationError("product %d has variants; sell one of its variants instead", id)
+		}
+		products[id] = product
+
+		if product.Stock < requested[id] {
+			shortages = append(shortages, models.InsufficientStockItem{
+				ProductID: id,
+				Name:      product.Name,
+				Requested: requested[id],
+				Available: product.Stock,
+			})
+		}
+	}
+	if len(shortages) > 0 {
+		return nil, &models.InsufficientStockError{Items: shortages}
+	}
+
+	// 2. Price the lines and settle payments with the locked products
+	if err := price(products); err != nil {
+		return nil, err
 	}
 
-	// 1. Insert Transaction
-	// We only insert date and total. ID is auto-increment.
-	// Assuming the table structure matches.
-	query := "INSERT INTO transactions (date, total_amount) VALUES ($1, $2) RETURNING id"
-	// Assuming date is current time if not set, or we use the passed date.
+	// 3. Insert Transaction and its details
+	transaction.Type = models.TransactionTypeSale
+	transaction.ReferenceID = nil
 	if transaction.Date.IsZero() {
 		transaction.Date = time.Now()
 	}
+	if err := insertTransaction(tx, transaction); err != nil {
+		return nil, err
+	}
+
+	// 4. Deduct stock through the ledger
+	var alerts []models.LowStockAlert
+	for _, id := range productIDs {
+		movement := &models.StockMovement{
+			ProductID:     id,
+			Type:          models.StockSale,
+			Quantity:      -requested[id],
+			ReferenceType: models.ReferenceTransaction,
+			ReferenceID:   &transaction.ID,
+			UserID:        transaction.CashierID,
+		}
+		if err := moveStock(tx, movement); err != nil {
+			return nil, err
+		}
+
+		product := products[id]
+		if product.Stock >= product.MinStock && movement.BalanceAfter < product.MinStock {
+			alerts = append(alerts, models.LowStockAlert{
+				ProductID:       id,
+				Name:            product.Name,
+				Stock:           movement.BalanceAfter,
+				MinStock:        product.MinStock,
+				ReorderQuantity: product.ReorderQuantity,
+				TransactionID:   transaction.ID,
+				CreatedAt:       movement.CreatedAt,
+			})
+		}
+	}
+
+	// 5. Post loyalty points and credit after the products, so locks are
+	// always taken in the order products, customer
+	if transaction.CustomerID != nil {
+		entries := []models.LoyaltyPointEntry{
+			{Type: models.PointsRedeem, Points: -transaction.PointsRedeemed},
+			{Type: models.PointsEarn, Points: transaction.PointsEarned},
+		}
+		if err := postPoints(tx, transaction, entries); err != nil {
+			return nil, err
+		}
+		credit := 0
+		for _, p := range transaction.Payments {
+			if p.Method == models.PaymentCredit {
+				credit += p.Amount
+			}
+		}
+		if err := postCredit(tx, transaction, models.CreditCharge, credit); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// postPoints menulis entri poin transaksi yang nilainya tidak nol ke ledger
+// pelanggan transaksi itu.
+func postPoints(tx *sql.Tx, transaction *models.Transaction, entries []models.LoyaltyPointEntry) error {
+	for i := range entries {
+		e := &entries[i]
+		if e.Points == 0 {
+			continue
+		}
+		e.CustomerID = *transaction.CustomerID
+		e.TransactionID = &transaction.ID
+		e.UserID = transaction.CashierID
+		if err := movePoints(tx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postCredit menulis entri kasbon sebesar amount untuk transaksi pelanggan,
+// bila tidak nol.
+func postCredit(tx *sql.Tx, transaction *models.Transaction, entryType string, amount int) error {
+	if amount == 0 {
+		return nil
+	}
+	return moveCredit(tx, &models.CreditEntry{
+		CustomerID:    *transaction.CustomerID,
+		Type:          entryType,
+		Amount:        amount,
+		TransactionID: &transaction.ID,
+		UserID:        transaction.CashierID,
+	})
+}
+
+// List mengembalikan header transaksi (tanpa detail) sesuai filter, terbaru dulu.
+func (r *TransactionRepository) List(filter models.TransactionFilter) ([]models.Transaction, int, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.StartDate != nil {
+		addCondition("t.date >= $%d", *filter.StartDate)
+	}
+	if filter.EndDate != nil {
+		addCondition("t.date < $%d", *filter.EndDate)
+	}
+	if filter.MinTotal != nil {
+		addCondition("t.total_amount >= $%d", *filter.MinTotal)
+	}
+	if filter.MaxTotal != nil {
+		addCondition("t.total_amount <= $%d", *filter.MaxTotal)
+	}
+	if filter.Type != "" {
+		addCondition("t.type = $%d", filter.Type)
+	}
+	if filter.CustomerID != nil {
+		addCondition("t.customer_id = $%d", *filter.CustomerID)
+	}
+	if filter.ShiftID != nil {
+		addCondition("t.shift_id = $%d", *filter.ShiftID)
+	}
+	if filter.ProductID != nil {
+		addCondition("EXISTS (SELECT 1 FROM transaction_details td WHERE td.transaction_id = t.id AND td.product_id = $%d)", *filter.ProductID)
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM transactions t"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	query := fmt.Sprintf(`%s%s
+		ORDER BY t.date DESC, t.id DESC
+		LIMIT $%d OFFSET $%d`, transactionSelect, where, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	transactions := make([]models.Transaction, 0)
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		transactions = append(transactions, *t)
+	}
+
+	return transactions, total, rows.Err()
+}
+
+// GetByID mengembalikan header transaksi beserta detail item saat penjualan,
+// promosi yang terpakai dan pembayarannya.
+func (r *TransactionRepository) GetByID(id int) (*models.Transaction, error) {
+	t, err := scanTransaction(r.db.QueryRow(transactionSelect+" WHERE t.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(detailSelect+" WHERE td.transaction_id = $1 ORDER BY td.id", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	t.Details = make([]models.TransactionDetail, 0)
+	for rows.Next() {
+		d, err := scanDetail(rows)
+		if err != nil {
+			return nil, err
+		}
+		t.Details = append(t.Details, *d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
 
-	var id int
-	err = tx.QueryRow(query, transaction.Date, transaction.Total).Scan(&id)
+	detailIDs := make([]int, len(t.Details))
+	for i, d := range t.Details {
+		detailIDs[i] = d.ID
+	}
+	modifiers, err := detailModifiers(r.db, detailIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range t.Details {
+		t.Details[i].Modifiers = modifiers[t.Details[i].ID]
+	}
+
+	promotionRows, err := r.db.Query(`
+		SELECT promotion_id, name, type, discount
+		FROM transaction_promotions
+		WHERE transaction_id = $1
+		ORDER BY id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer promotionRows.Close()
+
+	for promotionRows.Next() {
+		var p models.AppliedPromotion
+		if err := promotionRows.Scan(&p.PromotionID, &p.Name, &p.Type, &p.Discount); err != nil {
+			return nil, err
+		}
+		t.Promotions = append(t.Promotions, p)
+	}
+	if err := promotionRows.Err(); err != nil {
+		return nil, err
+	}
+
+	paymentQuery := `
+		SELECT id, transaction_id, method, amount, tendered, change_amount, reference
+		FROM transaction_payments
+		WHERE transaction_id = $1
+		ORDER BY id`
+	paymentRows, err := r.db.Query(paymentQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer paymentRows.Close()
+
+	for paymentRows.Next() {
+		var p models.Payment
+		if err := paymentRows.Scan(&p.ID, &p.TransactionID, &p.Method, &p.Amount, &p.Tendered, &p.Change, &p.Reference); err != nil {
+			return nil, err
+		}
+		t.Payments = append(t.Payments, p)
+		t.Change += p.Change
+	}
+
+	return t, paymentRows.Err()
+}
+
+// insertTransaction menulis header dan semua detail di dalam tx, lalu mengisi
+// ID yang dihasilkan database. Penjualan ditautkan ke shift yang sedang
+// dibuka oleh CashierID, bila ada; shift void/retur ditentukan oleh Reverse.
+// Tender tunai butuh shift, karena uangnya harus masuk ke rekonsiliasi laci.
+func insertTransaction(tx *sql.Tx, transaction *models.Transaction) error {
+	if transaction.Type == models.TransactionTypeSale {
+		shiftID, err := openShiftID(tx, transaction.CashierID)
+		if err != nil {
+			return err
+		}
+		transaction.ShiftID = shiftID
+	}
+	if transaction.ShiftID == nil {
+		for _, p := range transaction.Payments {
+			if p.Method == models.PaymentCash && p.Amount != 0 {
+				return models.NewValidationError("open a shift before taking or refunding cash")
+			}
+		}
+	}
+
+	query := `
+		INSERT INTO transactions (type, reference_id, cashier_id, customer_id, shift_id, date, discount_amount, tax_amount, total_amount,
+			reason, approved_by, points_earned, points_redeemed)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
+	err := tx.QueryRow(query, transaction.Type, transaction.ReferenceID, transaction.CashierID, transaction.CustomerID, transaction.ShiftID,
+		transaction.Date, transaction.Discount, transaction.Tax, transaction.Total, transaction.Reason, transaction.ApprovedBy,
+		transaction.PointsEarned, transaction.PointsRedeemed).Scan(&transaction.ID)
 	if err != nil {
-		tx.Rollback()
 		return err
 	}
-	// transaction.ID = int(id) // id is already int
-	transaction.ID = id
 
-	// 2. Insert Transaction Details
-	for _, detail := range transaction.Details {
-		detailQuery := "INSERT INTO transaction_details (transaction_id, product_id, quantity, subtotal) VALUES ($1, $2, $3, $4)"
-		// Ensure formatting matches DB types.
-		// detail.TransactionID is set to the new ID.
-		_, err := tx.Exec(detailQuery, transaction.ID, detail.ProductID, detail.Quantity, detail.Subtotal)
+	detailQuery := `
+		INSERT INTO transaction_details (transaction_id, reference_detail_id, product_id, product_name, unit_price, quantity,
+			discount, subtotal, tax_rate_id, tax_name, tax_rate, tax_inclusive, tax, unit_cost, parent_product_id, unit, unit_factor)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, $12, $13, $14, $15, $16, $17) RETURNING id`
+	for i := range transaction.Details {
+		detail := &transaction.Details[i]
+		detail.TransactionID = transaction.ID
+		err := tx.QueryRow(detailQuery, transaction.ID, detail.ReferenceDetailID, detail.ProductID, detail.ProductName,
+			detail.UnitPrice, detail.Quantity, detail.Discount, detail.Subtotal, detail.TaxRateID, detail.TaxName, detail.TaxRate,
+			detail.TaxInclusive, detail.Tax, detail.UnitCost, detail.ParentProductID, detail.Unit, detail.UnitFactor).Scan(&detail.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range detail.Modifiers {
+			_, err := tx.Exec(`
+				INSERT INTO transaction_detail_modifiers (detail_id, modifier_id, group_name, name, price)
+				VALUES ($1, $2, $3, $4, $5)`, detail.ID, m.ModifierID, m.GroupName, m.Name, m.Price)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	promotionQuery := `
+		INSERT INTO transaction_promotions (transaction_id, promotion_id, name, type, discount)
+		VALUES ($1, $2, $3, $4, $5)`
+	for _, promotion := range transaction.Promotions {
+		_, err := tx.Exec(promotionQuery, transaction.ID, promotion.PromotionID, promotion.Name, promotion.Type, promotion.Discount)
 		if err != nil {
-			tx.Rollback()
+			return err
+		}
+	}
+
+	paymentQuery := `
+		INSERT INTO transaction_payments (transaction_id, method, amount, tendered, change_amount, reference)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	for i := range transaction.Payments {
+		payment := &transaction.Payments[i]
+		payment.TransactionID = transaction.ID
+		err := tx.QueryRow(paymentQuery, transaction.ID, payment.Method, payment.Amount, payment.Tendered,
+			payment.Change, payment.Reference).Scan(&payment.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Reverse membuat dokumen void/retur bernilai negatif untuk penjualan
+// reversal.ReferenceID dan mengembalikan stok item yang diretur. Baris
+// penjualan asli dikunci selama tx, sehingga dua retur paralel tidak bisa
+// mengembalikan qty yang sama dua kali.
+//
+// Untuk void, Details diabaikan dan seluruh sisa qty yang belum diretur
+// dibatalkan. Untuk retur, Details berisi ReferenceDetailID dan Quantity
+// (positif) per baris yang dikembalikan. Payments berisi satu tender refund
+// yang nilainya diisi sebesar total negatif dokumen.
+//
+// Bila penjualan memakai pelanggan, dokumen ikut mencatat pelanggannya. Poin
+// yang dipakai membayar dikembalikan lebih dulu (tender points), lalu bagian
+// yang dibayar kasbon mengurangi utangnya (tender credit), dan hanya sisanya
+// lewat tender refund; poin yang didapat ditarik sebanding dengan nilai yang
+// dikembalikan, seluruh sisanya bila semua item sudah kembali.
+//
+// Dokumen masuk ke shift penjualan selama shift itu masih open, supaya refund
+// tunai keluar dari laci yang menerimanya. Bila shift itu sudah ditutup, void
+// ditolak dan retur masuk ke shift yang sedang dibuka oleh CashierID; refund
+// tunainya ditolak bila CashierID tidak punya shift open.
+func (r *TransactionRepository) Reverse(reversal *models.Transaction) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var original models.Transaction
+	err = tx.QueryRow("SELECT type, customer_id, shift_id, total_amount, points_earned, points_redeemed FROM transactions WHERE id = $1 FOR UPDATE",
+		*reversal.ReferenceID).Scan(&original.Type, &original.CustomerID, &original.ShiftID, &original.Total, &original.PointsEarned, &original.PointsRedeemed)
+	if err == sql.ErrNoRows {
+		return models.ErrTransactionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if original.Type != models.TransactionTypeSale {
+		return models.NewValidationError("transaction %d is a %s document and cannot be reversed", *reversal.ReferenceID, original.Type)
+	}
+	if err := reversalShift(tx, reversal, &original); err != nil {
+		return err
+	}
+	shiftClosed := original.ShiftID != nil && (reversal.ShiftID == nil || *reversal.ShiftID != *original.ShiftID)
+
+	var voided bool
+	err = tx.QueryRow("SELECT EXISTS (SELECT 1 FROM transactions WHERE reference_id = $1 AND type = $2)",
+		*reversal.ReferenceID, models.TransactionTypeVoid).Scan(&voided)
+	if err != nil {
+		return err
+	}
+	if voided {
+		return models.ErrTransactionVoided
+	}
+
+	lines, err := remainingLines(tx, *reversal.ReferenceID)
+	if err != nil {
+		return err
+	}
+
+	// Build the negative lines
+	var details []models.TransactionDetail
+	if reversal.Type == models.TransactionTypeVoid {
+		for _, line := range lines {
+			if line.remainingQty > 0 {
+				details = append(details, line.reverse(line.remainingQty))
+			}
+		}
+		if len(details) == 0 {
+			return models.NewValidationError("all items of transaction %d have already been returned", *reversal.ReferenceID)
+		}
+	} else {
+		byID := make(map[int]*soldLine, len(lines))
+		for i := range lines {
+			byID[lines[i].detail.ID] = &lines[i]
+		}
+		for _, item := range reversal.Details {
+			line, ok := byID[*item.ReferenceDetailID]
+			if !ok {
+				return models.NewValidationError("detail %d does not belong to transaction %d", *item.ReferenceDetailID, *reversal.ReferenceID)
+			}
+			if item.Quantity > line.remainingQty {
+				return models.NewValidationError("cannot return %s %s of %s, only %s left", item.Quantity, line.detail.Unit, line.detail.ProductName, line.remainingQty)
+			}
+			if _, exact := item.Quantity.Mul(line.detail.UnitFactor); !exact {
+				return models.NewValidationError("cannot return %s %s of %s, it is not a whole number of base units", item.Quantity, line.detail.Unit, line.detail.ProductName)
+			}
+			details = append(details, line.reverse(item.Quantity))
+			line.remainingQty -= item.Quantity
+			line.remainingSubtotal += details[len(details)-1].Subtotal
+			line.remainingTax += details[len(details)-1].Tax
+		}
+	}
+
+	reversal.Details = details
+	reversal.Total, reversal.Tax = 0, 0
+	for _, d := range details {
+		reversal.Total += d.Amount()
+		reversal.Tax += d.Tax
+	}
+	// The whole refund goes out through the single refund tender, except
+	// for points and then credit paid on the sale, which go back first
+	fullyReversed := reversal.Type == models.TransactionTypeVoid
+	if !fullyReversed {
+		fullyReversed = true
+		for _, line := range lines {
+			if line.remainingQty > 0 {
+				fullyReversed = false
+			}
+		}
+	}
+	pointsRefund, creditRefund := 0, 0
+	if original.CustomerID != nil {
+		reversal.CustomerID = original.CustomerID
+		if pointsRefund, err = reversePoints(tx, reversal, &original, fullyReversed); err != nil {
+			return err
+		}
+		if creditRefund, err = reverseCredit(tx, *reversal.ReferenceID, -reversal.Total-pointsRefund); err != nil {
+			return err
+		}
+	}
+	for i := range reversal.Payments {
+		reversal.Payments[i].Amount = reversal.Total + pointsRefund + creditRefund
+	}
+	if pointsRefund > 0 || creditRefund > 0 {
+		if len(reversal.Payments) > 0 && reversal.Payments[0].Amount == 0 {
+			reversal.Payments = nil
+		}
+		var account []models.Payment
+		if pointsRefund > 0 {
+			account = append(account, models.Payment{Method: models.PaymentPoints, Amount: -pointsRefund})
+		}
+		if creditRefund > 0 {
+			account = append(account, models.Payment{Method: models.PaymentCredit, Amount: -creditRefund})
+		}
+		reversal.Payments = append(account, reversal.Payments...)
+	}
+	if shiftClosed && reversal.ShiftID == nil {
+		for _, p := range reversal.Payments {
+			if p.Method == models.PaymentCash && p.Amount != 0 {
+				return models.NewValidationError("the shift of transaction %d is closed, open a shift to refund in cash", *reversal.ReferenceID)
+			}
+		}
+	}
+	if reversal.Date.IsZero() {
+		reversal.Date = time.Now()
+	}
+	if err := insertTransaction(tx, reversal); err != nil {
+		return err
+	}
+
+	// Restock in ascending product order, same as checkout
+	restock := make(map[int]models.Quantity)
+	for _, d := range details {
+		if d.ProductID != 0 {
+			restock[d.ProductID] -= d.BaseQuantity()
+		}
+	}
+	productIDs := make([]int, 0, len(restock))
+	for id := range restock {
+		productIDs = append(productIDs, id)
+	}
+	sort.Ints(productIDs)
+	for _, id := range productIDs {
+		err := moveStock(tx, &models.StockMovement{
+			ProductID:     id,
+			Type:          reversal.Type,
+			Quantity:      restock[id],
+			ReferenceType: models.ReferenceTransaction,
+			ReferenceID:   &reversal.ID,
+			UserID:        reversal.CashierID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if reversal.CustomerID != nil {
+		entries := []models.LoyaltyPointEntry{
+			{Type: reversal.Type, Points: -reversal.PointsRedeemed, Note: "points paid refunded"},
+			{Type: reversal.Type, Points: reversal.PointsEarned, Note: "earned points taken back"},
+		}
+		if err := postPoints(tx, reversal, entries); err != nil {
+			return err
+		}
+		if err := postCredit(tx, reversal, reversal.Type, -creditRefund); err != nil {
 			return err
 		}
 	}
@@ -53,62 +638,188 @@ func (r *TransactionRepository) CreateTransaction(transaction *models.Transactio
 	return tx.Commit()
 }
 
-func (r *TransactionRepository) GetDailyReport(date time.Time) (map[string]interface{}, error) {
-	// Format date for query, assuming DATETIME or DATE column
-	startDate := date.Format("2006-01-02") + " 00:00:00"
-	endDate := date.Format("2006-01-02") + " 23:59:59"
+// reversePoints mengisi PointsEarned dan PointsRedeemed (negatif) pada dokumen
+// void/retur dari poin penjualan yang belum dikembalikan, lalu mengembalikan
+// nilai rupiah poin yang dikembalikan sebagai tender points.
+func reversePoints(tx *sql.Tx, reversal, original *models.Transaction, fullyReversed bool) (int, error) {
+	var earned, redeemed, pointsPaid int
+	err := tx.QueryRow(`
+		SELECT $2::int + COALESCE(SUM(points_earned), 0), $3::int + COALESCE(SUM(points_redeemed), 0),
+			(SELECT COALESCE(SUM(amount), 0) FROM transaction_payments WHERE transaction_id = $1 AND method = $4)
+		FROM transactions WHERE reference_id = $1`,
+		*reversal.ReferenceID, original.PointsEarned, original.PointsRedeemed, models.PaymentPoints).Scan(&earned, &redeemed, &pointsPaid)
+	if err != nil {
+		return 0, err
+	}
+
+	refund := -reversal.Total
+	takeBack := earned
+	if !fullyReversed && original.Total > 0 {
+		takeBack = min(earned, original.PointsEarned*refund/original.Total)
+	}
+	returned, value := 0, 0
+	if original.PointsRedeemed > 0 && pointsPaid > 0 {
+		value = pointsPaid / original.PointsRedeemed
+		returned = min(redeemed, refund/value)
+	}
+	reversal.PointsEarned, reversal.PointsRedeemed = -takeBack, -returned
+	return returned * value, nil
+}
 
-	var totalRevenue int
-	var totalTransactions int
+// reversalShift mengisi shift dokumen void/retur. Selama shift penjualan
+// masih open, dokumen masuk ke sana. Bila sudah ditutup, void ditolak karena
+// uangnya sudah dihitung; retur, juga dokumen untuk penjualan tanpa shift,
+// masuk ke shift yang sedang dibuka oleh CashierID (nil bila tidak ada).
+func reversalShift(tx *sql.Tx, reversal, original *models.Transaction) error {
+	if original.ShiftID != nil {
+		var status string
+		err := tx.QueryRow("SELECT status FROM shifts WHERE id = $1 FOR SHARE", *original.ShiftID).Scan(&status)
+		if err != nil {
+			return err
+		}
+		if status == models.ShiftOpen {
+			reversal.ShiftID = original.ShiftID
+			return nil
+		}
+		if reversal.Type == models.TransactionTypeVoid {
+			return models.NewValidationError("shift %d of transaction %d is already closed, use a return instead", *original.ShiftID, *reversal.ReferenceID)
+		}
+	}
+	shiftID, err := openShiftID(tx, reversal.CashierID)
+	if err != nil {
+		return err
+	}
+	reversal.ShiftID = shiftID
+	return nil
+}
 
-	// Query Total Revenue and Total Transactions
+// reverseCredit mengembalikan bagian refund (maksimal refund) yang mengurangi
+// kasbon: nilai tender credit penjualan yang belum dikembalikan oleh void/retur
+// sebelumnya.
+func reverseCredit(tx *sql.Tx, saleID, refund int) (int, error) {
+	var charged int
+	err := tx.QueryRow(`
+		SELECT COALESCE(SUM(p.amount), 0)
+		FROM transaction_payments p
+		JOIN transactions t ON t.id = p.transaction_id
+		WHERE (t.id = $1 OR t.reference_id = $1) AND p.method = $2`, saleID, models.PaymentCredit).Scan(&charged)
+	if err != nil {
+		return 0, err
+	}
+	return max(min(charged, refund), 0), nil
+}
+
+// soldLine adalah baris penjualan beserta sisa qty (dalam satuan baris), nilai
+// dan pajak yang belum diretur.
+type soldLine struct {
+	detail            models.TransactionDetail
+	remainingQty      models.Quantity
+	remainingSubtotal int
+	remainingTax      int
+}
+
+// reverse membuat baris negatif untuk qty yang dikembalikan. Nilai dan
+// pajaknya proporsional terhadap baris asli dengan tarif yang sama; retur
+// terakhir mengambil seluruh sisa supaya pembulatan tidak meninggalkan selisih.
+func (l *soldLine) reverse(qty models.Quantity) models.TransactionDetail {
+	// qty tidak melebihi qty baris, jadi bagiannya selalu muat.
+	refund, _ := models.Share(l.detail.Subtotal, qty, l.detail.Quantity)
+	tax, _ := models.Share(l.detail.Tax, qty, l.detail.Quantity)
+	if qty == l.remainingQty {
+		refund = l.remainingSubtotal
+		tax = l.remainingTax
+	}
+	detailID := l.detail.ID
+	return models.TransactionDetail{
+		ReferenceDetailID: &detailID,
+		ProductID:         l.detail.ProductID,
+		ParentProductID:   l.detail.ParentProductID,
+		ProductName:       l.detail.ProductName,
+		UnitPrice:         l.detail.UnitPrice,
+		Quantity:          -qty,
+		Unit:              l.detail.Unit,
+		UnitFactor:        l.detail.UnitFactor,
+		Subtotal:          -refund,
+		TaxRateID:         l.detail.TaxRateID,
+		TaxName:           l.detail.TaxName,
+		TaxRate:           l.detail.TaxRate,
+		TaxInclusive:      l.detail.TaxInclusive,
+		Tax:               -tax,
+		UnitCost:          l.detail.UnitCost,
+		Modifiers:         l.detail.Modifiers,
+	}
+}
+
+func remainingLines(tx *sql.Tx, transactionID int) ([]soldLine, error) {
 	query := `
-		SELECT 
-			COALESCE(SUM(total_amount), 0), 
-			COUNT(id) 
-		FROM transactions 
-		WHERE date BETWEEN $1 AND $2`
-	
-	err := r.db.QueryRow(query, startDate, endDate).Scan(&totalRevenue, &totalTransactions)
-	if err != nil {
-		return nil, err
-	}
-
-	// Query Best Selling Product
-	// This requires joining with products table to get name, or just returning ID
-	// Let's assume we want product name.
-	// Adjust table names if necessary (transaction_details vs transaction_detail?)
-	// Based on common naming conventions: transaction_details
-	bestSellingQuery := `
-		SELECT 
-			p.name, 
-			SUM(td.quantity) as total_qty 
-		FROM transaction_details td
-		JOIN products p ON td.product_id = p.id
-		JOIN transactions t ON td.transaction_id = t.id
-		WHERE t.date BETWEEN $1 AND $2
-		GROUP BY p.id, p.name
-		ORDER BY total_qty DESC
-		LIMIT 1
-	`
-	
-	var bestProductName string
-	var bestProductQty int
-
-	err = r.db.QueryRow(bestSellingQuery, startDate, endDate).Scan(&bestProductName, &bestProductQty)
-	if err != nil && err != sql.ErrNoRows {
-		return nil, err
-	}
-	
-	// Construct response
-	result := map[string]interface{}{
-		"total_revenue":     totalRevenue,
-		"total_transaksi":   totalTransactions,
-		"produk_terlaris": map[string]interface{}{
-			"nama":        bestProductName,
-			"qty_terjual": bestProductQty,
-		},
-	}
-
-	return result, nil
+		SELECT d.id, COALESCE(d.product_id, 0), d.parent_product_id, d.product_name, d.unit_price, d.quantity, d.unit, d.unit_factor, d.subtotal,
+			d.tax_rate_id, COALESCE(d.tax_name, ''), d.tax_rate::float8, d.tax_inclusive, d.tax, d.unit_cost,
+			d.quantity + COALESCE(SUM(r.quantity), 0),
+			d.subtotal + COALESCE(SUM(r.subtotal), 0),
+			d.tax + COALESCE(SUM(r.tax), 0)
+		FROM transaction_details d
+		LEFT JOIN transaction_details r ON r.reference_detail_id = d.id
+		WHERE d.transaction_id = $1
+		GROUP BY d.id
+		ORDER BY d.id`
+
+	rows, err := tx.Query(query, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []soldLine
+	for rows.Next() {
+		var l soldLine
+		l.detail.TransactionID = transactionID
+		err := rows.Scan(&l.detail.ID, &l.detail.ProductID, &l.detail.ParentProductID, &l.detail.ProductName, &l.detail.UnitPrice,
+			&l.detail.Quantity, &l.detail.Unit, &l.detail.UnitFactor, &l.detail.Subtotal, &l.detail.TaxRateID, &l.detail.TaxName, &l.detail.TaxRate,
+			&l.detail.TaxInclusive, &l.detail.Tax, &l.detail.UnitCost, &l.remainingQty, &l.remainingSubtotal, &l.remainingTax)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	detailIDs := make([]int, len(lines))
+	for i, l := range lines {
+		detailIDs[i] = l.detail.ID
+	}
+	modifiers, err := detailModifiers(tx, detailIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lines {
+		lines[i].detail.Modifiers = modifiers[lines[i].detail.ID]
+	}
+	return lines, nil
+}
+
+// detailModifiers mengembalikan modifier yang tersimpan per baris transaksi.
+func detailModifiers(q queryer, detailIDs []int) (map[int][]models.DetailModifier, error) {
+	rows, err := q.Query(`
+		SELECT detail_id, modifier_id, group_name, name, price
+		FROM transaction_detail_modifiers
+		WHERE detail_id = ANY($1)
+		ORDER BY id`, detailIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	modifiers := make(map[int][]models.DetailModifier)
+	for rows.Next() {
+		var detailID int
+		var m models.DetailModifier
+		if err := rows.Scan(&detailID, &m.ModifierID, &m.GroupName, &m.Name, &m.Price); err != nil {
+			return nil, err
+		}
+		modifiers[detailID] = append(modifiers[detailID], m)
+	}
+	return modifiers, rows.Err()
 }
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"database/sql"
+	"go-kasir-api/models"
+)
+
+type UserRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+const userSelect = "SELECT id, username, name, role, active, created_at, password_hash FROM users"
+
+func scanUser(row rowScanner) (*models.User, error) {
+	var u models.User
+	if err := row.Scan(&u.ID, &u.Username, &u.Name, &u.Role, &u.Active, &u.CreatedAt, &u.PasswordHash); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (repo *UserRepository) GetAll() ([]models.User, error) {
+	rows, err := repo.db.Query(userSelect + " ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]models.User, 0)
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+
+	return users, rows.Err()
+}
+
+func (repo *UserRepository) GetByID(id int) (*models.User, error) {
+	u, err := scanUser(repo.db.QueryRow(userSelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrUserNotFound
+	}
+	return u, err
+}
+
+func (repo *UserRepository) GetByUsername(username string) (*models.User, error) {
+	u, err := scanUser(repo.db.QueryRow(userSelect+" WHERE username = $1", username))
+	if err == sql.ErrNoRows {
+		return nil, models.ErrUserNotFound
+	}
+	return u, err
+}
+
+func (repo *UserRepository) Create(user *models.User) error {
+	query := `
+		INSERT INTO users (username, name, password_hash, role, active)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+	err := repo.db.QueryRow(query, user.Username, user.Name, user.PasswordHash, user.Role, user.Active).Scan(&user.ID, &user.CreatedAt)
+	if isUniqueViolation(err) {
+		return models.ErrUsernameTaken
+	}
+	return err
+}
+
+func (repo *UserRepository) Update(user *models.User) error {
+	query := "UPDATE users SET username = $1, name = $2, password_hash = $3, role = $4, active = $5 WHERE id = $6"
+	result, err := repo.db.Exec(query, user.Username, user.Name, user.PasswordHash, user.Role, user.Active, user.ID)
+	if isUniqueViolation(err) {
+		return models.ErrUsernameTaken
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrUserNotFound
+	}
+	return nil
+}
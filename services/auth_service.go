@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"time"
+)
+
+type AuthService struct {
+	userRepo *repositories.UserRepository
+	tokens   *TokenService
+}
+
+func NewAuthService(userRepo *repositories.UserRepository, tokens *TokenService) *AuthService {
+	return &AuthService{userRepo: userRepo, tokens: tokens}
+}
+
+// Login memeriksa username dan password lalu menerbitkan token. Username yang
+// tidak ada dan user nonaktif tetap melewati PBKDF2 supaya waktu responsnya
+// tidak membocorkan username mana yang terdaftar.
+func (s *AuthService) Login(req models.LoginRequest) (*models.LoginResponse, error) {
+	user, err := s.userRepo.GetByUsername(req.Username)
+	if errors.Is(err, models.ErrUserNotFound) {
+		CheckPassword(dummyPasswordHash, req.Password)
+		return nil, models.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !CheckPassword(user.PasswordHash, req.Password) || !user.Active {
+		return nil, models.ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := s.tokens.Sign(user, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LoginResponse{Token: token, ExpiresAt: expiresAt, User: *user}, nil
+}
+
+// Authenticate memverifikasi token dan memuat ulang user dari database, jadi
+// user yang dinonaktifkan atau diganti role-nya langsung berlaku.
+func (s *AuthService) Authenticate(token string) (*models.User, error) {
+	userID, err := s.tokens.Verify(token, time.Now())
+	if err != nil {
+		return nil, models.ErrUnauthorized
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if errors.Is(err, models.ErrUserNotFound) {
+		return nil, models.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !user.Active {
+		return nil, models.ErrUnauthorized
+	}
+	return user, nil
+}
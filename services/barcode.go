@@ -0,0 +1,130 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Label timbangan (restricted circulation EAN-13): PP IIIII VVVVV C, dengan PP
+// prefix 20-29, IIIII kode PLU barang, VVVVV nilai dan C check digit. Prefix
+// 20-24 berisi berat dalam gram, 25-29 berisi harga.
+const (
+	labelPrefixMin       = 20
+	labelPrefixMax       = 29
+	labelWeightPrefixMax = 24
+)
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isGTINLength melaporkan apakah code berbentuk EAN-8, UPC-A atau EAN-13.
+func isGTINLength(code string) bool {
+	return isDigits(code) && (len(code) == 8 || len(code) == 12 || len(code) == 13)
+}
+
+// validGTIN memeriksa check digit (modulo 10, bobot 3 dan 1 dari kanan).
+func validGTIN(code string) bool {
+	if !isGTINLength(code) {
+		return false
+	}
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		d := int(code[i] - '0')
+		if (len(code)-2-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10-sum%10)%10 == int(code[len(code)-1]-'0')
+}
+
+// normalizeBarcode merapikan dan memvalidasi satu barcode produk.
+func normalizeBarcode(b *models.ProductBarcode) error {
+	b.Code = strings.TrimSpace(b.Code)
+	b.Type = strings.ToLower(strings.TrimSpace(b.Type))
+	if b.Code == "" {
+		return models.NewValidationError("barcode code is required")
+	}
+
+	if b.Type == "" {
+		b.Type = models.BarcodeInternal
+		if validGTIN(b.Code) {
+			b.Type = map[int]string{8: models.BarcodeEAN8, 12: models.BarcodeUPCA, 13: models.BarcodeEAN13}[len(b.Code)]
+		}
+	}
+
+	length := map[string]int{models.BarcodeEAN13: 13, models.BarcodeUPCA: 12, models.BarcodeEAN8: 8}
+	switch b.Type {
+	case models.BarcodeEAN13, models.BarcodeUPCA, models.BarcodeEAN8:
+		if !isDigits(b.Code) || len(b.Code) != length[b.Type] {
+			return models.NewValidationError("%s barcode %q must be %d digits", b.Type, b.Code, length[b.Type])
+		}
+		if !validGTIN(b.Code) {
+			return models.NewValidationError("barcode %q has an invalid check digit", b.Code)
+		}
+	case models.BarcodePLU:
+		if !isDigits(b.Code) || len(b.Code) != 5 {
+			return models.NewValidationError("plu %q must be 5 digits", b.Code)
+		}
+	case models.BarcodeInternal:
+		if len(b.Code) > 32 || strings.IndexFunc(b.Code, unicode.IsSpace) >= 0 {
+			return models.NewValidationError("internal barcode %q must be at most 32 characters without spaces", b.Code)
+		}
+	default:
+		return models.NewValidationError("barcode type must be one of ean13, upca, ean8, plu, internal")
+	}
+	return nil
+}
+
+// normalizeBarcodes memvalidasi semua barcode produk dan menolak kode ganda.
+func normalizeBarcodes(barcodes []models.ProductBarcode) error {
+	seen := make(map[string]bool, len(barcodes))
+	for i := range barcodes {
+		if err := normalizeBarcode(&barcodes[i]); err != nil {
+			return err
+		}
+		if seen[barcodes[i].Code] {
+			return models.NewValidationError("barcode %q appears more than once", barcodes[i].Code)
+		}
+		seen[barcodes[i].Code] = true
+	}
+	return nil
+}
+
+// scaleLabel adalah isi label timbangan yang sudah diurai.
+type scaleLabel struct {
+	plu    string
+	weight *int
+	price  *int
+}
+
+// decodeScaleLabel mengurai label timbangan; ok false bila code bukan label.
+func decodeScaleLabel(code string) (label scaleLabel, ok bool) {
+	if len(code) != 13 || !validGTIN(code) {
+		return label, false
+	}
+	prefix, _ := strconv.Atoi(code[:2])
+	if prefix < labelPrefixMin || prefix > labelPrefixMax {
+		return label, false
+	}
+
+	value, _ := strconv.Atoi(code[7:12])
+	label.plu = code[2:7]
+	if prefix <= labelWeightPrefixMax {
+		label.weight = &value
+	} else {
+		label.price = &value
+	}
+	return label, true
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"testing"
+)
+
+func TestValidGTIN(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{"4006381333931", true},
+		{"8991234567891", true},
+		{"0000000000000", true},
+		{"036000291452", true},
+		{"96385074", true},
+		{"4006381333932", false},
+		{"8991234567893", false},
+		{"036000291453", false},
+		{"96385075", false},
+		{"400638133393", false},
+		{"40063813339310", false},
+		{"4006381333a31", false},
+		{"-006381333931", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := validGTIN(tt.code); got != tt.want {
+			t.Errorf("validGTIN(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeScaleLabel(t *testing.T) {
+	tests := []struct {
+		name   string
+		code   string
+		ok     bool
+		plu    string
+		weight int
+		price  int
+	}{
+		{name: "weight", code: "2000123012506", ok: true, plu: "00123", weight: 1250},
+		{name: "last weight prefix", code: "2400123005001", ok: true, plu: "00123", weight: 500},
+		{name: "price", code: "2500123150005", ok: true, plu: "00123", price: 15000},
+		{name: "last price prefix", code: "2900123999992", ok: true, plu: "00123", price: 99999},
+		{name: "bad check digit", code: "2000123012507", ok: false},
+		{name: "prefix below range", code: "1900123012500", ok: false},
+		{name: "prefix above range", code: "3000123012505", ok: false},
+		{name: "regular product", code: "8991234567891", ok: false},
+		{name: "too short", code: "200012301250", ok: false},
+		{name: "not digits", code: "20001230125a6", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, ok := decodeScaleLabel(tt.code)
+			if ok != tt.ok {
+				t.Fatalf("decodeScaleLabel(%q) ok = %v, want %v", tt.code, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if label.plu != tt.plu {
+				t.Errorf("plu = %q, want %q", label.plu, tt.plu)
+			}
+			if tt.weight != 0 && (label.weight == nil || *label.weight != tt.weight || label.price != nil) {
+				t.Errorf("weight = %v, price = %v; want weight %d", label.weight, label.price, tt.weight)
+			}
+			if tt.price != 0 && (label.price == nil || *label.price != tt.price || label.weight != nil) {
+				t.Errorf("price = %v, weight = %v; want price %d", label.price, label.weight, tt.price)
+			}
+		})
+	}
+}
+
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		code, typ string
+		wantType  string
+		wantErr   bool
+	}{
+		{code: " 8991234567891 ", wantType: models.BarcodeEAN13},
+		{code: "036000291452", wantType: models.BarcodeUPCA},
+		{code: "96385074", wantType: models.BarcodeEAN8},
+		{code: "8991234567893", wantType: models.BarcodeInternal},
+		{code: "00123", typ: "PLU", wantType: models.BarcodePLU},
+		{code: "KOPI-250", wantType: models.BarcodeInternal},
+		{code: "8991234567893", typ: "ean13", wantErr: true},
+		{code: "96385074", typ: "ean13", wantErr: true},
+		{code: "0123", typ: "plu", wantErr: true},
+		{code: "KOPI 250", wantErr: true},
+		{code: "", wantErr: true},
+		{code: "123", typ: "qr", wantErr: true},
+	}
+	for _, tt := range tests {
+		b := models.ProductBarcode{Code: tt.code, Type: tt.typ}
+		err := normalizeBarcode(&b)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("normalizeBarcode(%q, %q) = %q, want error", tt.code, tt.typ, b.Type)
+			}
+			continue
+		}
+		if err != nil || b.Type != tt.wantType {
+			t.Errorf("normalizeBarcode(%q, %q) = %q, %v; want %q", tt.code, tt.typ, b.Type, err, tt.wantType)
+		}
+	}
+}
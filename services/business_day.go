@@ -0,0 +1,51 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// BusinessDay memetakan tanggal bisnis toko ke rentang waktu absolut. Dengan
+// cutoff 03:00, penjualan jam 01:30 dihitung ke hari bisnis sebelumnya.
+// Tanggal bisnis direpresentasikan sebagai time.Time tengah malam UTC.
+type BusinessDay struct {
+	Location *time.Location
+	Cutoff   time.Duration
+}
+
+// NewBusinessDay membuat BusinessDay dari nama zona IANA (mis. "Asia/Jakarta")
+// dan jam cutoff "HH:MM".
+func NewBusinessDay(timezone string, cutoff string) (*BusinessDay, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid store timezone %q: %w", timezone, err)
+	}
+
+	var d time.Duration
+	if cutoff != "" {
+		t, err := time.Parse("15:04", cutoff)
+		if err != nil {
+			return nil, fmt.Errorf("invalid business day cutoff %q, expected HH:MM", cutoff)
+		}
+		d = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+
+	return &BusinessDay{Location: loc, Cutoff: d}, nil
+}
+
+// Start mengembalikan waktu mulai hari bisnis untuk tanggal date.
+func (b *BusinessDay) Start(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, b.Location).Add(b.Cutoff)
+}
+
+// DateOf mengembalikan tanggal bisnis yang memuat waktu t.
+func (b *BusinessDay) DateOf(t time.Time) time.Time {
+	local := t.In(b.Location).Add(-b.Cutoff)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Range mengembalikan rentang half-open [start, end) untuk tanggal bisnis
+// from sampai to (inklusif).
+func (b *BusinessDay) Range(from, to time.Time) (time.Time, time.Time) {
+	return b.Start(from), b.Start(to.AddDate(0, 0, 1))
+}
@@ -6,11 +6,13 @@ import (
 )
 
 type CategoryService struct {
-	repo *repositories.CategoryRepository
+	repo        *repositories.CategoryRepository
+	productRepo *repositories.ProductRepository
+	taxRateRepo *repositories.TaxRateRepository
 }
 
-func NewCategoryService(repo *repositories.CategoryRepository) *CategoryService {
-	return &CategoryService{repo: repo}
+func NewCategoryService(repo *repositories.CategoryRepository, productRepo *repositories.ProductRepository, taxRateRepo *repositories.TaxRateRepository) *CategoryService {
+	return &CategoryService{repo: repo, productRepo: productRepo, taxRateRepo: taxRateRepo}
 }
 
 func (s *CategoryService) GetAll() ([]models.Category, error) {
@@ -18,6 +20,9 @@ func (s *CategoryService) GetAll() ([]models.Category, error) {
 }
 
 func (s *CategoryService) Create(category *models.Category) error {
+	if err := checkTaxRate(s.taxRateRepo, category.TaxRateID); err != nil {
+		return err
+	}
 	return s.repo.Create(category)
 }
 
@@ -26,9 +31,27 @@ func (s *CategoryService) GetByID(id int) (*models.Category, error) {
 }
 
 func (s *CategoryService) Update(category *models.Category) error {
+	if err := checkTaxRate(s.taxRateRepo, category.TaxRateID); err != nil {
+		return err
+	}
 	return s.repo.Update(category)
 }
 
-func (s *CategoryService) Delete(id int) error {
-	return s.repo.Delete(id)
+// Delete menolak kategori yang masih punya produk (ErrCategoryInUse), kecuali
+// reassignTo diisi: produknya dipindah ke kategori itu lebih dulu.
+func (s *CategoryService) Delete(id int, reassignTo *int) error {
+	if reassignTo == nil {
+		return s.repo.Delete(id)
+	}
+	if *reassignTo == id {
+		return models.NewValidationError("cannot reassign products to the category being deleted")
+	}
+	return s.repo.ReassignAndDelete(id, *reassignTo)
+}
+
+func (s *CategoryService) GetProducts(id int) ([]models.Product, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.productRepo.GetByCategoryID(id)
 }
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+	"time"
+)
+
+type CreditService struct {
+	repo         *repositories.CreditRepository
+	customerRepo *repositories.CustomerRepository
+	day          *BusinessDay
+}
+
+func NewCreditService(repo *repositories.CreditRepository, customerRepo *repositories.CustomerRepository, day *BusinessDay) *CreditService {
+	return &CreditService{repo: repo, customerRepo: customerRepo, day: day}
+}
+
+// Pay mencatat pembayaran kasbon (boleh sebagian) atas nama user. Pembayaran
+// tidak boleh melebihi utang pelanggan.
+func (s *CreditService) Pay(req models.CreditPaymentRequest, user *models.User) (*models.CreditEntry, error) {
+	req.Reference = strings.TrimSpace(req.Reference)
+	req.Note = strings.TrimSpace(req.Note)
+	if req.CustomerID <= 0 {
+		return nil, models.NewValidationError("customer_id is required")
+	}
+	if req.Amount <= 0 {
+		return nil, models.NewValidationError("amount must be greater than 0")
+	}
+	if req.Method == "" {
+		req.Method = models.PaymentCash
+	}
+	if !isValidPaymentMethod(req.Method) {
+		return nil, models.NewValidationError("method must be one of cash, card, ewallet, qris")
+	}
+
+	entry := &models.CreditEntry{
+		CustomerID: req.CustomerID,
+		Type:       models.CreditPayment,
+		Amount:     -req.Amount,
+		Method:     req.Method,
+		Reference:  req.Reference,
+		Note:       req.Note,
+		UserID:     userID(user),
+	}
+	if err := s.repo.Pay(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Statement menyusun rekening koran kasbon pelanggan untuk tanggal bisnis from
+// sampai to (inklusif). Tanpa from, periode dimulai dari entri pertama; tanpa
+// to, sampai hari bisnis now. Saldo awal dan akhir dihitung mundur dari saldo
+// saat ini, jadi selalu cocok dengan ledger.
+func (s *CreditService) Statement(customerID int, from, to *time.Time, now time.Time) (*models.CreditStatement, error) {
+	customer, err := s.customerRepo.GetByID(customerID)
+	if err != nil {
+		return nil, err
+	}
+	today := s.day.DateOf(now)
+	if to == nil {
+		to = &today
+	}
+	var start time.Time
+	_, end := s.day.Range(*to, *to)
+	if from != nil {
+		if from.After(*to) {
+			return nil, models.NewValidationError("start_date must not be after end_date")
+		}
+		start = s.day.Start(*from)
+	}
+
+	entries, after, err := s.repo.GetEntries(customerID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	charges, err := s.repo.GetOpenCharges(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &models.CreditStatement{
+		CustomerID:     customer.ID,
+		Name:           customer.Name,
+		CreditLimit:    customer.CreditLimit,
+		Balance:        customer.CreditBalance,
+		Available:      max(customer.CreditLimit-customer.CreditBalance, 0),
+		PeriodEnd:      end,
+		ClosingBalance: customer.CreditBalance - after,
+		Entries:        entries,
+		OpenCharges:    charges,
+		Aging:          ageCharges(charges, today, s.day),
+	}
+	if from != nil {
+		statement.PeriodStart = &start
+	}
+	for _, e := range entries {
+		if e.Amount > 0 {
+			statement.Charges += e.Amount
+		} else {
+			statement.Credits -= e.Amount
+		}
+	}
+	statement.OpeningBalance = statement.ClosingBalance - statement.Charges + statement.Credits
+	return statement, nil
+}
+
+// creditPayment menjumlahkan tender credit dan memeriksanya terhadap
+// pelanggan dan sisa limitnya. Limit dicek lagi saat dibukukan.
+func creditPayment(payments []models.Payment, customer *models.Customer) (int, error) {
+	amount := 0
+	for _, p := range payments {
+		if p.Method == models.PaymentCredit {
+			amount += p.Amount
+		}
+	}
+	if amount == 0 {
+		return 0, nil
+	}
+	if customer == nil {
+		return 0, models.NewValidationError("paying on credit needs a customer_id")
+	}
+	if available := customer.CreditLimit - customer.CreditBalance; amount > available {
+		return 0, models.NewValidationError("credit of %d exceeds the limit of customer %d, %d is available",
+			amount, customer.ID, max(available, 0))
+	}
+	return amount, nil
+}
+
+// ageCharges mengisi umur (dalam hari bisnis sampai today) setiap charge
+// terbuka dan menjumlahkannya ke bucket 0-30, 31-60 dan lebih dari 60 hari.
+func ageCharges(charges []models.OpenCharge, today time.Time, day *BusinessDay) models.CreditAging {
+	var aging models.CreditAging
+	for i := range charges {
+		c := &charges[i]
+		c.AgeDays = int(today.Sub(day.DateOf(c.ChargedAt)).Hours() / 24)
+		switch {
+		case c.AgeDays <= 30:
+			aging.Days0To30 += c.Amount
+		case c.AgeDays <= 60:
+			aging.Days31To60 += c.Amount
+		default:
+			aging.Over60 += c.Amount
+		}
+	}
+	return aging
+}
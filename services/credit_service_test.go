@@ -0,0 +1,109 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"testing"
+	"time"
+)
+
+func TestCreditPayment(t *testing.T) {
+	credit := func(amount int) models.Payment {
+		return models.Payment{Method: models.PaymentCredit, Amount: amount}
+	}
+	cash := models.Payment{Method: models.PaymentCash, Amount: 5000}
+
+	tests := []struct {
+		name     string
+		payments []models.Payment
+		customer *models.Customer
+		want     int
+		wantErr  bool
+	}{
+		{name: "no credit tender", payments: []models.Payment{cash}, want: 0},
+		{name: "below limit", payments: []models.Payment{credit(40000), cash}, customer: &models.Customer{ID: 1, CreditLimit: 100000, CreditBalance: 50000}, want: 40000},
+		{name: "exactly the available credit", payments: []models.Payment{credit(50000)}, customer: &models.Customer{ID: 1, CreditLimit: 100000, CreditBalance: 50000}, want: 50000},
+		{name: "one rupiah over the limit", payments: []models.Payment{credit(50001)}, customer: &models.Customer{ID: 1, CreditLimit: 100000, CreditBalance: 50000}, wantErr: true},
+		{name: "no limit", payments: []models.Payment{credit(1)}, customer: &models.Customer{ID: 1}, wantErr: true},
+		{name: "balance already over limit", payments: []models.Payment{credit(1000)}, customer: &models.Customer{ID: 1, CreditLimit: 10000, CreditBalance: 12000}, wantErr: true},
+		{name: "balance paid in advance", payments: []models.Payment{credit(15000)}, customer: &models.Customer{ID: 1, CreditLimit: 10000, CreditBalance: -5000}, want: 15000},
+		{name: "without customer", payments: []models.Payment{credit(1000)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := creditPayment(tt.payments, tt.customer)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("creditPayment = %d, want error", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("creditPayment = %d, %v; want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgeCharges(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*60*60)
+	day := &BusinessDay{Location: wib, Cutoff: 3 * time.Hour}
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	// charged membuat charge terbuka sebesar amount, days hari kalender
+	// sebelum today pada jam lokal hour.
+	charged := func(days, hour, amount int) models.OpenCharge {
+		return models.OpenCharge{ChargedAt: time.Date(2026, 10, 18-days, hour, 0, 0, 0, wib), Amount: amount}
+	}
+
+	tests := []struct {
+		name     string
+		charges  []models.OpenCharge
+		wantAges []int
+		want     models.CreditAging
+	}{
+		{
+			name:     "nothing open",
+			charges:  []models.OpenCharge{},
+			wantAges: []int{},
+		},
+		{
+			name:     "bucket boundaries",
+			charges:  []models.OpenCharge{charged(0, 12, 1), charged(30, 12, 10), charged(31, 12, 100), charged(60, 12, 1000), charged(61, 12, 10000)},
+			wantAges: []int{0, 30, 31, 60, 61},
+			want:     models.CreditAging{Days0To30: 11, Days31To60: 1100, Over60: 10000},
+		},
+		{
+			name:     "sale before cutoff belongs to the previous business day",
+			charges:  []models.OpenCharge{charged(30, 1, 500), charged(30, 3, 700)},
+			wantAges: []int{31, 30},
+			want:     models.CreditAging{Days0To30: 700, Days31To60: 500},
+		},
+		{
+			// 80.000 dibayar atas charge 50.000 (70 hari), 50.000 (45 hari) dan
+			// 20.000 (10 hari): yang tertua lunas, sisa 20.000 dari charge
+			// kedua tetap di bucket 31-60.
+			name:     "partial repayment leaves the rest of a charge in its bucket",
+			charges:  []models.OpenCharge{charged(45, 12, 20000), charged(10, 12, 20000)},
+			wantAges: []int{45, 10},
+			want:     models.CreditAging{Days0To30: 20000, Days31To60: 20000},
+		},
+		{
+			name:     "several charges in one bucket",
+			charges:  []models.OpenCharge{charged(90, 12, 15000), charged(75, 12, 5000), charged(5, 12, 2500)},
+			wantAges: []int{90, 75, 5},
+			want:     models.CreditAging{Days0To30: 2500, Over60: 20000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ageCharges(tt.charges, today, day)
+			if got != tt.want {
+				t.Errorf("aging = %+v, want %+v", got, tt.want)
+			}
+			for i, c := range tt.charges {
+				if c.AgeDays != tt.wantAges[i] {
+					t.Errorf("charge %d age = %d, want %d", i, c.AgeDays, tt.wantAges[i])
+				}
+			}
+		})
+	}
+}
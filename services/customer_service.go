@@ -0,0 +1,121 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+)
+
+type CustomerService struct {
+	repo *repositories.CustomerRepository
+}
+
+func NewCustomerService(repo *repositories.CustomerRepository) *CustomerService {
+	return &CustomerService{repo: repo}
+}
+
+// List mengembalikan satu halaman pelanggan, bisa dicari lewat nama, nomor
+// telepon atau nomor kartu member.
+func (s *CustomerService) List(filter models.CustomerFilter) (*models.CustomerList, error) {
+	filter.Page, filter.PageSize = normalizePage(filter.Page, filter.PageSize)
+	filter.Query = strings.TrimSpace(filter.Query)
+	if len(filter.Query) > 100 {
+		return nil, models.NewValidationError("q must be at most 100 characters")
+	}
+
+	customers, total, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CustomerList{Data: customers, Pagination: newPagination(filter.Page, filter.PageSize, total)}, nil
+}
+
+func (s *CustomerService) GetByID(id int) (*models.Customer, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *CustomerService) Create(customer *models.Customer) error {
+	if err := validateCustomer(customer); err != nil {
+		return err
+	}
+	if err := s.repo.Create(customer); err != nil {
+		return err
+	}
+	customer.LifetimeValue, customer.Visits, customer.LastPurchaseAt, customer.Tier = 0, 0, nil, nil
+	return nil
+}
+
+// Update menyimpan data pelanggan dan mengembalikan pelanggan lengkap dengan
+// saldo poin dan nilai belanjanya.
+func (s *CustomerService) Update(customer *models.Customer) (*models.Customer, error) {
+	if err := validateCustomer(customer); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(customer); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(customer.ID)
+}
+
+func (s *CustomerService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+// Points mengembalikan ledger poin pelanggan, terbaru dulu.
+func (s *CustomerService) Points(id, page, pageSize int) (*models.LoyaltyPointList, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	page, pageSize = normalizePage(page, pageSize)
+	entries, total, err := s.repo.ListPoints(id, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &models.LoyaltyPointList{Data: entries, Pagination: newPagination(page, pageSize, total)}, nil
+}
+
+// AdjustPoints mencatat koreksi poin manual atas nama user.
+func (s *CustomerService) AdjustPoints(id int, req models.PointAdjustmentRequest, user *models.User) (*models.LoyaltyPointEntry, error) {
+	req.Note = strings.TrimSpace(req.Note)
+	if req.Points == 0 {
+		return nil, models.NewValidationError("points must not be 0")
+	}
+	if req.Note == "" {
+		return nil, models.NewValidationError("note is required")
+	}
+
+	entry := &models.LoyaltyPointEntry{
+		CustomerID: id,
+		Type:       models.PointsAdjustment,
+		Points:     req.Points,
+		Note:       req.Note,
+		UserID:     userID(user),
+	}
+	if err := s.repo.AdjustPoints(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func validateCustomer(customer *models.Customer) error {
+	customer.Name = strings.TrimSpace(customer.Name)
+	customer.Phone = strings.TrimSpace(customer.Phone)
+	customer.MemberCard = strings.TrimSpace(customer.MemberCard)
+	customer.Email = strings.TrimSpace(customer.Email)
+	if customer.Name == "" {
+		return models.NewValidationError("name is required")
+	}
+	if len(customer.Phone) > 50 {
+		return models.NewValidationError("phone must be at most 50 characters")
+	}
+	if len(customer.MemberCard) > 64 || strings.ContainsAny(customer.MemberCard, " \t") {
+		return models.NewValidationError("member_card must be at most 64 characters without spaces")
+	}
+	if customer.Email != "" && !strings.Contains(customer.Email, "@") {
+		return models.NewValidationError("email %q is not valid", customer.Email)
+	}
+	if customer.CreditLimit < 0 {
+		return models.NewValidationError("credit_limit must not be negative")
+	}
+	return nil
+}
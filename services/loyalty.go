@@ -0,0 +1,65 @@
+package services
+
+import "go-kasir-api/models"
+
+// LoyaltyRules adalah aturan poin toko. EarnAmount adalah belanja per satu
+// poin (0 mematikan perolehan poin) dan PointValue nilai rupiah satu poin saat
+// dipakai membayar (0 mematikan penukaran).
+type LoyaltyRules struct {
+	EarnAmount int
+	PointValue int
+}
+
+// earn menghitung poin untuk belanja amount: satu poin per EarnAmount penuh,
+// ditambah bonus persen tier pelanggan (dibulatkan ke bawah).
+func (r LoyaltyRules) earn(amount int, tier *models.LoyaltyTier) int {
+	if r.EarnAmount <= 0 || amount <= 0 {
+		return 0
+	}
+	points := amount / r.EarnAmount
+	if tier != nil {
+		points = points * (100 + tier.BonusPercent) / 100
+	}
+	return points
+}
+
+// pointsPayment menjumlahkan tender points dan memeriksanya terhadap
+// pelanggan dan aturan penukaran. Saldo poin dicek saat dibukukan.
+func (r LoyaltyRules) pointsPayment(payments []models.Payment, customer *models.Customer) (int, error) {
+	amount := 0
+	for _, p := range payments {
+		if p.Method == models.PaymentPoints {
+			amount += p.Amount
+		}
+	}
+	if amount == 0 {
+		return 0, nil
+	}
+	if customer == nil {
+		return 0, models.NewValidationError("paying with points needs a customer_id")
+	}
+	if r.PointValue <= 0 {
+		return 0, models.NewValidationError("paying with points is turned off")
+	}
+	if amount%r.PointValue != 0 {
+		return 0, models.NewValidationError("points payment must be a multiple of %d, the value of one point", r.PointValue)
+	}
+	if amount/r.PointValue > customer.Points {
+		return 0, models.NewValidationError("customer %d has only %d points", customer.ID, customer.Points)
+	}
+	return amount, nil
+}
+
+// applyLoyalty mengisi poin yang dipakai dan didapat pada penjualan yang
+// total dan pembayarannya sudah final. Bagian yang dibayar dengan poin tidak
+// menghasilkan poin.
+func (r LoyaltyRules) applyLoyalty(transaction *models.Transaction, customer *models.Customer, pointsAmount int) {
+	transaction.PointsEarned, transaction.PointsRedeemed = 0, 0
+	if customer == nil {
+		return
+	}
+	if pointsAmount > 0 {
+		transaction.PointsRedeemed = pointsAmount / r.PointValue
+	}
+	transaction.PointsEarned = r.earn(transaction.Total-pointsAmount, customer.Tier)
+}
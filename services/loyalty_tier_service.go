@@ -0,0 +1,55 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+)
+
+type LoyaltyTierService struct {
+	repo *repositories.LoyaltyTierRepository
+}
+
+func NewLoyaltyTierService(repo *repositories.LoyaltyTierRepository) *LoyaltyTierService {
+	return &LoyaltyTierService{repo: repo}
+}
+
+func (s *LoyaltyTierService) GetAll() ([]models.LoyaltyTier, error) {
+	return s.repo.GetAll()
+}
+
+func (s *LoyaltyTierService) GetByID(id int) (*models.LoyaltyTier, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *LoyaltyTierService) Create(tier *models.LoyaltyTier) error {
+	if err := validateLoyaltyTier(tier); err != nil {
+		return err
+	}
+	return s.repo.Create(tier)
+}
+
+func (s *LoyaltyTierService) Update(tier *models.LoyaltyTier) error {
+	if err := validateLoyaltyTier(tier); err != nil {
+		return err
+	}
+	return s.repo.Update(tier)
+}
+
+func (s *LoyaltyTierService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+func validateLoyaltyTier(tier *models.LoyaltyTier) error {
+	tier.Name = strings.TrimSpace(tier.Name)
+	if tier.Name == "" || len(tier.Name) > 100 {
+		return models.NewValidationError("name is required and must be at most 100 characters")
+	}
+	if tier.MinSpend < 0 {
+		return models.NewValidationError("min_spend must not be negative")
+	}
+	if tier.BonusPercent < 0 || tier.BonusPercent > 1000 {
+		return models.NewValidationError("bonus_percent must be between 0 and 1000")
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+)
+
+type ModifierGroupService struct {
+	repo *repositories.ModifierGroupRepository
+}
+
+func NewModifierGroupService(repo *repositories.ModifierGroupRepository) *ModifierGroupService {
+	return &ModifierGroupService{repo: repo}
+}
+
+func (s *ModifierGroupService) GetAll() ([]models.ModifierGroup, error) {
+	return s.repo.GetAll()
+}
+
+func (s *ModifierGroupService) GetByID(id int) (*models.ModifierGroup, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *ModifierGroupService) Create(group *models.ModifierGroup) error {
+	if err := validateModifierGroup(group); err != nil {
+		return err
+	}
+	return s.repo.Create(group)
+}
+
+func (s *ModifierGroupService) Update(group *models.ModifierGroup) error {
+	if err := validateModifierGroup(group); err != nil {
+		return err
+	}
+	return s.repo.Update(group)
+}
+
+func (s *ModifierGroupService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+// validateModifierGroup memeriksa grup dan modifier-nya. Grup wajib dengan
+// min_select 0 dianggap min_select 1.
+func validateModifierGroup(group *models.ModifierGroup) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" {
+		return models.NewValidationError("name is required")
+	}
+	if len(group.Name) > 100 {
+		return models.NewValidationError("name must be at most 100 characters")
+	}
+	if group.MinSelect < 0 || group.MaxSelect < 0 {
+		return models.NewValidationError("min_select and max_select must not be negative")
+	}
+	if group.Required && group.MinSelect == 0 {
+		group.MinSelect = 1
+	}
+	if group.MaxSelect > 0 && group.MinSelect > group.MaxSelect {
+		return models.NewValidationError("min_select must not be more than max_select")
+	}
+
+	if group.Modifiers == nil {
+		group.Modifiers = make([]models.Modifier, 0)
+	}
+	seen := make(map[string]bool, len(group.Modifiers))
+	active := 0
+	for i := range group.Modifiers {
+		m := &group.Modifiers[i]
+		m.Name = strings.TrimSpace(m.Name)
+		if m.Name == "" {
+			return models.NewValidationError("modifier %d needs a name", i)
+		}
+		if len(m.Name) > 100 {
+			return models.NewValidationError("modifier name must be at most 100 characters")
+		}
+		if m.Price < 0 {
+			return models.NewValidationError("price of %s must not be negative", m.Name)
+		}
+		key := strings.ToLower(m.Name)
+		if seen[key] {
+			return models.NewValidationError("modifier %q is listed twice", m.Name)
+		}
+		seen[key] = true
+		if m.Active {
+			active++
+		}
+	}
+	if group.MinSelect > active {
+		return models.NewValidationError("min_select %d is more than the %d active modifiers", group.MinSelect, active)
+	}
+	return nil
+}
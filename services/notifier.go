@@ -0,0 +1,165 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go-kasir-api/models"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Notifier mengirim peringatan stok menipis ke luar aplikasi. Satu panggilan
+// berisi semua peringatan dari satu checkout.
+type Notifier interface {
+	NotifyLowStock(alerts []models.LowStockAlert) error
+}
+
+// LogNotifier menulis peringatan ke log aplikasi.
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier(logger *log.Logger) *LogNotifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) NotifyLowStock(alerts []models.LowStockAlert) error {
+	for _, a := range alerts {
+		n.logger.Printf("low stock: %s (#%d) stock %s below minimum %s after transaction %d",
+			a.Name, a.ProductID, a.Stock, a.MinStock, a.TransactionID)
+	}
+	return nil
+}
+
+// WebhookNotifier mengirim peringatan sebagai JSON lewat POST ke url.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (n *WebhookNotifier) NotifyLowStock(alerts []models.LowStockAlert) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"event":  "low_stock",
+		"alerts": alerts,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.client.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned %s", n.url, resp.Status)
+	}
+	return nil
+}
+
+// EmailSender mengirim satu email yang sudah berformat RFC 822.
+type EmailSender interface {
+	Send(from string, to []string, msg []byte) error
+}
+
+// EmailNotifier mengirim peringatan sebagai email teks lewat sender.
+type EmailNotifier struct {
+	sender EmailSender
+	from   string
+	to     []string
+}
+
+func NewEmailNotifier(sender EmailSender, from string, to []string) *EmailNotifier {
+	recipients := make([]string, 0, len(to))
+	for _, addr := range to {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			recipients = append(recipients, addr)
+		}
+	}
+	return &EmailNotifier{sender: sender, from: from, to: recipients}
+}
+
+func (n *EmailNotifier) NotifyLowStock(alerts []models.LowStockAlert) error {
+	var body strings.Builder
+	body.WriteString("The following products are below their minimum stock:\r\n\r\n")
+	for _, a := range alerts {
+		fmt.Fprintf(&body, "- %s (#%d): stock %s, minimum %s, reorder %s\r\n",
+			a.Name, a.ProductID, a.Stock, a.MinStock, a.ReorderQuantity)
+	}
+
+	subject := fmt.Sprintf("Low stock: %d product(s)", len(alerts))
+	if len(alerts) == 1 {
+		subject = "Low stock: " + alerts[0].Name
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", encodeHeader(subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(body.String())
+
+	return n.sender.Send(n.from, n.to, msg.Bytes())
+}
+
+// encodeHeader menyiapkan teks bebas untuk header email: baris baru diganti
+// spasi supaya tidak bisa menyisipkan header lain, dan karakter non-ASCII
+// dikodekan sesuai RFC 2047.
+func encodeHeader(s string) string {
+	s = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(s)
+	return mime.QEncoding.Encode("utf-8", s)
+}
+
+// SMTPSender mengirim email lewat server SMTP di addr (host:port). Auth
+// dipakai bila username diisi.
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+}
+
+func NewSMTPSender(addr, username, password string) *SMTPSender {
+	s := &SMTPSender{addr: addr}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return s
+}
+
+func (s *SMTPSender) Send(from string, to []string, msg []byte) error {
+	return smtp.SendMail(s.addr, s.auth, from, to, msg)
+}
+
+// OutboxSender adalah sender palsu untuk development: setiap email disimpan
+// sebagai file .eml di dir, tidak ada yang benar-benar dikirim.
+type OutboxSender struct {
+	dir string
+}
+
+func NewOutboxSender(dir string) *OutboxSender {
+	return &OutboxSender{dir: dir}
+}
+
+func (s *OutboxSender) Send(from string, to []string, msg []byte) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	name := filepath.Join(s.dir, fmt.Sprintf("%d.eml", time.Now().UnixNano()))
+	return os.WriteFile(name, msg, 0o644)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"io"
+	"mime"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+// fakeSender menyimpan email terakhir yang dikirim.
+type fakeSender struct {
+	from string
+	to   []string
+	msg  []byte
+}
+
+func (s *fakeSender) Send(from string, to []string, msg []byte) error {
+	s.from, s.to, s.msg = from, to, msg
+	return nil
+}
+
+func TestEmailNotifierSubject(t *testing.T) {
+	tests := []struct {
+		name        string
+		productName string
+		wantSubject string
+	}{
+		{"plain name", "Kopi Susu", "Low stock: Kopi Susu"},
+		{"non-ASCII name", "Teh Manis ☕", "Low stock: Teh Manis ☕"},
+		{"header injection", "Kopi\r\nBcc: attacker@example.com", "Low stock: Kopi Bcc: attacker@example.com"},
+		{"bare line feed", "Kopi\nX-Evil: 1", "Low stock: Kopi X-Evil: 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := &fakeSender{}
+			n := NewEmailNotifier(sender, "kasir@example.com", []string{" gudang@example.com ", ""})
+			alerts := []models.LowStockAlert{{ProductID: 1, Name: tt.productName, Stock: models.Units(2), MinStock: models.Units(5)}}
+			if err := n.NotifyLowStock(alerts); err != nil {
+				t.Fatal(err)
+			}
+
+			if sender.from != "kasir@example.com" || len(sender.to) != 1 || sender.to[0] != "gudang@example.com" {
+				t.Errorf("envelope = %q -> %q", sender.from, sender.to)
+			}
+			msg, err := mail.ReadMessage(strings.NewReader(string(sender.msg)))
+			if err != nil {
+				t.Fatalf("message does not parse: %v\n%s", err, sender.msg)
+			}
+			for _, key := range []string{"Bcc", "X-Evil"} {
+				if v := msg.Header.Get(key); v != "" {
+					t.Errorf("injected header %s: %q", key, v)
+				}
+			}
+			raw := msg.Header.Get("Subject")
+			for _, r := range raw {
+				if r > 127 {
+					t.Errorf("raw subject is not ASCII: %q", raw)
+					break
+				}
+			}
+			subject, err := new(mime.WordDecoder).DecodeHeader(raw)
+			if err != nil || subject != tt.wantSubject {
+				t.Errorf("subject = %q, %v; want %q", subject, err, tt.wantSubject)
+			}
+			if msg.Header.Get("To") != "gudang@example.com" {
+				t.Errorf("To = %q", msg.Header.Get("To"))
+			}
+		})
+	}
+}
+
+func TestEmailNotifierSeveralAlerts(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, "kasir@example.com", []string{"gudang@example.com"})
+	alerts := []models.LowStockAlert{
+		{ProductID: 1, Name: "Kopi", Stock: 1500, MinStock: models.Units(5)},
+		{ProductID: 2, Name: "Teh", Stock: 0, MinStock: models.Units(3), ReorderQuantity: models.Units(12)},
+	}
+	if err := n.NotifyLowStock(alerts); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(sender.msg)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := msg.Header.Get("Subject"); got != "Low stock: 2 product(s)" {
+		t.Errorf("subject = %q", got)
+	}
+	body, err := io.ReadAll(msg.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"- Kopi (#1): stock 1.5, minimum 5", "- Teh (#2): stock 0, minimum 3, reorder 12"} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("body misses %q:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package services
+
+import "go-kasir-api/models"
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// normalizePage mengisi default dan membatasi ukuran halaman dari query.
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+func newPagination(page, pageSize, totalItems int) models.Pagination {
+	return models.Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: totalItems,
+		TotalPages: (totalItems + pageSize - 1) / pageSize,
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	passwordIterations = 600000
+	passwordKeyLength  = 32
+	passwordSaltLength = 16
+)
+
+// dummyPasswordHash dibandingkan saat login dengan username yang tidak ada,
+// dengan biaya yang sama seperti hash asli. Tidak ada password yang cocok.
+var dummyPasswordHash = fmt.Sprintf("pbkdf2-sha256$%d$%s$%s", passwordIterations,
+	base64.RawStdEncoding.EncodeToString(make([]byte, passwordSaltLength)),
+	base64.RawStdEncoding.EncodeToString(make([]byte, passwordKeyLength)))
+
+// HashPassword menghasilkan hash PBKDF2-SHA256 dengan format
+// "pbkdf2-sha256$<iterasi>$<salt>$<hash>" (base64 tanpa padding).
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, passwordIterations, passwordKeyLength)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("pbkdf2-sha256$%d$%s$%s", passwordIterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// CheckPassword membandingkan password dengan hash dari HashPassword.
+func CheckPassword(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != "pbkdf2-sha256" {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations < 1 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+
+	key, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(expected))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(key, expected) == 1
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := HashPassword("rahasia123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(hash, "$")
+	// Hash dengan iterasi kecil supaya kasus format tidak lambat.
+	cheap := strings.Join([]string{parts[0], "1", parts[2], parts[3]}, "$")
+
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		want     bool
+	}{
+		{"correct password", hash, "rahasia123", true},
+		{"wrong password", hash, "rahasia124", false},
+		{"empty password", hash, "", false},
+		{"password with trailing space", hash, "rahasia123 ", false},
+		{"changed iterations", cheap, "rahasia123", false},
+		{"zero iterations", strings.Join([]string{parts[0], "0", parts[2], parts[3]}, "$"), "rahasia123", false},
+		{"other algorithm", strings.Join([]string{"pbkdf2-sha1", "1", parts[2], parts[3]}, "$"), "rahasia123", false},
+		{"bad salt", strings.Join([]string{parts[0], "1", "!!", parts[3]}, "$"), "rahasia123", false},
+		{"bad key", strings.Join([]string{parts[0], "1", parts[2], "!!"}, "$"), "rahasia123", false},
+		{"missing part", strings.Join(parts[:3], "$"), "rahasia123", false},
+		{"empty hash", "", "", false},
+		{"dummy hash", dummyPasswordHash, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckPassword(tt.hash, tt.password); got != tt.want {
+				t.Errorf("CheckPassword = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashPasswordSalted(t *testing.T) {
+	a, err := HashPassword("rahasia123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := HashPassword("rahasia123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b {
+		t.Error("same password hashed twice gives the same hash")
+	}
+}
+
+func TestDummyPasswordHashCost(t *testing.T) {
+	hash, err := HashPassword("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, dummy := strings.Split(hash, "$"), strings.Split(dummyPasswordHash, "$")
+	for i := range 2 {
+		if actual[i] != dummy[i] {
+			t.Errorf("dummy hash part %d = %q, want %q", i, dummy[i], actual[i])
+		}
+	}
+	if len(actual[2]) != len(dummy[2]) || len(actual[3]) != len(dummy[3]) {
+		t.Errorf("dummy salt/key length differs from a real hash: %q", dummyPasswordHash)
+	}
+}
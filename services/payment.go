@@ -0,0 +1,93 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"strings"
+)
+
+func isValidPaymentMethod(method string) bool {
+	switch method {
+	case models.PaymentCash, models.PaymentCard, models.PaymentEWallet, models.PaymentQRIS:
+		return true
+	}
+	return false
+}
+
+// validatePayments memeriksa tender dari client sebelum produk dikunci.
+func validatePayments(payments []models.Payment) error {
+	cashCount, pointsCount, creditCount := 0, 0, 0
+	for _, p := range payments {
+		if !isValidPaymentMethod(p.Method) && p.Method != models.PaymentPoints && p.Method != models.PaymentCredit {
+			return models.NewValidationError("payment method must be one of cash, card, ewallet, qris, points, credit")
+		}
+		if p.Amount <= 0 {
+			return models.NewValidationError("payment amount must be greater than 0")
+		}
+		switch p.Method {
+		case models.PaymentCash:
+			cashCount++
+		case models.PaymentPoints:
+			pointsCount++
+		case models.PaymentCredit:
+			creditCount++
+		}
+	}
+	if cashCount > 1 {
+		return models.NewValidationError("only one cash payment is allowed")
+	}
+	if pointsCount > 1 {
+		return models.NewValidationError("only one points payment is allowed")
+	}
+	if creditCount > 1 {
+		return models.NewValidationError("only one credit payment is allowed")
+	}
+	return nil
+}
+
+// settlePayments mencocokkan tender dengan total yang sudah dihitung server.
+// Tender non-tunai tidak boleh melebihi total (tidak ada kembalian dari kartu
+// atau e-wallet); sisanya dibayar tunai dan kelebihan uang tunai menjadi
+// kembalian.
+func settlePayments(transaction *models.Transaction) error {
+	if transaction.Total == 0 && len(transaction.Payments) == 0 {
+		return nil
+	}
+	if len(transaction.Payments) == 0 {
+		return models.NewValidationError("payments are required")
+	}
+
+	nonCash := 0
+	var cash *models.Payment
+	for i := range transaction.Payments {
+		p := &transaction.Payments[i]
+		p.Reference = strings.TrimSpace(p.Reference)
+		p.Tendered, p.Change = 0, 0
+		if p.Method == models.PaymentCash {
+			cash = p
+			continue
+		}
+		nonCash += p.Amount
+	}
+
+	if nonCash > transaction.Total {
+		return models.NewValidationError("non-cash payments (%d) exceed the total (%d)", nonCash, transaction.Total)
+	}
+
+	due := transaction.Total - nonCash
+	transaction.Change = 0
+	if cash == nil {
+		if due > 0 {
+			return models.NewValidationError("payments (%d) do not cover the total (%d)", nonCash, transaction.Total)
+		}
+		return nil
+	}
+
+	if cash.Amount < due {
+		return models.NewValidationError("payments (%d) do not cover the total (%d)", nonCash+cash.Amount, transaction.Total)
+	}
+	cash.Tendered = cash.Amount
+	cash.Change = cash.Amount - due
+	cash.Amount = due
+	transaction.Change = cash.Change
+	return nil
+}
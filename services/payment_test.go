@@ -0,0 +1,101 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"testing"
+)
+
+func TestSettlePayments(t *testing.T) {
+	pay := func(method string, amount int) models.Payment {
+		return models.Payment{Method: method, Amount: amount}
+	}
+
+	tests := []struct {
+		name       string
+		total      int
+		payments   []models.Payment
+		wantErr    bool
+		wantAmount []int
+		wantChange int
+	}{
+		{name: "free sale", total: 0},
+		{name: "no payments", total: 10000, wantErr: true},
+		{name: "exact cash", total: 10000, payments: []models.Payment{pay(models.PaymentCash, 10000)}, wantAmount: []int{10000}},
+		{name: "cash overpayment gives change", total: 10000, payments: []models.Payment{pay(models.PaymentCash, 50000)}, wantAmount: []int{10000}, wantChange: 40000},
+		{name: "cash one rupiah short", total: 10000, payments: []models.Payment{pay(models.PaymentCash, 9999)}, wantErr: true},
+		{name: "exact card", total: 10000, payments: []models.Payment{pay(models.PaymentCard, 10000)}, wantAmount: []int{10000}},
+		{name: "card over the total", total: 10000, payments: []models.Payment{pay(models.PaymentCard, 10001)}, wantErr: true},
+		{name: "card short without cash", total: 10000, payments: []models.Payment{pay(models.PaymentQRIS, 9000)}, wantErr: true},
+		{
+			name:       "split card and cash",
+			total:      10000,
+			payments:   []models.Payment{pay(models.PaymentCard, 6000), pay(models.PaymentCash, 5000)},
+			wantAmount: []int{6000, 4000},
+			wantChange: 1000,
+		},
+		{
+			name:       "credit and cash",
+			total:      25000,
+			payments:   []models.Payment{pay(models.PaymentCredit, 20000), pay(models.PaymentCash, 10000)},
+			wantAmount: []int{20000, 5000},
+			wantChange: 5000,
+		},
+		{
+			name:     "non-cash tenders together over the total",
+			total:    10000,
+			payments: []models.Payment{pay(models.PaymentCredit, 6000), pay(models.PaymentEWallet, 5000), pay(models.PaymentCash, 1000)},
+			wantErr:  true,
+		},
+		{
+			name:     "split short of the total",
+			total:    10000,
+			payments: []models.Payment{pay(models.PaymentPoints, 2000), pay(models.PaymentCash, 7000)},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &models.Transaction{Total: tt.total, Payments: tt.payments, Change: 123}
+			err := settlePayments(transaction)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("settlePayments succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			paid := 0
+			for i, p := range transaction.Payments {
+				if p.Amount != tt.wantAmount[i] {
+					t.Errorf("payment %d amount = %d, want %d", i, p.Amount, tt.wantAmount[i])
+				}
+				paid += p.Amount
+			}
+			if len(tt.payments) > 0 && paid != tt.total {
+				t.Errorf("payments add up to %d, want %d", paid, tt.total)
+			}
+			if len(tt.payments) > 0 && transaction.Change != tt.wantChange {
+				t.Errorf("change = %d, want %d", transaction.Change, tt.wantChange)
+			}
+		})
+	}
+}
+
+func TestSettlePaymentsResetsClientFields(t *testing.T) {
+	transaction := &models.Transaction{Total: 10000, Payments: []models.Payment{
+		{Method: models.PaymentCard, Amount: 4000, Tendered: 9000, Change: 5000, Reference: "  APPR-1 "},
+		{Method: models.PaymentCash, Amount: 10000, Tendered: 1, Change: 1},
+	}}
+	if err := settlePayments(transaction); err != nil {
+		t.Fatal(err)
+	}
+	card, cash := transaction.Payments[0], transaction.Payments[1]
+	if card.Tendered != 0 || card.Change != 0 || card.Reference != "APPR-1" {
+		t.Errorf("card = %+v, want no tendered/change and trimmed reference", card)
+	}
+	if cash.Tendered != 10000 || cash.Change != 4000 || cash.Amount != 6000 {
+		t.Errorf("cash = %+v, want tendered 10000, change 4000, amount 6000", cash)
+	}
+}
@@ -0,0 +1,558 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"io"
+	"log"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// maxImportRows membatasi jumlah baris data dalam satu file impor.
+	maxImportRows = 20000
+	// importBatchSize adalah jumlah baris di antara penyimpanan progres job.
+	importBatchSize = 100
+)
+
+type ProductImportService struct {
+	repo         *repositories.ProductImportRepository
+	products     *ProductService
+	productRepo  *repositories.ProductRepository
+	categoryRepo *repositories.CategoryRepository
+}
+
+func NewProductImportService(repo *repositories.ProductImportRepository, products *ProductService, productRepo *repositories.ProductRepository, categoryRepo *repositories.CategoryRepository) *ProductImportService {
+	return &ProductImportService{repo: repo, products: products, productRepo: productRepo, categoryRepo: categoryRepo}
+}
+
+func (s *ProductImportService) GetAll() ([]models.ProductImportJob, error) {
+	return s.repo.GetAll()
+}
+
+func (s *ProductImportService) GetByID(id int) (*models.ProductImportJob, error) {
+	return s.repo.GetByID(id)
+}
+
+// FailUnfinished menandai job yang terputus oleh restart server sebagai failed.
+func (s *ProductImportService) FailUnfinished() error {
+	return s.repo.FailUnfinished("import was interrupted by a server restart")
+}
+
+// importRow adalah satu baris data beserta nomor barisnya di file.
+type importRow struct {
+	line  int
+	cells []string
+}
+
+// Start membaca file dan memeriksa judul kolomnya, lalu memproses baris-baris
+// di background. Kesalahan file (format, kolom, kosong) langsung dikembalikan;
+// kesalahan per baris dicatat di job.
+func (s *ProductImportService) Start(req models.ProductImportRequest, user *models.User) (*models.ProductImportJob, error) {
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(req.FileName)), ".")
+	}
+	if format != models.ImportFormatCSV && format != models.ImportFormatXLSX {
+		return nil, models.NewValidationError("format must be csv or xlsx")
+	}
+
+	records, err := readSheet(format, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, models.NewValidationError("file is empty")
+	}
+	header := records[0]
+	columns, err := mapImportColumns(header, req.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for i, cells := range records[1:] {
+		if strings.TrimSpace(strings.Join(cells, "")) == "" {
+			continue
+		}
+		rows = append(rows, importRow{line: i + 2, cells: cells})
+	}
+	if len(rows) == 0 {
+		return nil, models.NewValidationError("file has no data rows")
+	}
+	if len(rows) > maxImportRows {
+		return nil, models.NewValidationError("file has %d rows; at most %d rows can be imported at once", len(rows), maxImportRows)
+	}
+
+	job := &models.ProductImportJob{
+		DryRun:    req.DryRun,
+		FileName:  filepath.Base(req.FileName),
+		Format:    format,
+		TotalRows: len(rows),
+		CreatedBy: userID(user),
+	}
+	if err := s.repo.Create(job); err != nil {
+		return nil, err
+	}
+
+	go s.run(job, header, columns, rows, user)
+	return job, nil
+}
+
+// readSheet membaca semua baris CSV atau sheet pertama file XLSX.
+func readSheet(format string, data []byte) ([][]string, error) {
+	if format == models.ImportFormatCSV {
+		data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+		r := csv.NewReader(bytes.NewReader(data))
+		r.FieldsPerRecord = -1
+		r.TrimLeadingSpace = true
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, models.NewValidationError("invalid csv file: %v", err)
+		}
+		return records, nil
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, models.NewValidationError("invalid xlsx file: %v", err)
+	}
+	defer f.Close()
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	records, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, models.NewValidationError("invalid xlsx file: %v", err)
+	}
+	return records, nil
+}
+
+// mapImportColumns mencari indeks kolom setiap field impor. Judul kolom
+// dicocokkan tanpa memandang huruf besar/kecil; field yang tidak ada di
+// mapping memakai judul yang sama dengan nama field.
+func mapImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		key := strings.ToLower(strings.TrimSpace(h))
+		if _, ok := index[key]; !ok && key != "" {
+			index[key] = i
+		}
+	}
+
+	known := make(map[string]bool, len(models.ProductImportFields))
+	for _, field := range models.ProductImportFields {
+		known[field] = true
+	}
+	columns := make(map[string]int)
+	for field, h := range mapping {
+		if !known[field] {
+			return nil, models.NewValidationError("unknown import field %q, use one of %s", field, strings.Join(models.ProductImportFields, ", "))
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(h))]
+		if !ok {
+			return nil, models.NewValidationError("column %q mapped to %s is not in the file", h, field)
+		}
+		columns[field] = i
+	}
+	for _, field := range models.ProductImportFields {
+		if _, ok := mapping[field]; ok {
+			continue
+		}
+		if i, ok := index[field]; ok {
+			columns[field] = i
+		}
+	}
+
+	_, hasSKU := columns["sku"]
+	_, hasName := columns["name"]
+	if !hasSKU && !hasName {
+		return nil, models.NewValidationError("file needs a sku or name column")
+	}
+	return columns, nil
+}
+
+// run memproses semua baris job. Baris yang gagal dicatat dan dilewati;
+// kesalahan lain (mis. database) menghentikan job sebagai failed.
+func (s *ProductImportService) run(job *models.ProductImportJob, header []string, columns map[string]int, rows []importRow, user *models.User) {
+	var rowErrors []models.ImportRowError
+	finish := func(status, message string) {
+		job.Status, job.Message = status, message
+		if err := s.repo.Finish(job, rowErrors); err != nil {
+			log.Printf("product import %d: saving result failed: %v", job.ID, err)
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("product import %d: %v", job.ID, r)
+			finish(models.ImportFailed, "import stopped unexpectedly")
+		}
+	}()
+
+	if err := s.repo.Start(job); err != nil {
+		finish(models.ImportFailed, err.Error())
+		return
+	}
+	imp, err := s.newImporter(job.DryRun, header, columns, user)
+	if err != nil {
+		finish(models.ImportFailed, err.Error())
+		return
+	}
+
+	for i, row := range rows {
+		created, column, err := imp.apply(row)
+		switch {
+		case err == nil && created:
+			job.Created++
+		case err == nil:
+			job.Updated++
+		case isRowError(err):
+			job.Failed++
+			rowErrors = append(rowErrors, models.ImportRowError{Row: row.line, Column: column, Message: err.Error()})
+		default:
+			finish(models.ImportFailed, fmt.Sprintf("row %d: %v", row.line, err))
+			return
+		}
+		job.Processed++
+
+		if (i+1)%importBatchSize == 0 && i+1 < len(rows) {
+			if err := s.repo.SaveProgress(job, rowErrors); err != nil {
+				finish(models.ImportFailed, err.Error())
+				return
+			}
+			rowErrors = nil
+		}
+	}
+	finish(models.ImportCompleted, "")
+}
+
+// isRowError membedakan kesalahan isi baris dari kesalahan sistem.
+func isRowError(err error) bool {
+	var validation *models.ValidationError
+	return errors.As(err, &validation) ||
+		errors.Is(err, models.ErrSKUTaken) ||
+		errors.Is(err, models.ErrBarcodeTaken) ||
+		errors.Is(err, models.ErrProductNotFound)
+}
+
+// productImporter menyimpan keadaan selama satu job: kategori yang dikenal
+// (berdasarkan nama) dan SKU yang sudah muncul di file.
+type productImporter struct {
+	*ProductImportService
+	dryRun     bool
+	header     []string
+	columns    map[string]int
+	user       *models.User
+	categories map[string]int
+	seenSKU    map[string]int
+}
+
+func (s *ProductImportService) newImporter(dryRun bool, header []string, columns map[string]int, user *models.User) (*productImporter, error) {
+	categories, err := s.categoryRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	imp := &productImporter{
+		ProductImportService: s,
+		dryRun:               dryRun,
+		header:               header,
+		columns:              columns,
+		user:                 user,
+		categories:           make(map[string]int, len(categories)),
+		seenSKU:              make(map[string]int),
+	}
+	for _, c := range categories {
+		imp.categories[strings.ToLower(c.Name)] = c.ID
+	}
+	return imp, nil
+}
+
+// cell mengembalikan isi field pada baris; ok false bila kolomnya tidak ada
+// atau selnya kosong, yang berarti nilai produk tidak diubah. Tanda kutip
+// yang ditambahkan escapeFormula saat ekspor dibuang lagi.
+func (imp *productImporter) cell(row importRow, field string) (string, bool) {
+	i, ok := imp.columns[field]
+	if !ok || i >= len(row.cells) {
+		return "", false
+	}
+	v := strings.TrimSpace(row.cells[i])
+	if len(v) > 1 && v[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(v[1])) {
+		v = v[1:]
+	}
+	return v, v != ""
+}
+
+// column adalah judul kolom field di file, untuk pesan error.
+func (imp *productImporter) column(field string) string {
+	return strings.TrimSpace(imp.header[imp.columns[field]])
+}
+
+// apply memvalidasi satu baris dan, bila bukan dry run, menyimpannya. Baris
+// dengan SKU yang sudah ada memperbarui produk itu (hanya sel yang terisi),
+// selain itu produk baru dibuat. Kategori dicari berdasarkan nama dan dibuat
+// bila belum ada. Column berisi judul kolom penyebab error bila diketahui.
+func (imp *productImporter) apply(row importRow) (created bool, column string, err error) {
+	sku, _ := imp.cell(row, "sku")
+	if sku != "" {
+		if line, ok := imp.seenSKU[sku]; ok {
+			return false, imp.column("sku"), models.NewValidationError("sku %s already appears on row %d", sku, line)
+		}
+		imp.seenSKU[sku] = row.line
+	}
+
+	var current *models.Product
+	if sku != "" {
+		current, err = imp.productRepo.GetBySKU(sku)
+		if err != nil && !errors.Is(err, models.ErrProductNotFound) {
+			return false, "", err
+		}
+	}
+	product := &models.Product{SKU: sku}
+	if current != nil {
+		p := *current
+		p.Barcodes, p.Units, p.ModifierGroupIDs = nil, nil, nil
+		product = &p
+	}
+
+	if v, ok := imp.cell(row, "name"); ok {
+		product.Name = v
+	}
+	for _, f := range []struct {
+		field string
+		dest  *int
+	}{{"price", &product.Price}, {"cost", &product.Cost}} {
+		if v, ok := imp.cell(row, f.field); ok {
+			if *f.dest, err = parseAmount(v); err != nil {
+				return false, imp.column(f.field), err
+			}
+		}
+	}
+	for _, f := range []struct {
+		field string
+		dest  *models.Quantity
+	}{{"stock", &product.Stock}, {"min_stock", &product.MinStock}, {"reorder_quantity", &product.ReorderQuantity}} {
+		if v, ok := imp.cell(row, f.field); ok {
+			q, err := models.ParseQuantity(v)
+			if err != nil {
+				return false, imp.column(f.field), models.NewValidationError("%v", err)
+			}
+			*f.dest = q
+		}
+	}
+	if v, ok := imp.cell(row, "unit"); ok {
+		product.Unit = v
+	}
+	if v, ok := imp.cell(row, "decimals"); ok {
+		if product.Decimals, err = strconv.Atoi(v); err != nil {
+			return false, imp.column("decimals"), models.NewValidationError("decimals must be a whole number")
+		}
+	}
+	if v, ok := imp.cell(row, "barcodes"); ok {
+		product.Barcodes = parseBarcodeList(v)
+	}
+
+	newCategory := ""
+	if v, ok := imp.cell(row, "category"); ok {
+		if id, found := imp.categories[strings.ToLower(v)]; found {
+			product.CategoryID = &id
+		} else {
+			if len(v) > 100 {
+				return false, imp.column("category"), models.NewValidationError("category must be at most 100 characters")
+			}
+			product.CategoryID, newCategory = nil, v
+		}
+	}
+
+	// Stok baris untuk produk yang sudah ada menjadi penyesuaian di ledger;
+	// validateUpdate mengembalikan product.Stock ke stok saat ini.
+	stock := product.Stock
+	if current != nil {
+		err = imp.products.validateUpdate(current, product)
+		if err == nil && stock != product.Stock {
+			if err := validateImportedStock(current, product, stock); err != nil {
+				return false, imp.column("stock"), err
+			}
+		}
+	} else {
+		err = imp.products.validate(product)
+	}
+	if err != nil || imp.dryRun {
+		return current == nil, "", err
+	}
+
+	if err := imp.productRepo.SaveImported(product, stock, newCategory, userID(imp.user)); err != nil {
+		return false, "", err
+	}
+	if newCategory != "" {
+		imp.categories[strings.ToLower(newCategory)] = *product.CategoryID
+	}
+	return current == nil, "", nil
+}
+
+// validateImportedStock memeriksa stok baru dari import untuk produk yang
+// sudah ada.
+func validateImportedStock(current, product *models.Product, stock models.Quantity) error {
+	switch {
+	case current.HasVariants:
+		return models.NewValidationError("product %d has variants; stock is kept on each variant", current.ID)
+	case stock < 0:
+		return models.NewValidationError("stock must not be negative")
+	case !stock.Fits(product.Decimals):
+		return models.NewValidationError("stock of %s may have at most %d decimals", product.Name, product.Decimals)
+	}
+	return nil
+}
+
+// parseAmount membaca harga rupiah; "15000" dan "15000.00" diterima.
+func parseAmount(v string) (int, error) {
+	if n, err := strconv.Atoi(v); err == nil {
+		return n, nil
+	}
+	q, err := models.ParseQuantity(v)
+	if err != nil || !q.Fits(0) {
+		return 0, models.NewValidationError("%q is not a whole rupiah amount", v)
+	}
+	return int(q / models.QuantityScale), nil
+}
+
+// parseBarcodeList membaca barcode yang dipisah ";". Awalan "tipe:" (mis.
+// "plu:12345") menetapkan tipe barcode; tanpa awalan tipenya dideteksi.
+func parseBarcodeList(v string) []models.ProductBarcode {
+	var barcodes []models.ProductBarcode
+	for _, code := range strings.Split(v, ";") {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			continue
+		}
+		b := models.ProductBarcode{Code: code}
+		if t, c, ok := strings.Cut(code, ":"); ok {
+			b.Type, b.Code = strings.TrimSpace(t), strings.TrimSpace(c)
+		}
+		barcodes = append(barcodes, b)
+	}
+	return barcodes
+}
+
+// formatBarcodeList kebalikan parseBarcodeList: tipe hanya ditulis bila tidak
+// sama dengan hasil deteksi dari kodenya.
+func formatBarcodeList(barcodes []models.ProductBarcode) string {
+	codes := make([]string, len(barcodes))
+	for i, b := range barcodes {
+		detected := models.ProductBarcode{Code: b.Code}
+		if err := normalizeBarcode(&detected); err != nil || detected.Type != b.Type {
+			codes[i] = b.Type + ":" + b.Code
+			continue
+		}
+		codes[i] = b.Code
+	}
+	return strings.Join(codes, ";")
+}
+
+// Export menulis seluruh katalog (induk diikuti variannya) sebagai CSV atau
+// XLSX dengan kolom yang sama dengan impor, sehingga file bisa diedit lalu
+// diimpor kembali. Varian diperbarui lewat SKU-nya; varian baru tetap dibuat
+// lewat endpoint varian.
+func (s *ProductImportService) Export(format string, w io.Writer) error {
+	if format != models.ImportFormatCSV && format != models.ImportFormatXLSX {
+		return models.NewValidationError("format must be csv or xlsx")
+	}
+	products, err := s.productRepo.GetCatalog()
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]interface{}, 0, len(products)+1)
+	header := make([]interface{}, len(models.ProductImportFields))
+	for i, c := range models.ProductImportFields {
+		header[i] = c
+	}
+	rows = append(rows, header)
+	for _, p := range products {
+		category := ""
+		if p.Category != nil {
+			category = p.Category.Name
+		}
+		rows = append(rows, []interface{}{
+			escapeFormula(p.SKU), escapeFormula(p.Name), escapeFormula(category), p.Price, p.Cost, p.Stock,
+			escapeFormula(p.Unit), p.Decimals, p.MinStock, p.ReorderQuantity, escapeFormula(formatBarcodeList(p.Barcodes)),
+		})
+	}
+
+	if format == models.ImportFormatCSV {
+		return writeCSV(w, rows)
+	}
+	return writeXLSX(w, rows)
+}
+
+// formulaPrefixes adalah karakter awal yang membuat spreadsheet membaca sel
+// sebagai rumus.
+const formulaPrefixes = "=+-@"
+
+// escapeFormula menambahkan tanda kutip di depan teks yang akan dibaca
+// sebagai rumus, mis. nama produk "=HYPERLINK(...)", supaya spreadsheet
+// menampilkannya sebagai teks biasa.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune(formulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func writeCSV(w io.Writer, rows [][]interface{}) error {
+	cw := csv.NewWriter(w)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, v := range row {
+			record[i] = fmt.Sprint(v)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeXLSX menulis baris ke sheet "Products"; qty ditulis sebagai angka
+// agar bisa dihitung di spreadsheet.
+func writeXLSX(w io.Writer, rows [][]interface{}) error {
+	f := excelize.NewFile()
+	defer f.Close()
+	const sheet = "Products"
+	if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+		return err
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	for i, row := range rows {
+		values := make([]interface{}, len(row))
+		for j, v := range row {
+			if q, ok := v.(models.Quantity); ok {
+				v = float64(q) / models.QuantityScale
+			}
+			values[j] = v
+		}
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetRow(cell, values); err != nil {
+			return err
+		}
+	}
+	if err := sw.Flush(); err != nil {
+		return err
+	}
+	return f.Write(w)
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Kopi Susu", "Kopi Susu"},
+		{"", ""},
+		{"=HYPERLINK(\"http://example.com\")", "'=HYPERLINK(\"http://example.com\")"},
+		{"+62812", "'+62812"},
+		{"-5%", "'-5%"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"a=b", "a=b"},
+		{"'quoted", "'quoted"},
+	}
+	for _, tt := range tests {
+		if got := escapeFormula(tt.in); got != tt.want {
+			t.Errorf("escapeFormula(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestImportCellDropsFormulaQuote(t *testing.T) {
+	imp := &productImporter{columns: map[string]int{"name": 0}}
+	tests := []struct {
+		cell, want string
+	}{
+		{escapeFormula("=1+1"), "=1+1"},
+		{escapeFormula("-Diskon"), "-Diskon"},
+		{"'quoted", "'quoted"},
+		{"'", "'"},
+		{" Kopi ", "Kopi"},
+	}
+	for _, tt := range tests {
+		got, _ := imp.cell(importRow{cells: []string{tt.cell}}, "name")
+		if got != tt.want {
+			t.Errorf("cell(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCSVEscapedRow(t *testing.T) {
+	var buf bytes.Buffer
+	rows := [][]interface{}{{"name", "price"}, {escapeFormula("=cmd|' /C calc'!A0"), 15000}}
+	if err := writeCSV(&buf, rows); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,price\n'=cmd|' /C calc'!A0,15000\n"
+	if buf.String() != want {
+		t.Errorf("csv = %q, want %q", buf.String(), want)
+	}
+}
@@ -1,34 +1,334 @@
 package services
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"go-kasir-api/models"
 	"go-kasir-api/repositories"
+	"strconv"
+	"strings"
 )
 
 type ProductService struct {
-	repo *repositories.ProductRepository
+	repo         *repositories.ProductRepository
+	categoryRepo *repositories.CategoryRepository
+	taxRateRepo  *repositories.TaxRateRepository
 }
 
-func NewProductService(repo *repositories.ProductRepository) *ProductService {
-	return &ProductService{repo: repo}
+func NewProductService(repo *repositories.ProductRepository, categoryRepo *repositories.CategoryRepository, taxRateRepo *repositories.TaxRateRepository) *ProductService {
+	return &ProductService{repo: repo, categoryRepo: categoryRepo, taxRateRepo: taxRateRepo}
 }
 
-func (s *ProductService) GetAll() ([]models.Product, error) {
-	return s.repo.GetAll()
+// List mengembalikan satu halaman produk tingkat atas. Tanpa sort, hasil
+// pencarian diurutkan menurut kemiripan nama dan tanpa pencarian menurut ID.
+// Halaman bisa dipilih dengan page atau dengan cursor dari next_cursor
+// halaman sebelumnya; cursor tetap stabil walau produk ditambah atau dihapus.
+func (s *ProductService) List(filter models.ProductFilter) (*models.ProductList, error) {
+	filter.Page, filter.PageSize = normalizePage(filter.Page, filter.PageSize)
+	filter.Query = strings.TrimSpace(filter.Query)
+	if len(filter.Query) > 100 {
+		return nil, models.NewValidationError("q must be at most 100 characters")
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return nil, models.NewValidationError("min_price must not be greater than max_price")
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeProductCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if filter.Sort == "" {
+			filter.Sort = cursor.Sort
+		}
+		if filter.Sort != cursor.Sort {
+			return nil, models.NewValidationError("cursor belongs to sort %q, not %q", cursor.Sort, filter.Sort)
+		}
+		filter.After = cursor
+	}
+	if filter.Sort == "" {
+		filter.Sort = models.ProductSortID
+		if filter.Query != "" {
+			filter.Sort = models.ProductSortRelevance
+		}
+	}
+	switch strings.TrimPrefix(filter.Sort, "-") {
+	case models.ProductSortID, models.ProductSortName, models.ProductSortPrice, models.ProductSortStock:
+	case models.ProductSortRelevance:
+		if filter.Sort != models.ProductSortRelevance || filter.Query == "" {
+			return nil, models.NewValidationError("sort relevance needs q and cannot be reversed")
+		}
+		if filter.After != nil {
+			return nil, models.NewValidationError("cursor pagination needs a sort other than relevance")
+		}
+	default:
+		return nil, models.NewValidationError("sort must be one of relevance, id, name, price, stock, optionally prefixed with -")
+	}
+
+	products, total, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &models.ProductList{Data: products, Pagination: newPagination(filter.Page, filter.PageSize, total)}
+	more := filter.Page*filter.PageSize < total
+	if filter.After != nil {
+		list.Pagination.Page = 0
+		more = len(products) > filter.PageSize
+		if more {
+			list.Data = products[:filter.PageSize]
+		}
+	}
+	if more && filter.Sort != models.ProductSortRelevance && len(list.Data) > 0 {
+		list.Pagination.NextCursor = encodeProductCursor(filter.Sort, list.Data[len(list.Data)-1])
+	}
+	return list, nil
+}
+
+// encodeProductCursor menyimpan posisi produk p pada urutan sort sebagai
+// string base64 yang aman untuk URL.
+func encodeProductCursor(sort string, p models.Product) string {
+	cursor := models.ProductCursor{Sort: sort, ID: p.ID}
+	switch strings.TrimPrefix(sort, "-") {
+	case models.ProductSortName:
+		cursor.Value = p.Name
+	case models.ProductSortPrice:
+		cursor.Value = strconv.Itoa(p.Price)
+	case models.ProductSortStock:
+		cursor.Value = p.Stock.String()
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeProductCursor(s string) (*models.ProductCursor, error) {
+	var cursor models.ProductCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(data, &cursor) != nil || cursor.Sort == "" || cursor.ID <= 0 {
+		return nil, models.NewValidationError("invalid cursor")
+	}
+	switch strings.TrimPrefix(cursor.Sort, "-") {
+	case models.ProductSortPrice:
+		if _, err := strconv.Atoi(cursor.Value); err != nil {
+			return nil, models.NewValidationError("invalid cursor")
+		}
+	case models.ProductSortStock:
+		if _, err := models.ParseQuantity(cursor.Value); err != nil {
+			return nil, models.NewValidationError("invalid cursor")
+		}
+	}
+	return &cursor, nil
 }
 
-func (s *ProductService) Create(data *models.Product) error {
-	return s.repo.Create(data)
+// Create menyimpan produk; stok awalnya tercatat di ledger atas nama user.
+func (s *ProductService) Create(data *models.Product, user *models.User) error {
+	if err := s.validate(data); err != nil {
+		return err
+	}
+	return s.repo.Create(data, userID(user))
 }
 
 func (s *ProductService) GetByID(id int) (*models.Product, error) {
 	return s.repo.GetByID(id)
 }
 
+// Update menyimpan produk. Stok tidak bisa diubah di sini, hanya lewat
+// pergerakan stok; nilai stok yang dikirim diabaikan. Varian selalu memakai
+// kategori serta tarif pajak induknya. Unit kosong berarti satuan dan desimal
+// tidak diubah.
 func (s *ProductService) Update(product *models.Product) error {
-	return s.repo.Update(product)
+	current, err := s.repo.GetByID(product.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.validateUpdate(current, product); err != nil {
+		return err
+	}
+	if err := s.repo.Update(product); err != nil {
+		return err
+	}
+	product.ParentID, product.Options, product.HasVariants = current.ParentID, current.Options, current.HasVariants
+	product.OptionGroups, product.Variants = current.OptionGroups, current.Variants
+	return nil
+}
+
+// validateUpdate menerapkan aturan Update terhadap produk yang tersimpan
+// (current) lalu memvalidasi product.
+func (s *ProductService) validateUpdate(current, product *models.Product) error {
+	product.Stock = current.Stock
+	if product.Unit == "" {
+		product.Unit, product.Decimals = current.Unit, current.Decimals
+	}
+	if current.ParentID != nil {
+		parent, err := s.repo.GetByID(*current.ParentID)
+		if err != nil {
+			return err
+		}
+		product.CategoryID, product.TaxRateID = parent.CategoryID, parent.TaxRateID
+	}
+	return s.validate(product)
+}
+
+// CreateVariant menambah varian di bawah produk induk. Nama kosong diisi
+// "Induk - Nilai / Nilai", harga 0 memakai harga induk, unit kosong memakai
+// satuan dan desimal induk, sedangkan kategori dan tarif pajak selalu
+// mengikuti induk.
+func (s *ProductService) CreateVariant(parentID int, variant *models.Product, user *models.User) error {
+	if len(variant.Options) == 0 {
+		return models.NewValidationError("options are required")
+	}
+	seen := make(map[string]bool, len(variant.Options))
+	values := make([]string, len(variant.Options))
+	for i := range variant.Options {
+		o := &variant.Options[i]
+		o.Group, o.Value = strings.TrimSpace(o.Group), strings.TrimSpace(o.Value)
+		if o.Group == "" || o.Value == "" {
+			return models.NewValidationError("option %d needs both group and value", i)
+		}
+		if len(o.Group) > 100 || len(o.Value) > 100 {
+			return models.NewValidationError("option group and value must be at most 100 characters")
+		}
+		key := strings.ToLower(o.Group)
+		if seen[key] {
+			return models.NewValidationError("option group %q is set twice", o.Group)
+		}
+		seen[key] = true
+		values[i] = o.Value
+	}
+
+	parent, err := s.repo.GetByID(parentID)
+	if err != nil {
+		return err
+	}
+	if parent.ParentID != nil {
+		return models.NewValidationError("product %d is itself a variant", parentID)
+	}
+
+	if strings.TrimSpace(variant.Name) == "" {
+		variant.Name = parent.Name + " - " + strings.Join(values, " / ")
+	}
+	if variant.Price == 0 {
+		variant.Price = parent.Price
+	}
+	if variant.Unit == "" {
+		variant.Unit, variant.Decimals = parent.Unit, parent.Decimals
+	}
+	variant.CategoryID, variant.TaxRateID = parent.CategoryID, parent.TaxRateID
+	if err := s.validate(variant); err != nil {
+		return err
+	}
+	return s.repo.CreateVariant(parentID, variant, userID(user))
 }
 
 func (s *ProductService) Delete(id int) error {
 	return s.repo.Delete(id)
-}
\ No newline at end of file
+}
+
+// Lookup mencari produk dari hasil scan: barcode atau SKU yang terdaftar
+// dulu, lalu label timbangan (prefix 20-29) yang memuat PLU dan berat atau
+// harga. Untuk produk dengan qty desimal (mis. per kg) berat gram dibaca
+// sebagai kg; produk tanpa desimal tetap dicatat per gram. Label harga
+// dibagi harga produk dan dibulatkan ke desimal produk.
+func (s *ProductService) Lookup(code string) (*models.BarcodeLookup, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, models.NewValidationError("barcode is required")
+	}
+
+	product, err := s.repo.GetByCode(code)
+	if err == nil {
+		return &models.BarcodeLookup{Barcode: code, Product: *product, Quantity: models.Units(1)}, nil
+	}
+	if !errors.Is(err, models.ErrProductNotFound) {
+		return nil, err
+	}
+
+	if isGTINLength(code) && !validGTIN(code) {
+		return nil, models.NewValidationError("barcode %q has an invalid check digit, scan again", code)
+	}
+	label, ok := decodeScaleLabel(code)
+	if !ok {
+		return nil, models.ErrBarcodeNotFound
+	}
+
+	product, err = s.repo.GetByPLU(label.plu)
+	if errors.Is(err, models.ErrProductNotFound) {
+		return nil, models.ErrBarcodeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BarcodeLookup{Barcode: code, Product: *product, Weight: label.weight, Price: label.price}
+	switch {
+	case label.weight != nil && product.Decimals > 0:
+		result.Quantity = models.Quantity(*label.weight).Round(product.Decimals)
+	case label.weight != nil:
+		result.Quantity = models.Units(*label.weight)
+	case product.Price > 0:
+		perUnit := int64(*label.price) * models.QuantityScale
+		result.Quantity = models.Quantity((perUnit + int64(product.Price)/2) / int64(product.Price)).Round(product.Decimals)
+	default:
+		result.Quantity = models.Units(1)
+	}
+	if result.Quantity <= 0 {
+		return nil, models.NewValidationError("label %q encodes a zero quantity", code)
+	}
+	return result, nil
+}
+
+// validate memeriksa field produk dan mengisi Category untuk response.
+func (s *ProductService) validate(product *models.Product) error {
+	product.Name = strings.TrimSpace(product.Name)
+	if product.Name == "" {
+		return models.NewValidationError("name is required")
+	}
+	if product.Price < 0 {
+		return models.NewValidationError("price must not be negative")
+	}
+	if product.Cost < 0 {
+		return models.NewValidationError("cost must not be negative")
+	}
+	if product.Stock < 0 {
+		return models.NewValidationError("stock must not be negative")
+	}
+	if product.MinStock < 0 || product.ReorderQuantity < 0 {
+		return models.NewValidationError("min_stock and reorder_quantity must not be negative")
+	}
+	if err := validateUnits(product); err != nil {
+		return err
+	}
+	product.SKU = strings.TrimSpace(product.SKU)
+	if len(product.SKU) > 64 || strings.ContainsAny(product.SKU, " \t") {
+		return models.NewValidationError("sku must be at most 64 characters without spaces")
+	}
+	if err := normalizeBarcodes(product.Barcodes); err != nil {
+		return err
+	}
+	seenGroups := make(map[int]bool, len(product.ModifierGroupIDs))
+	for _, id := range product.ModifierGroupIDs {
+		if seenGroups[id] {
+			return models.NewValidationError("modifier group %d is listed twice", id)
+		}
+		seenGroups[id] = true
+	}
+	if err := checkTaxRate(s.taxRateRepo, product.TaxRateID); err != nil {
+		return err
+	}
+
+	product.Category = nil
+	if product.CategoryID == nil {
+		return nil
+	}
+
+	category, err := s.categoryRepo.GetByID(*product.CategoryID)
+	if errors.Is(err, models.ErrCategoryNotFound) {
+		return models.NewValidationError("category %d not found", *product.CategoryID)
+	}
+	if err != nil {
+		return err
+	}
+	product.Category = category
+	return nil
+}
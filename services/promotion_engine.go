@@ -0,0 +1,289 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"time"
+)
+
+// inWindow melaporkan apakah promosi berlaku pada waktu lokal toko now.
+// Masa berlaku starts_at/ends_at sudah difilter repository.
+func inWindow(p models.Promotion, now time.Time) bool {
+	if len(p.DaysOfWeek) > 0 {
+		today := false
+		for _, day := range p.DaysOfWeek {
+			if time.Weekday(day) == now.Weekday() {
+				today = true
+				break
+			}
+		}
+		if !today {
+			return false
+		}
+	}
+	if p.StartTime == "" {
+		return true
+	}
+
+	clock := now.Format("15:04")
+	if p.StartTime < p.EndTime {
+		return clock >= p.StartTime && clock < p.EndTime
+	}
+	// Window melewati tengah malam, mis. 22:00-02:00
+	return clock >= p.StartTime || clock < p.EndTime
+}
+
+// promotionCart melacak berapa qty tiap baris (dalam satuan dasar) yang belum
+// dipakai promosi dan diskon yang sudah dibebankan ke baris tersebut.
+type promotionCart struct {
+	transaction *models.Transaction
+	products    map[int]*models.Product
+	free        []models.Quantity
+	applied     []models.AppliedPromotion
+}
+
+// available mengembalikan qty produk yang belum terpakai promosi lain.
+func (c *promotionCart) available(productID int) models.Quantity {
+	var qty models.Quantity
+	for i, detail := range c.transaction.Details {
+		if detail.ProductID == productID {
+			qty += c.free[i]
+		}
+	}
+	return qty
+}
+
+// take memakai qty unit productID dan membebankan discount ke baris-baris
+// yang terpakai sebanding qty-nya.
+func (c *promotionCart) take(productID int, qty models.Quantity, discount int) {
+	remainingQty, remainingDiscount := qty, discount
+	for i := range c.transaction.Details {
+		detail := &c.transaction.Details[i]
+		if remainingQty == 0 {
+			return
+		}
+		if detail.ProductID != productID || c.free[i] == 0 {
+			continue
+		}
+		n := min(c.free[i], remainingQty)
+		share, _ := models.Share(discount, n, qty) // n <= qty
+		if n == remainingQty {
+			share = remainingDiscount
+		}
+		c.free[i] -= n
+		detail.Discount += share
+		remainingQty -= n
+		remainingDiscount -= share
+	}
+}
+
+func (c *promotionCart) record(p models.Promotion, discount int) {
+	if discount <= 0 {
+		return
+	}
+	for i := range c.applied {
+		if *c.applied[i].PromotionID == p.ID {
+			c.applied[i].Discount += discount
+			return
+		}
+	}
+	id := p.ID
+	c.applied = append(c.applied, models.AppliedPromotion{PromotionID: &id, Name: p.Name, Type: p.Type, Discount: discount})
+}
+
+// applyPromotions menerapkan promosi yang berlaku pada baris yang sudah
+// diberi harga oleh priceLines. Urutannya:
+//
+//  1. bundle dan buy_x_get_y, yang memakai qty sehingga unit yang sama tidak
+//     didiskon dua kali;
+//  2. satu promosi produk/kategori terbaik per baris untuk qty sisanya;
+//  3. satu promosi keranjang terbaik atas subtotal setelah diskon baris,
+//     dibagi ke baris sebanding nilai bersihnya.
+//
+// Setelahnya Subtotal setiap baris adalah nilai bersih, Discount total
+// potongannya, dan Total transaksi adalah jumlah subtotal bersih.
+func applyPromotions(transaction *models.Transaction, products map[int]*models.Product, promotions []models.Promotion, now time.Time) {
+	cart := &promotionCart{
+		transaction: transaction,
+		products:    products,
+		free:        make([]models.Quantity, len(transaction.Details)),
+	}
+	for i, detail := range transaction.Details {
+		cart.free[i] = detail.BaseQuantity()
+		transaction.Details[i].Discount = 0
+	}
+
+	var active []models.Promotion
+	for _, p := range promotions {
+		if inWindow(p, now) {
+			active = append(active, p)
+		}
+	}
+
+	// 1. Quantity based promotions
+	for _, p := range active {
+		switch p.Type {
+		case models.PromotionBundle:
+			cart.applyBundle(p)
+		case models.PromotionBuyXGetY:
+			cart.applyBuyXGetY(p)
+		}
+	}
+
+	// 2. Best line promotion for the units left
+	for i := range transaction.Details {
+		detail := &transaction.Details[i]
+		if cart.free[i] == 0 {
+			continue
+		}
+		product := products[detail.ProductID]
+
+		var best *models.Promotion
+		bestDiscount := 0
+		for j := range active {
+			p := active[j]
+			if p.Type != models.PromotionPercentage && p.Type != models.PromotionFixed {
+				continue
+			}
+			// Promosi produk pada induk ikut berlaku untuk semua variannya.
+			matches := (p.Scope == models.ScopeProduct && p.ProductID != nil &&
+				(*p.ProductID == product.ID || (product.ParentID != nil && *p.ProductID == *product.ParentID))) ||
+				(p.Scope == models.ScopeCategory && p.CategoryID != nil && product.CategoryID != nil && *p.CategoryID == *product.CategoryID)
+			if !matches {
+				continue
+			}
+			// Nilai promosi fixed berlaku per satuan dasar, mis. per pcs
+			// walau dibeli per karton.
+			freeValue, _ := models.Share(detail.Subtotal, cart.free[i], detail.BaseQuantity()) // free <= base qty
+			discount := freeValue * p.Value / 100
+			if p.Type == models.PromotionFixed {
+				// Nilai yang tidak muat pasti melebihi freeValue.
+				discount = freeValue
+				if value, ok := cart.free[i].Amount(p.Value); ok {
+					discount = min(value, freeValue)
+				}
+			}
+			if discount > bestDiscount {
+				best, bestDiscount = &active[j], discount
+			}
+		}
+		if best != nil {
+			detail.Discount += bestDiscount
+			cart.free[i] = 0
+			cart.record(*best, bestDiscount)
+		}
+	}
+
+	// 3. Best cart promotion on what is left to pay
+	net := 0
+	for _, detail := range transaction.Details {
+		net += detail.Subtotal - detail.Discount
+	}
+	var best *models.Promotion
+	bestDiscount := 0
+	for j := range active {
+		p := active[j]
+		if p.Scope != models.ScopeCart || (p.Type != models.PromotionPercentage && p.Type != models.PromotionFixed) {
+			continue
+		}
+		if net < p.MinSubtotal {
+			continue
+		}
+		discount := net * p.Value / 100
+		if p.Type == models.PromotionFixed {
+			discount = min(p.Value, net)
+		}
+		if discount > bestDiscount {
+			best, bestDiscount = &active[j], discount
+		}
+	}
+	if best != nil {
+		remaining := bestDiscount
+		last := -1
+		for i, detail := range transaction.Details {
+			if detail.Subtotal-detail.Discount > 0 {
+				last = i
+			}
+		}
+		for i := range transaction.Details {
+			detail := &transaction.Details[i]
+			share := bestDiscount * (detail.Subtotal - detail.Discount) / net
+			if i == last {
+				share = remaining
+			}
+			detail.Discount += share
+			remaining -= share
+		}
+		cart.record(*best, bestDiscount)
+	}
+
+	transaction.Total, transaction.Discount = 0, 0
+	for i := range transaction.Details {
+		detail := &transaction.Details[i]
+		detail.Subtotal -= detail.Discount
+		transaction.Total += detail.Subtotal
+		transaction.Discount += detail.Discount
+	}
+	transaction.Promotions = cart.applied
+}
+
+// applyBundle menjual set Items seharga Value sebanyak set yang lengkap di
+// keranjang, dihitung dalam satuan dasar. Potongan per set dibagi ke item sebanding harga normalnya.
+func (c *promotionCart) applyBundle(p models.Promotion) {
+	sets := -1
+	setPrice := 0
+	for _, item := range p.Items {
+		product, ok := c.products[item.ProductID]
+		if !ok {
+			return
+		}
+		n := int(c.available(item.ProductID) / models.Units(item.Quantity))
+		if sets < 0 || n < sets {
+			sets = n
+		}
+		setPrice += product.Price * item.Quantity
+	}
+	if sets <= 0 || setPrice <= p.Value {
+		return
+	}
+
+	discount := (setPrice - p.Value) * sets
+	remaining := discount
+	for i, item := range p.Items {
+		share := discount * c.products[item.ProductID].Price * item.Quantity / setPrice
+		if i == len(p.Items)-1 {
+			share = remaining
+		}
+		c.take(item.ProductID, models.Units(item.Quantity*sets), share)
+		remaining -= share
+	}
+	c.record(p, discount)
+}
+
+// applyBuyXGetY menggratiskan GetQuantity GetProductID untuk setiap
+// BuyQuantity ProductID. Unit yang dibeli juga dipakai supaya tidak ikut
+// promosi lain.
+func (c *promotionCart) applyBuyXGetY(p models.Promotion) {
+	getProductID := *p.ProductID
+	if p.GetProductID != nil {
+		getProductID = *p.GetProductID
+	}
+	getProduct, ok := c.products[getProductID]
+	if !ok {
+		return
+	}
+
+	var sets int
+	if getProductID == *p.ProductID {
+		sets = int(c.available(*p.ProductID) / models.Units(p.BuyQuantity+p.GetQuantity))
+	} else {
+		sets = int(min(c.available(*p.ProductID)/models.Units(p.BuyQuantity), c.available(getProductID)/models.Units(p.GetQuantity)))
+	}
+	if sets <= 0 {
+		return
+	}
+
+	discount := getProduct.Price * p.GetQuantity * sets
+	c.take(*p.ProductID, models.Units(p.BuyQuantity*sets), 0)
+	c.take(getProductID, models.Units(p.GetQuantity*sets), discount)
+	c.record(p, discount)
+}
@@ -0,0 +1,275 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"testing"
+	"time"
+)
+
+func intPtr(n int) *int {
+	return &n
+}
+
+// line membuat baris checkout yang sudah diberi harga, dalam satuan dasar.
+func line(productID, qty, price int) models.TransactionDetail {
+	return models.TransactionDetail{
+		ProductID:  productID,
+		UnitPrice:  price,
+		Quantity:   models.Units(qty),
+		UnitFactor: models.Units(1),
+		Subtotal:   price * qty,
+	}
+}
+
+func TestApplyPromotions(t *testing.T) {
+	products := map[int]*models.Product{
+		1: {ID: 1, Name: "A", Price: 10000, CategoryID: intPtr(1)},
+		2: {ID: 2, Name: "B", Price: 8000, CategoryID: intPtr(1)},
+		3: {ID: 3, Name: "C", Price: 1000, CategoryID: intPtr(2)},
+		4: {ID: 4, Name: "A - Large", Price: 12000, ParentID: intPtr(1), CategoryID: intPtr(1)},
+	}
+	percent := func(id int, scope string, target *int, value int) models.Promotion {
+		p := models.Promotion{ID: id, Type: models.PromotionPercentage, Scope: scope, Value: value}
+		if scope == models.ScopeCategory {
+			p.CategoryID = target
+		} else {
+			p.ProductID = target
+		}
+		return p
+	}
+	fixed := func(id int, scope string, target *int, value int) models.Promotion {
+		p := percent(id, scope, target, value)
+		p.Type = models.PromotionFixed
+		return p
+	}
+	buyGet := func(id, productID, buy, get int, getProductID *int) models.Promotion {
+		return models.Promotion{ID: id, Type: models.PromotionBuyXGetY, Scope: models.ScopeProduct,
+			ProductID: intPtr(productID), BuyQuantity: buy, GetQuantity: get, GetProductID: getProductID}
+	}
+	bundle := func(id, value int, items ...models.PromotionItem) models.Promotion {
+		return models.Promotion{ID: id, Type: models.PromotionBundle, Scope: models.ScopeProduct, Value: value, Items: items}
+	}
+	cart := func(id int, typ string, value, minSubtotal int) models.Promotion {
+		return models.Promotion{ID: id, Type: typ, Scope: models.ScopeCart, Value: value, MinSubtotal: minSubtotal}
+	}
+
+	tests := []struct {
+		name          string
+		details       []models.TransactionDetail
+		promotions    []models.Promotion
+		wantDiscounts []int
+		wantApplied   map[int]int
+	}{
+		{
+			name:          "percentage on product",
+			details:       []models.TransactionDetail{line(1, 3, 10000)},
+			promotions:    []models.Promotion{percent(1, models.ScopeProduct, intPtr(1), 10)},
+			wantDiscounts: []int{3000},
+			wantApplied:   map[int]int{1: 3000},
+		},
+		{
+			name:          "product promotion applies to variants",
+			details:       []models.TransactionDetail{line(4, 1, 12000)},
+			promotions:    []models.Promotion{percent(1, models.ScopeProduct, intPtr(1), 10)},
+			wantDiscounts: []int{1200},
+			wantApplied:   map[int]int{1: 1200},
+		},
+		{
+			name:          "fixed per unit is capped at the line value",
+			details:       []models.TransactionDetail{line(3, 2, 1000)},
+			promotions:    []models.Promotion{fixed(1, models.ScopeProduct, intPtr(3), 1500)},
+			wantDiscounts: []int{2000},
+			wantApplied:   map[int]int{1: 2000},
+		},
+		{
+			name:    "best line promotion wins",
+			details: []models.TransactionDetail{line(1, 1, 10000)},
+			promotions: []models.Promotion{
+				percent(1, models.ScopeProduct, intPtr(1), 10),
+				fixed(2, models.ScopeCategory, intPtr(1), 2000),
+			},
+			wantDiscounts: []int{2000},
+			wantApplied:   map[int]int{2: 2000},
+		},
+		{
+			name:          "cart minimum subtotal not reached",
+			details:       []models.TransactionDetail{line(3, 9, 1000)},
+			promotions:    []models.Promotion{cart(1, models.PromotionPercentage, 10, 10000)},
+			wantDiscounts: []int{0},
+			wantApplied:   map[int]int{},
+		},
+		{
+			name:          "cart minimum subtotal reached exactly",
+			details:       []models.TransactionDetail{line(3, 10, 1000)},
+			promotions:    []models.Promotion{cart(1, models.PromotionPercentage, 10, 10000)},
+			wantDiscounts: []int{1000},
+			wantApplied:   map[int]int{1: 1000},
+		},
+		{
+			name:          "fixed cart discount is capped at the net total",
+			details:       []models.TransactionDetail{line(3, 1, 1000)},
+			promotions:    []models.Promotion{cart(1, models.PromotionFixed, 5000, 0)},
+			wantDiscounts: []int{1000},
+			wantApplied:   map[int]int{1: 1000},
+		},
+		{
+			name:          "cart discount remainder goes to the last line",
+			details:       []models.TransactionDetail{line(3, 1, 1000), line(3, 1, 1000), line(3, 1, 1000)},
+			promotions:    []models.Promotion{cart(1, models.PromotionFixed, 100, 0)},
+			wantDiscounts: []int{33, 33, 34},
+			wantApplied:   map[int]int{1: 100},
+		},
+		{
+			name:    "cart discount is shared on net line values",
+			details: []models.TransactionDetail{line(1, 1, 10000), line(3, 1, 1000)},
+			promotions: []models.Promotion{
+				percent(1, models.ScopeProduct, intPtr(1), 50),
+				cart(2, models.PromotionPercentage, 10, 0),
+			},
+			wantDiscounts: []int{5500, 100},
+			wantApplied:   map[int]int{1: 5000, 2: 600},
+		},
+		{
+			name:          "buy x get y on the same product",
+			details:       []models.TransactionDetail{line(3, 3, 1000)},
+			promotions:    []models.Promotion{buyGet(1, 3, 2, 1, nil)},
+			wantDiscounts: []int{1000},
+			wantApplied:   map[int]int{1: 1000},
+		},
+		{
+			name:    "buy x get y counts complete sets and leaves the rest to line promotions",
+			details: []models.TransactionDetail{line(3, 5, 1000)},
+			promotions: []models.Promotion{
+				buyGet(1, 3, 2, 1, nil),
+				percent(2, models.ScopeProduct, intPtr(3), 10),
+			},
+			wantDiscounts: []int{1200},
+			wantApplied:   map[int]int{1: 1000, 2: 200},
+		},
+		{
+			name:          "buy x get y on another product",
+			details:       []models.TransactionDetail{line(1, 4, 10000), line(3, 1, 1000)},
+			promotions:    []models.Promotion{buyGet(1, 1, 2, 1, intPtr(3))},
+			wantDiscounts: []int{0, 1000},
+			wantApplied:   map[int]int{1: 1000},
+		},
+		{
+			name:          "buy x get y without the free product in the cart",
+			details:       []models.TransactionDetail{line(1, 2, 10000)},
+			promotions:    []models.Promotion{buyGet(1, 1, 2, 1, intPtr(3))},
+			wantDiscounts: []int{0},
+			wantApplied:   map[int]int{},
+		},
+		{
+			name:    "bundle shares the discount by price and leaves extra units",
+			details: []models.TransactionDetail{line(1, 2, 10000), line(2, 1, 8000)},
+			promotions: []models.Promotion{
+				bundle(1, 15000, models.PromotionItem{ProductID: 1, Quantity: 1}, models.PromotionItem{ProductID: 2, Quantity: 1}),
+				percent(2, models.ScopeProduct, intPtr(1), 10),
+			},
+			wantDiscounts: []int{2666, 1334},
+			wantApplied:   map[int]int{1: 3000, 2: 1000},
+		},
+		{
+			name:          "incomplete bundle",
+			details:       []models.TransactionDetail{line(1, 1, 10000)},
+			promotions:    []models.Promotion{bundle(1, 15000, models.PromotionItem{ProductID: 1, Quantity: 1}, models.PromotionItem{ProductID: 2, Quantity: 1})},
+			wantDiscounts: []int{0},
+			wantApplied:   map[int]int{},
+		},
+		{
+			name:          "bundle not cheaper than its items",
+			details:       []models.TransactionDetail{line(1, 1, 10000), line(2, 1, 8000)},
+			promotions:    []models.Promotion{bundle(1, 18000, models.PromotionItem{ProductID: 1, Quantity: 1}, models.PromotionItem{ProductID: 2, Quantity: 1})},
+			wantDiscounts: []int{0, 0},
+			wantApplied:   map[int]int{},
+		},
+		{
+			name:          "bundle split over several lines of one product",
+			details:       []models.TransactionDetail{line(3, 1, 1000), line(3, 1, 1000), line(3, 1, 1000)},
+			promotions:    []models.Promotion{bundle(1, 2000, models.PromotionItem{ProductID: 3, Quantity: 3})},
+			wantDiscounts: []int{333, 333, 334},
+			wantApplied:   map[int]int{1: 1000},
+		},
+		{
+			name:    "units used by a bundle are not used again",
+			details: []models.TransactionDetail{line(3, 3, 1000)},
+			promotions: []models.Promotion{
+				bundle(1, 2000, models.PromotionItem{ProductID: 3, Quantity: 3}),
+				buyGet(2, 3, 2, 1, nil),
+				percent(3, models.ScopeProduct, intPtr(3), 50),
+			},
+			wantDiscounts: []int{1000},
+			wantApplied:   map[int]int{1: 1000},
+		},
+		{
+			name:    "promotion outside its hours",
+			details: []models.TransactionDetail{line(1, 1, 10000)},
+			promotions: []models.Promotion{{ID: 1, Type: models.PromotionPercentage, Scope: models.ScopeProduct,
+				ProductID: intPtr(1), Value: 10, StartTime: "16:00", EndTime: "18:00"}},
+			wantDiscounts: []int{0},
+			wantApplied:   map[int]int{},
+		},
+	}
+
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &models.Transaction{Details: tt.details}
+			subtotal := 0
+			for _, d := range tt.details {
+				subtotal += d.Subtotal
+			}
+
+			applyPromotions(transaction, products, tt.promotions, now)
+
+			discount := 0
+			for i, d := range transaction.Details {
+				if d.Discount != tt.wantDiscounts[i] {
+					t.Errorf("line %d discount = %d, want %d", i, d.Discount, tt.wantDiscounts[i])
+				}
+				discount += tt.wantDiscounts[i]
+			}
+			if transaction.Discount != discount || transaction.Total != subtotal-discount {
+				t.Errorf("discount/total = %d/%d, want %d/%d", transaction.Discount, transaction.Total, discount, subtotal-discount)
+			}
+			if len(transaction.Promotions) != len(tt.wantApplied) {
+				t.Errorf("applied %d promotions, want %d", len(transaction.Promotions), len(tt.wantApplied))
+			}
+			for _, a := range transaction.Promotions {
+				if want, ok := tt.wantApplied[*a.PromotionID]; !ok || a.Discount != want {
+					t.Errorf("promotion %d discount = %d, want %d", *a.PromotionID, a.Discount, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInWindow(t *testing.T) {
+	// Rabu, 14 Oktober 2026
+	wednesday := func(hour, minute int) time.Time {
+		return time.Date(2026, 10, 14, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		promo models.Promotion
+		now   time.Time
+		want  bool
+	}{
+		{"no restriction", models.Promotion{}, wednesday(3, 0), true},
+		{"on listed day", models.Promotion{DaysOfWeek: []int{1, 3}}, wednesday(12, 0), true},
+		{"on other day", models.Promotion{DaysOfWeek: []int{0, 6}}, wednesday(12, 0), false},
+		{"at start time", models.Promotion{StartTime: "16:00", EndTime: "18:00"}, wednesday(16, 0), true},
+		{"at end time", models.Promotion{StartTime: "16:00", EndTime: "18:00"}, wednesday(18, 0), false},
+		{"before midnight in overnight window", models.Promotion{StartTime: "22:00", EndTime: "02:00"}, wednesday(23, 30), true},
+		{"after midnight in overnight window", models.Promotion{StartTime: "22:00", EndTime: "02:00"}, wednesday(1, 59), true},
+		{"outside overnight window", models.Promotion{StartTime: "22:00", EndTime: "02:00"}, wednesday(2, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inWindow(tt.promo, tt.now); got != tt.want {
+				t.Errorf("inWindow = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+	"time"
+)
+
+type PromotionService struct {
+	repo *repositories.PromotionRepository
+}
+
+func NewPromotionService(repo *repositories.PromotionRepository) *PromotionService {
+	return &PromotionService{repo: repo}
+}
+
+func (s *PromotionService) GetAll() ([]models.Promotion, error) {
+	return s.repo.GetAll()
+}
+
+func (s *PromotionService) GetByID(id int) (*models.Promotion, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *PromotionService) Create(promotion *models.Promotion) error {
+	if err := validatePromotion(promotion); err != nil {
+		return err
+	}
+	return s.repo.Create(promotion)
+}
+
+func (s *PromotionService) Update(promotion *models.Promotion) error {
+	if err := validatePromotion(promotion); err != nil {
+		return err
+	}
+	return s.repo.Update(promotion)
+}
+
+func (s *PromotionService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+// validatePromotion memastikan field yang dipakai tiap tipe promosi terisi
+// dan mengosongkan field yang tidak relevan.
+func validatePromotion(p *models.Promotion) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return models.NewValidationError("name is required")
+	}
+	if p.Value < 0 {
+		return models.NewValidationError("value must not be negative")
+	}
+	if p.MinSubtotal < 0 {
+		return models.NewValidationError("min_subtotal must not be negative")
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.StartsAt.Before(*p.EndsAt) {
+		return models.NewValidationError("starts_at must be before ends_at")
+	}
+	for _, day := range p.DaysOfWeek {
+		if day < 0 || day > 6 {
+			return models.NewValidationError("days_of_week must be between 0 (Sunday) and 6 (Saturday)")
+		}
+	}
+	if (p.StartTime == "") != (p.EndTime == "") {
+		return models.NewValidationError("start_time and end_time must be set together")
+	}
+	if p.StartTime != "" {
+		for _, clock := range []string{p.StartTime, p.EndTime} {
+			if _, err := time.Parse("15:04", clock); err != nil {
+				return models.NewValidationError("invalid time %q, expected HH:MM", clock)
+			}
+		}
+		if p.StartTime == p.EndTime {
+			return models.NewValidationError("start_time and end_time must differ")
+		}
+	}
+
+	switch p.Type {
+	case models.PromotionPercentage, models.PromotionFixed:
+		if p.Value <= 0 {
+			return models.NewValidationError("value must be greater than 0")
+		}
+		if p.Type == models.PromotionPercentage && p.Value > 100 {
+			return models.NewValidationError("percentage value must not exceed 100")
+		}
+		if p.Scope == "" {
+			p.Scope = models.ScopeCart
+		}
+		switch p.Scope {
+		case models.ScopeCart:
+			p.ProductID, p.CategoryID = nil, nil
+		case models.ScopeProduct:
+			if p.ProductID == nil {
+				return models.NewValidationError("product_id is required for product scope")
+			}
+			p.CategoryID = nil
+		case models.ScopeCategory:
+			if p.CategoryID == nil {
+				return models.NewValidationError("category_id is required for category scope")
+			}
+			p.ProductID = nil
+		default:
+			return models.NewValidationError("scope must be one of cart, product, category")
+		}
+		p.BuyQuantity, p.GetQuantity, p.GetProductID, p.Items = 0, 0, nil, nil
+
+	case models.PromotionBuyXGetY:
+		if p.ProductID == nil {
+			return models.NewValidationError("product_id is required for buy_x_get_y")
+		}
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return models.NewValidationError("buy_quantity and get_quantity must be greater than 0")
+		}
+		if p.GetProductID == nil {
+			p.GetProductID = p.ProductID
+		}
+		p.Scope, p.CategoryID, p.Value, p.MinSubtotal, p.Items = models.ScopeProduct, nil, 0, 0, nil
+
+	case models.PromotionBundle:
+		if len(p.Items) < 2 && (len(p.Items) == 0 || p.Items[0].Quantity < 2) {
+			return models.NewValidationError("bundle needs at least two units across its items")
+		}
+		seen := make(map[int]bool, len(p.Items))
+		for _, item := range p.Items {
+			if item.Quantity <= 0 {
+				return models.NewValidationError("quantity for bundle product %d must be greater than 0", item.ProductID)
+			}
+			if seen[item.ProductID] {
+				return models.NewValidationError("product %d is listed twice in the bundle", item.ProductID)
+			}
+			seen[item.ProductID] = true
+		}
+		p.Scope, p.ProductID, p.CategoryID, p.MinSubtotal = models.ScopeProduct, nil, nil, 0
+		p.BuyQuantity, p.GetQuantity, p.GetProductID = 0, 0, nil
+
+	default:
+		return models.NewValidationError("type must be one of percentage, fixed, buy_x_get_y, bundle")
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+	"time"
+)
+
+type PurchaseOrderService struct {
+	repo         *repositories.PurchaseOrderRepository
+	supplierRepo *repositories.SupplierRepository
+	productRepo  *repositories.ProductRepository
+}
+
+func NewPurchaseOrderService(repo *repositories.PurchaseOrderRepository, supplierRepo *repositories.SupplierRepository, productRepo *repositories.ProductRepository) *PurchaseOrderService {
+	return &PurchaseOrderService{repo: repo, supplierRepo: supplierRepo, productRepo: productRepo}
+}
+
+func (s *PurchaseOrderService) GetAll(status string, supplierID *int) ([]models.PurchaseOrder, error) {
+	switch status {
+	case "", models.PurchaseOrderOpen, models.PurchaseOrderPartial, models.PurchaseOrderReceived, models.PurchaseOrderCancelled:
+	default:
+		return nil, models.NewValidationError("status must be one of open, partial, received, cancelled")
+	}
+	return s.repo.GetAll(status, supplierID)
+}
+
+func (s *PurchaseOrderService) GetByID(id int) (*models.PurchaseOrder, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create membuat PO ke supplier aktif. Setiap baris boleh memesan dalam satuan
+// lain produk (mis. karton) dengan harga beli per satuan itu. Total dihitung
+// dari qty x harga beli tiap baris; stok baru bertambah, dalam satuan dasar,
+// saat barang diterima.
+func (s *PurchaseOrderService) Create(po *models.PurchaseOrder, user *models.User) (*models.PurchaseOrder, error) {
+	supplier, err := s.supplierRepo.GetByID(po.SupplierID)
+	if errors.Is(err, models.ErrSupplierNotFound) {
+		return nil, models.NewValidationError("supplier %d not found", po.SupplierID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !supplier.Active {
+		return nil, models.NewValidationError("supplier %d is inactive", po.SupplierID)
+	}
+
+	po.Note = strings.TrimSpace(po.Note)
+	if po.ExpectedDate != nil {
+		if _, err := time.Parse("2006-01-02", *po.ExpectedDate); err != nil {
+			return nil, models.NewValidationError("invalid expected_date %q, expected YYYY-MM-DD", *po.ExpectedDate)
+		}
+	}
+	if len(po.Lines) == 0 {
+		return nil, models.NewValidationError("lines must not be empty")
+	}
+
+	po.Total = 0
+	seen := make(map[int]bool, len(po.Lines))
+	for i := range po.Lines {
+		l := &po.Lines[i]
+		if l.Quantity <= 0 {
+			return nil, models.NewValidationError("quantity for product %d must be positive", l.ProductID)
+		}
+		if l.UnitCost < 0 {
+			return nil, models.NewValidationError("unit_cost for product %d must not be negative", l.ProductID)
+		}
+		if seen[l.ProductID] {
+			return nil, models.NewValidationError("product %d appears more than once", l.ProductID)
+		}
+		seen[l.ProductID] = true
+
+		product, err := s.productRepo.GetByID(l.ProductID)
+		if errors.Is(err, models.ErrProductNotFound) {
+			return nil, models.NewValidationError("product %d not found", l.ProductID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if product.HasVariants {
+			return nil, models.NewValidationError("product %d has variants; order one of its variants instead", l.ProductID)
+		}
+		u, ok := findUnit(unitsOf(product), l.Unit)
+		if !ok {
+			return nil, models.NewValidationError("product %d has no unit %q", l.ProductID, l.Unit)
+		}
+		if _, err := baseQuantity(product, l.Quantity, u); err != nil {
+			return nil, err
+		}
+		l.Unit, l.UnitFactor = u.Name, u.Factor
+		l.ID, l.ReceivedQuantity = 0, 0
+		amount, ok := l.Quantity.Amount(l.UnitCost)
+		if !ok {
+			return nil, models.NewValidationError("value of %s %s of product %d is too large", l.Quantity, l.Unit, l.ProductID)
+		}
+		po.Total += amount
+	}
+
+	po.CreatedBy = userID(user)
+	if err := s.repo.Create(po); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(po.ID)
+}
+
+// Receive mencatat penerimaan barang (boleh sebagian) untuk PO id dan
+// mengembalikan PO terbaru.
+func (s *PurchaseOrderService) Receive(id int, receipt *models.GoodsReceipt, user *models.User) (*models.PurchaseOrder, error) {
+	receipt.Note = strings.TrimSpace(receipt.Note)
+	if len(receipt.Lines) == 0 {
+		return nil, models.NewValidationError("lines must not be empty")
+	}
+
+	seen := make(map[int]bool, len(receipt.Lines))
+	for _, l := range receipt.Lines {
+		if l.Quantity <= 0 {
+			return nil, models.NewValidationError("quantity for line %d must be positive", l.PurchaseOrderLineID)
+		}
+		if l.UnitCost != nil && *l.UnitCost < 0 {
+			return nil, models.NewValidationError("unit_cost for line %d must not be negative", l.PurchaseOrderLineID)
+		}
+		if seen[l.PurchaseOrderLineID] {
+			return nil, models.NewValidationError("line %d appears more than once", l.PurchaseOrderLineID)
+		}
+		seen[l.PurchaseOrderLineID] = true
+	}
+
+	receipt.PurchaseOrderID = id
+	receipt.ReceivedBy = userID(user)
+	if err := s.repo.Receive(receipt); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *PurchaseOrderService) Cancel(id int) (*models.PurchaseOrder, error) {
+	if err := s.repo.Cancel(id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
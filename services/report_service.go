@@ -0,0 +1,357 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"math"
+	"time"
+)
+
+const (
+	defaultTopProducts = 5
+	maxTopProducts     = 50
+	maxReportBuckets   = 400
+
+	defaultTopCustomers = 20
+	maxTopCustomers     = 100
+)
+
+type ReportService struct {
+	repo *repositories.ReportRepository
+	day  *BusinessDay
+}
+
+func NewReportService(repo *repositories.ReportRepository, day *BusinessDay) *ReportService {
+	return &ReportService{repo: repo, day: day}
+}
+
+// GetDailyReport mengembalikan ringkasan hari bisnis yang sedang berjalan pada
+// waktu now beserta top-N produk. Dengan rollup, varian dihitung sebagai
+// produk induknya.
+func (s *ReportService) GetDailyReport(now time.Time, top int, rollup bool) (*models.DailyReport, error) {
+	date := s.day.DateOf(now)
+	start, end := s.day.Range(date, date)
+	return s.getSummary(start, end, top, rollup)
+}
+
+// GetSalesReport mengembalikan laporan tanggal bisnis from sampai to
+// (inklusif) yang dipecah per hari, minggu (Senin) atau bulan. Periode tanpa
+// penjualan tetap muncul dengan nilai nol supaya grafik tidak bolong.
+func (s *ReportService) GetSalesReport(from, to time.Time, groupBy string, top int, rollup bool) (*models.SalesReport, error) {
+	if groupBy == "" {
+		groupBy = models.GroupByDay
+	}
+	if groupBy != models.GroupByDay && groupBy != models.GroupByWeek && groupBy != models.GroupByMonth {
+		return nil, models.NewValidationError("group_by must be one of day, week, month")
+	}
+	if from.After(to) {
+		return nil, models.NewValidationError("start_date must not be after end_date")
+	}
+
+	start, end := s.day.Range(from, to)
+	summary, err := s.getSummary(start, end, top, rollup)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.repo.GetPeriodTotals(start, end, groupBy, s.day.Location.String(), s.day.Cutoff, rollup)
+	if err != nil {
+		return nil, err
+	}
+	byPeriod := make(map[string]models.DailyReport, len(totals))
+	for _, t := range totals {
+		byPeriod[t.PeriodStart.Format("2006-01-02")] = t
+	}
+
+	periods := make([]models.DailyReport, 0)
+	for bucket := truncatePeriod(from, groupBy); !bucket.After(to); bucket = nextPeriod(bucket, groupBy) {
+		if len(periods) == maxReportBuckets {
+			return nil, models.NewValidationError("date range too large for group_by=%s, use a larger grouping", groupBy)
+		}
+
+		report := byPeriod[bucket.Format("2006-01-02")]
+		report.PeriodStart = s.day.Start(latest(bucket, from))
+		report.PeriodEnd = s.day.Start(earliest(nextPeriod(bucket, groupBy), to.AddDate(0, 0, 1)))
+		periods = append(periods, report)
+	}
+
+	return &models.SalesReport{
+		GroupBy: groupBy,
+		Summary: *summary,
+		Periods: periods,
+	}, nil
+}
+
+// GetPromotionReport merangkum diskon tiap promosi untuk tanggal bisnis from
+// sampai to (inklusif).
+func (s *ReportService) GetPromotionReport(from, to time.Time) (*models.PromotionReport, error) {
+	if from.After(to) {
+		return nil, models.NewValidationError("start_date must not be after end_date")
+	}
+
+	start, end := s.day.Range(from, to)
+	usage, err := s.repo.GetPromotionUsage(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.PromotionReport{PeriodStart: start, PeriodEnd: end, Promotions: usage}
+	for _, u := range usage {
+		report.TotalDiscount += u.TotalDiscount
+	}
+	return report, nil
+}
+
+// GetModifierReport mengembalikan modifier yang paling sering dipilih untuk
+// tanggal bisnis from sampai to (inklusif).
+func (s *ReportService) GetModifierReport(from, to time.Time) (*models.ModifierReport, error) {
+	if from.After(to) {
+		return nil, models.NewValidationError("start_date must not be after end_date")
+	}
+
+	start, end := s.day.Range(from, to)
+	usage, err := s.repo.GetModifierUsage(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ModifierReport{PeriodStart: start, PeriodEnd: end, Modifiers: usage}, nil
+}
+
+// GetTaxReport merangkum pajak keluaran per tarif untuk tanggal bisnis from
+// sampai to (inklusif), mis. untuk pelaporan PPN bulanan.
+func (s *ReportService) GetTaxReport(from, to time.Time) (*models.TaxReport, error) {
+	if from.After(to) {
+		return nil, models.NewValidationError("start_date must not be after end_date")
+	}
+
+	start, end := s.day.Range(from, to)
+	summaries, err := s.repo.GetTaxSummary(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.TaxReport{PeriodStart: start, PeriodEnd: end, Rates: make([]models.TaxSummary, 0)}
+	for _, t := range summaries {
+		if t.Name == "" {
+			report.NonTaxableAmount += t.TaxableAmount
+			continue
+		}
+		report.TaxableAmount += t.TaxableAmount
+		report.Tax += t.Tax
+		report.Rates = append(report.Rates, t)
+	}
+	return report, nil
+}
+
+// GetOutstandingPurchaseReport mengembalikan sisa barang PO yang belum
+// diterima beserta nilainya pada harga beli PO.
+func (s *ReportService) GetOutstandingPurchaseReport(supplierID *int) (*models.OutstandingPurchaseReport, error) {
+	lines, err := s.repo.GetOutstandingPurchases(supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.OutstandingPurchaseReport{Lines: lines}
+	for _, l := range lines {
+		report.TotalValue += l.OutstandingValue
+	}
+	return report, nil
+}
+
+// GetSupplierPurchaseReport merangkum barang yang diterima dari supplier untuk
+// tanggal bisnis from sampai to (inklusif).
+func (s *ReportService) GetSupplierPurchaseReport(from, to time.Time, supplierID *int) (*models.SupplierPurchaseReport, error) {
+	if from.After(to) {
+		return nil, models.NewValidationError("start_date must not be after end_date")
+	}
+
+	start, end := s.day.Range(from, to)
+	suppliers, err := s.repo.GetSupplierPurchases(start, end, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.SupplierPurchaseReport{PeriodStart: start, PeriodEnd: end, Suppliers: suppliers}
+	for _, sp := range suppliers {
+		report.TotalValue += sp.TotalValue
+	}
+	return report, nil
+}
+
+// GetCustomerReport merangkum belanja pelanggan terdaftar untuk tanggal bisnis
+// from sampai to (inklusif): porsi penjualan member dan top-N pelanggan
+// menurut belanja bersih, dengan nilai seumur hidup dan tier-nya saat ini.
+func (s *ReportService) GetCustomerReport(from, to time.Time, top int) (*models.CustomerReport, error) {
+	if from.After(to) {
+		return nil, models.NewValidationError("start_date must not be after end_date")
+	}
+	if top < 1 {
+		top = defaultTopCustomers
+	}
+	if top > maxTopCustomers {
+		top = maxTopCustomers
+	}
+
+	start, end := s.day.Range(from, to)
+	report, err := s.repo.GetCustomerTotals(start, end)
+	if err != nil {
+		return nil, err
+	}
+	if report.Customers, err = s.repo.GetCustomerValues(start, end, top); err != nil {
+		return nil, err
+	}
+	report.PeriodStart, report.PeriodEnd = start, end
+	return report, nil
+}
+
+// GetReceivablesReport mengembalikan posisi kasbon semua pelanggan pada
+// hari bisnis now: total utang dan saldo titipan, serta umur utang per
+// pelanggan dalam bucket 0-30, 31-60 dan lebih dari 60 hari.
+func (s *ReportService) GetReceivablesReport(now time.Time) (*models.ReceivablesReport, error) {
+	customers, err := s.repo.GetReceivables()
+	if err != nil {
+		return nil, err
+	}
+	charges, err := s.repo.GetOpenCharges()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ReceivablesReport{AsOf: s.day.DateOf(now), Customers: customers}
+	byCustomer := make(map[int][]models.OpenCharge)
+	for _, c := range charges {
+		byCustomer[c.CustomerID] = append(byCustomer[c.CustomerID], c)
+	}
+	for i := range report.Customers {
+		c := &report.Customers[i]
+		if c.Balance < 0 {
+			report.StoreCredit -= c.Balance
+			continue
+		}
+		report.Total += c.Balance
+		open := byCustomer[c.CustomerID]
+		if len(open) > 0 {
+			c.OldestChargeAt = &open[0].ChargedAt
+		}
+		c.Aging = ageCharges(open, report.AsOf, s.day)
+		report.Aging.Days0To30 += c.Aging.Days0To30
+		report.Aging.Days31To60 += c.Aging.Days31To60
+		report.Aging.Over60 += c.Aging.Over60
+	}
+	return report, nil
+}
+
+// GetMarginReport menghitung laba kotor per produk, produk induk (varian
+// digabung), kategori atau hari untuk
+// tanggal bisnis from sampai to (inklusif). Harga pokok diambil dari salinan
+// di setiap baris transaksi, jadi perubahan harga beli tidak mengubah laporan
+// lama.
+func (s *ReportService) GetMarginReport(from, to time.Time, groupBy string) (*models.MarginReport, error) {
+	if groupBy == "" {
+		groupBy = models.GroupByProduct
+	}
+	switch groupBy {
+	case models.GroupByProduct, models.GroupByParent, models.GroupByCategory, models.GroupByDay:
+	default:
+		return nil, models.NewValidationError("group_by must be one of product, parent, category, day")
+	}
+	if from.After(to) {
+		return nil, models.NewValidationError("start_date must not be after end_date")
+	}
+
+	start, end := s.day.Range(from, to)
+	lines, err := s.repo.GetMargin(start, end, groupBy, s.day.Location.String(), s.day.Cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.MarginReport{PeriodStart: start, PeriodEnd: end, GroupBy: groupBy, Lines: lines}
+	for i := range lines {
+		l := &lines[i]
+		if l.Date != nil {
+			date := s.day.Start(*l.Date)
+			l.Date = &date
+		}
+		l.MarginPercent = marginPercent(l.GrossProfit, l.NetSales)
+		report.NetSales += l.NetSales
+		report.Cost += l.Cost
+		report.GrossProfit += l.GrossProfit
+	}
+	report.MarginPercent = marginPercent(report.GrossProfit, report.NetSales)
+	return report, nil
+}
+
+// marginPercent adalah laba kotor terhadap penjualan bersih, dua desimal.
+func marginPercent(profit, sales int) float64 {
+	if sales == 0 {
+		return 0
+	}
+	return math.Round(float64(profit)*10000/float64(sales)) / 100
+}
+
+func (s *ReportService) getSummary(start, end time.Time, top int, rollup bool) (*models.DailyReport, error) {
+	if top < 1 {
+		top = defaultTopProducts
+	}
+	if top > maxTopProducts {
+		top = maxTopProducts
+	}
+
+	report, err := s.repo.GetSummary(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	topProducts, err := s.repo.GetTopProducts(start, end, top, rollup)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.repo.GetPaymentBreakdown(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	report.TopProducts = topProducts
+	report.Payments = payments
+	if len(topProducts) > 0 {
+		report.ProdukTerlaris = topProducts[0]
+	}
+	return report, nil
+}
+
+// truncatePeriod mengikuti date_trunc PostgreSQL: minggu dimulai hari Senin.
+func truncatePeriod(t time.Time, groupBy string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch groupBy {
+	case models.GroupByWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case models.GroupByMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return day
+}
+
+func nextPeriod(t time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case models.GroupByWeek:
+		return t.AddDate(0, 0, 7)
+	case models.GroupByMonth:
+		return t.AddDate(0, 1, 0)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+	"time"
+)
+
+type ShiftService struct {
+	repo *repositories.ShiftRepository
+}
+
+func NewShiftService(repo *repositories.ShiftRepository) *ShiftService {
+	return &ShiftService{repo: repo}
+}
+
+// isManager menandakan user boleh melihat dan menutup shift kasir lain.
+func isManager(user *models.User) bool {
+	if user == nil {
+		return false
+	}
+	for _, role := range models.ManagerRoles {
+		if user.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// List mengembalikan satu halaman shift, terbaru dulu. Kasir hanya melihat
+// shift miliknya sendiri.
+func (s *ShiftService) List(filter models.ShiftFilter, user *models.User) (*models.ShiftList, error) {
+	filter.Page, filter.PageSize = normalizePage(filter.Page, filter.PageSize)
+	if filter.Status != "" && filter.Status != models.ShiftOpen && filter.Status != models.ShiftClosed {
+		return nil, models.NewValidationError("status must be open or closed")
+	}
+	if !isManager(user) {
+		filter.CashierID = userID(user)
+	}
+
+	shifts, total, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ShiftList{Data: shifts, Pagination: newPagination(filter.Page, filter.PageSize, total)}, nil
+}
+
+// GetByID mengembalikan shift; shift kasir lain tidak terlihat oleh kasir.
+func (s *ShiftService) GetByID(id int, user *models.User) (*models.Shift, error) {
+	shift, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !isManager(user) && (user == nil || shift.CashierID != user.ID) {
+		return nil, models.ErrShiftNotFound
+	}
+	return shift, nil
+}
+
+// Current mengembalikan shift yang sedang dibuka oleh user.
+func (s *ShiftService) Current(user *models.User) (*models.Shift, error) {
+	if user == nil {
+		return nil, models.ErrShiftNotFound
+	}
+	return s.repo.GetOpen(user.ID)
+}
+
+// Open membuka shift untuk user dengan modal awal di laci.
+func (s *ShiftService) Open(req models.OpenShiftRequest, user *models.User) (*models.Shift, error) {
+	if user == nil {
+		return nil, models.ErrUnauthorized
+	}
+	if req.OpeningFloat < 0 {
+		return nil, models.NewValidationError("opening_float must not be negative")
+	}
+	shift := &models.Shift{
+		CashierID:    user.ID,
+		CashierName:  user.Name,
+		OpeningFloat: req.OpeningFloat,
+		Note:         strings.TrimSpace(req.Note),
+	}
+	if err := s.repo.Open(shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// AddCashMovement mencatat kas kecil yang masuk atau keluar dari laci.
+func (s *ShiftService) AddCashMovement(id int, req models.CashMovementRequest, user *models.User) (*models.CashMovement, error) {
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Type != models.CashIn && req.Type != models.CashOut {
+		return nil, models.NewValidationError("type must be cash_in or cash_out")
+	}
+	if req.Amount <= 0 {
+		return nil, models.NewValidationError("amount must be greater than 0")
+	}
+	if req.Reason == "" {
+		return nil, models.NewValidationError("reason is required")
+	}
+	if _, err := s.GetByID(id, user); err != nil {
+		return nil, err
+	}
+
+	movement := &models.CashMovement{
+		ShiftID: id,
+		Type:    req.Type,
+		Amount:  req.Amount,
+		Reason:  req.Reason,
+		UserID:  userID(user),
+	}
+	if err := s.repo.AddCashMovement(movement); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// Close menutup shift dengan uang yang dihitung per pecahan dan mengembalikan
+// laporan Z-nya.
+func (s *ShiftService) Close(id int, req models.CloseShiftRequest, user *models.User) (*models.ShiftReport, error) {
+	seen := make(map[int]bool, len(req.Denominations))
+	for _, d := range req.Denominations {
+		if d.Denomination <= 0 {
+			return nil, models.NewValidationError("denomination must be greater than 0")
+		}
+		if d.Count < 0 {
+			return nil, models.NewValidationError("count for denomination %d must not be negative", d.Denomination)
+		}
+		if seen[d.Denomination] {
+			return nil, models.NewValidationError("denomination %d is listed twice", d.Denomination)
+		}
+		seen[d.Denomination] = true
+	}
+	if _, err := s.GetByID(id, user); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Close(id, req.Denominations, strings.TrimSpace(req.Note), userID(user)); err != nil {
+		return nil, err
+	}
+	return s.ZReport(id, user)
+}
+
+// XReport adalah laporan sementara shift yang masih berjalan.
+func (s *ShiftService) XReport(id int, user *models.User) (*models.ShiftReport, error) {
+	shift, err := s.GetByID(id, user)
+	if err != nil {
+		return nil, err
+	}
+	if shift.Status != models.ShiftOpen {
+		return nil, models.ErrShiftClosed
+	}
+	return s.report(models.ShiftReportX, shift)
+}
+
+// ZReport adalah laporan akhir shift yang sudah ditutup, dengan uang yang
+// dihitung dan selisihnya.
+func (s *ShiftService) ZReport(id int, user *models.User) (*models.ShiftReport, error) {
+	shift, err := s.GetByID(id, user)
+	if err != nil {
+		return nil, err
+	}
+	if shift.Status != models.ShiftClosed {
+		return nil, models.ErrShiftOpen
+	}
+	return s.report(models.ShiftReportZ, shift)
+}
+
+func (s *ShiftService) report(reportType string, shift *models.Shift) (*models.ShiftReport, error) {
+	report := &models.ShiftReport{Type: reportType, Shift: *shift, GeneratedAt: time.Now()}
+	if err := s.repo.GetTotals(report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
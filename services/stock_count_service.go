@@ -0,0 +1,118 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+)
+
+type StockCountService struct {
+	repo        *repositories.StockCountRepository
+	productRepo *repositories.ProductRepository
+}
+
+func NewStockCountService(repo *repositories.StockCountRepository, productRepo *repositories.ProductRepository) *StockCountService {
+	return &StockCountService{repo: repo, productRepo: productRepo}
+}
+
+func (s *StockCountService) GetAll() ([]models.StockCount, error) {
+	return s.repo.GetAll()
+}
+
+// GetByID mengembalikan sesi beserta selisih per produk dan nilainya pada
+// harga pokok.
+func (s *StockCountService) GetByID(id int) (*models.StockCount, error) {
+	count, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	count.Lines, err = s.repo.GetLines(count)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range count.Lines {
+		if l.VarianceValue < 0 {
+			count.ShortageValue += l.VarianceValue
+		} else {
+			count.SurplusValue += l.VarianceValue
+		}
+	}
+	count.VarianceValue = count.ShortageValue + count.SurplusValue
+	return count, nil
+}
+
+// Open membuka sesi stock opname baru.
+func (s *StockCountService) Open(note string, user *models.User) (*models.StockCount, error) {
+	count := &models.StockCount{Note: strings.TrimSpace(note), OpenedBy: userID(user)}
+	if err := s.repo.Create(count); err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// RecordItems menyimpan hasil hitung dari satu perangkat. Qty yang dihitung
+// dalam satuan lain (mis. karton) disimpan dalam satuan dasar.
+func (s *StockCountService) RecordItems(id int, entry models.StockCountEntry, user *models.User) error {
+	entry.Device = strings.TrimSpace(entry.Device)
+	if len(entry.Items) == 0 {
+		return models.NewValidationError("items are required")
+	}
+	seen := make(map[int]bool, len(entry.Items))
+	ids := make([]int, 0, len(entry.Items))
+	for _, item := range entry.Items {
+		if item.ProductID <= 0 {
+			return models.NewValidationError("invalid product_id %d", item.ProductID)
+		}
+		if item.Quantity < 0 {
+			return models.NewValidationError("quantity for product %d must not be negative", item.ProductID)
+		}
+		if seen[item.ProductID] {
+			return models.NewValidationError("product %d is listed twice", item.ProductID)
+		}
+		seen[item.ProductID] = true
+		ids = append(ids, item.ProductID)
+	}
+
+	products, err := s.productRepo.GetByIDs(ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*models.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+	for i := range entry.Items {
+		item := &entry.Items[i]
+		product, ok := byID[item.ProductID]
+		if !ok {
+			return models.NewValidationError("product %d not found", item.ProductID)
+		}
+		u, ok := findUnit(unitsOf(product), item.Unit)
+		if !ok {
+			return models.NewValidationError("product %d has no unit %q", item.ProductID, item.Unit)
+		}
+		base, err := baseQuantity(product, item.Quantity, u)
+		if err != nil {
+			return err
+		}
+		item.Quantity, item.Unit = base, product.Unit
+	}
+	return s.repo.SaveItems(id, entry, userID(user))
+}
+
+// Post membukukan selisih sebagai penyesuaian stok dan menutup sesi. Produk
+// yang tidak dihitung tidak diubah.
+func (s *StockCountService) Post(id int, user *models.User) (*models.StockCount, error) {
+	if err := s.repo.Post(id, userID(user)); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
+
+func (s *StockCountService) Cancel(id int, user *models.User) (*models.StockCount, error) {
+	if err := s.repo.Cancel(id, userID(user)); err != nil {
+		return nil, err
+	}
+	return s.GetByID(id)
+}
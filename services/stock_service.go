@@ -0,0 +1,131 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+)
+
+type StockService struct {
+	repo        *repositories.StockMovementRepository
+	productRepo *repositories.ProductRepository
+	day         *BusinessDay
+}
+
+func NewStockService(repo *repositories.StockMovementRepository, productRepo *repositories.ProductRepository, day *BusinessDay) *StockService {
+	return &StockService{repo: repo, productRepo: productRepo, day: day}
+}
+
+// ListMovements mengembalikan riwayat stok satu produk beserta saldo setelah
+// setiap entri. StartDate dan EndDate adalah tanggal bisnis inklusif.
+func (s *StockService) ListMovements(filter models.StockMovementFilter) (*models.StockMovementList, error) {
+	if _, err := s.productRepo.GetByID(filter.ProductID); err != nil {
+		return nil, err
+	}
+	filter.Page, filter.PageSize = normalizePage(filter.Page, filter.PageSize)
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return nil, models.NewValidationError("start_date must not be after end_date")
+	}
+	if filter.Type != "" && !isValidStockType(filter.Type) {
+		return nil, models.NewValidationError("unknown movement type %q", filter.Type)
+	}
+
+	if filter.StartDate != nil {
+		start := s.day.Start(*filter.StartDate)
+		filter.StartDate = &start
+	}
+	if filter.EndDate != nil {
+		end := s.day.Start(filter.EndDate.AddDate(0, 0, 1))
+		filter.EndDate = &end
+	}
+
+	movements, total, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StockMovementList{
+		Data:       movements,
+		Pagination: newPagination(filter.Page, filter.PageSize, total),
+	}, nil
+}
+
+// RecordMovement mencatat pergerakan stok manual. Quantity bertanda dalam
+// satuan dasar produk: barang masuk positif, keluar negatif. Penjualan, void dan retur hanya tercatat
+// lewat transaksi.
+func (s *StockService) RecordMovement(movement *models.StockMovement, user *models.User) error {
+	movement.Note = strings.TrimSpace(movement.Note)
+	if movement.Quantity == 0 {
+		return models.NewValidationError("quantity must not be zero")
+	}
+
+	switch movement.Type {
+	case models.StockPurchase:
+		if movement.Quantity < 0 {
+			return models.NewValidationError("purchase quantity must be positive")
+		}
+	case models.StockWaste:
+		if movement.Quantity > 0 {
+			return models.NewValidationError("waste quantity must be negative")
+		}
+	case models.StockAdjustment, models.StockTransfer:
+	default:
+		return models.NewValidationError("type must be one of purchase, adjustment, transfer, waste")
+	}
+	if movement.Type != models.StockPurchase && movement.Note == "" {
+		return models.NewValidationError("note is required for %s", movement.Type)
+	}
+
+	product, err := s.productRepo.GetByID(movement.ProductID)
+	if err != nil {
+		return err
+	}
+	if product.HasVariants {
+		return models.NewValidationError("product %d has variants; record the movement on a variant", product.ID)
+	}
+	if !movement.Quantity.Fits(product.Decimals) {
+		return models.NewValidationError("quantity of %s may have at most %d decimals", product.Name, product.Decimals)
+	}
+
+	movement.ReferenceType, movement.ReferenceID = "", nil
+	movement.UserID = userID(user)
+	return s.repo.Create(movement)
+}
+
+// LowStock mengembalikan produk di bawah stok minimum beserta saran qty pesan.
+func (s *StockService) LowStock() ([]models.LowStockItem, error) {
+	items, err := s.productRepo.GetLowStock()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		item := &items[i]
+		need := item.ReorderQuantity
+		if shortfall := item.MinStock - item.Stock; shortfall > need {
+			need = shortfall
+		}
+		if need -= item.OnOrder; need > 0 {
+			item.SuggestedOrder = need
+		}
+	}
+	return items, nil
+}
+
+func isValidStockType(t string) bool {
+	switch t {
+	case models.StockOpening, models.StockSale, models.StockVoid, models.StockReturn,
+		models.StockPurchase, models.StockAdjustment, models.StockTransfer, models.StockWaste:
+		return true
+	}
+	return false
+}
+
+// userID mengembalikan ID user untuk dicatat di ledger, nil jika tidak ada.
+func userID(user *models.User) *int {
+	if user == nil {
+		return nil
+	}
+	id := user.ID
+	return &id
+}
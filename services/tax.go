@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"math"
+	"strings"
+)
+
+type TaxRateService struct {
+	repo *repositories.TaxRateRepository
+}
+
+func NewTaxRateService(repo *repositories.TaxRateRepository) *TaxRateService {
+	return &TaxRateService{repo: repo}
+}
+
+func (s *TaxRateService) GetAll() ([]models.TaxRate, error) {
+	return s.repo.GetAll()
+}
+
+func (s *TaxRateService) GetByID(id int) (*models.TaxRate, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *TaxRateService) Create(rate *models.TaxRate) error {
+	if err := validateTaxRate(rate); err != nil {
+		return err
+	}
+	return s.repo.Create(rate)
+}
+
+// Update mengubah tarif untuk penjualan berikutnya. Baris transaksi lama
+// menyimpan salinan tarifnya sendiri dan tidak ikut berubah.
+func (s *TaxRateService) Update(rate *models.TaxRate) error {
+	if err := validateTaxRate(rate); err != nil {
+		return err
+	}
+	return s.repo.Update(rate)
+}
+
+func (s *TaxRateService) Delete(id int) error {
+	return s.repo.Delete(id)
+}
+
+func validateTaxRate(rate *models.TaxRate) error {
+	rate.Name = strings.TrimSpace(rate.Name)
+	if rate.Name == "" {
+		return models.NewValidationError("name is required")
+	}
+	if rate.Rate < 0 || rate.Rate >= 100 {
+		return models.NewValidationError("rate must be between 0 and 100")
+	}
+	rate.Rate = math.Round(rate.Rate*100) / 100
+	return nil
+}
+
+// checkTaxRate memastikan tax_rate_id yang dipasang ke produk atau kategori ada.
+func checkTaxRate(repo *repositories.TaxRateRepository, id *int) error {
+	if id == nil {
+		return nil
+	}
+	_, err := repo.GetByID(*id)
+	if errors.Is(err, models.ErrTaxRateNotFound) {
+		return models.NewValidationError("tax rate %d not found", *id)
+	}
+	return err
+}
+
+// taxRateFor mengembalikan tarif produk, atau tarif kategorinya bila produk
+// tidak punya tarif sendiri. nil berarti produk tidak kena pajak.
+func taxRateFor(product *models.Product, rates map[int]models.TaxRate) *models.TaxRate {
+	id := product.TaxRateID
+	if id == nil && product.Category != nil {
+		id = product.Category.TaxRateID
+	}
+	if id == nil {
+		return nil
+	}
+	rate, ok := rates[*id]
+	if !ok {
+		return nil
+	}
+	return &rate
+}
+
+// applyTax menghitung pajak per baris dari subtotal bersih setelah diskon,
+// dibulatkan per baris. Untuk tarif inclusive pajak diambil dari dalam
+// subtotal; untuk exclusive pajak ditambahkan ke total yang harus dibayar.
+func applyTax(transaction *models.Transaction, products map[int]*models.Product, rates map[int]models.TaxRate) {
+	transaction.Tax, transaction.Total = 0, 0
+	for i := range transaction.Details {
+		detail := &transaction.Details[i]
+		detail.TaxRateID, detail.TaxName, detail.TaxRate, detail.TaxInclusive, detail.Tax = nil, "", 0, false, 0
+
+		if rate := taxRateFor(products[detail.ProductID], rates); rate != nil {
+			id := rate.ID
+			basisPoints := int(math.Round(rate.Rate * 100))
+			detail.TaxRateID, detail.TaxName, detail.TaxRate, detail.TaxInclusive = &id, rate.Name, rate.Rate, rate.Inclusive
+			if rate.Inclusive {
+				detail.Tax = detail.Subtotal - divRound(detail.Subtotal*10000, 10000+basisPoints)
+			} else {
+				detail.Tax = divRound(detail.Subtotal*basisPoints, 10000)
+			}
+		}
+
+		transaction.Tax += detail.Tax
+		transaction.Total += detail.Amount()
+	}
+}
+
+// divRound membagi bilangan non-negatif dengan pembulatan setengah ke atas.
+func divRound(a, b int) int {
+	return (a + b/2) / b
+}
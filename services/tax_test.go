@@ -0,0 +1,113 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"testing"
+)
+
+func TestDivRound(t *testing.T) {
+	tests := []struct {
+		a, b, want int
+	}{
+		{0, 7, 0},
+		{1, 2, 1},
+		{3, 2, 2},
+		{4, 3, 1},
+		{5, 3, 2},
+		{14999, 10000, 1},
+		{15000, 10000, 2},
+		{25000, 10000, 3},
+	}
+	for _, tt := range tests {
+		if got := divRound(tt.a, tt.b); got != tt.want {
+			t.Errorf("divRound(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestApplyTax(t *testing.T) {
+	rates := map[int]models.TaxRate{
+		1: {ID: 1, Name: "PPN", Rate: 11},
+		2: {ID: 2, Name: "PPN incl", Rate: 11, Inclusive: true},
+		3: {ID: 3, Name: "PB1", Rate: 10},
+		4: {ID: 4, Name: "PPh", Rate: 2.5},
+	}
+	products := map[int]*models.Product{
+		1: {ID: 1, TaxRateID: intPtr(1)},
+		2: {ID: 2, TaxRateID: intPtr(2)},
+		3: {ID: 3, Category: &models.Category{TaxRateID: intPtr(3)}},
+		4: {ID: 4},
+		5: {ID: 5, TaxRateID: intPtr(1), Category: &models.Category{TaxRateID: intPtr(3)}},
+		6: {ID: 6, TaxRateID: intPtr(99)},
+		7: {ID: 7, TaxRateID: intPtr(4)},
+	}
+	// taxed membuat baris dengan subtotal bersih setelah discount.
+	taxed := func(productID, subtotal, discount int) models.TransactionDetail {
+		return models.TransactionDetail{ProductID: productID, Subtotal: subtotal, Discount: discount}
+	}
+
+	tests := []struct {
+		name      string
+		details   []models.TransactionDetail
+		wantTaxes []int
+		wantTax   int
+		wantTotal int
+	}{
+		{"exclusive", []models.TransactionDetail{taxed(1, 10000, 0)}, []int{1100}, 1100, 11100},
+		{"exclusive rounds half up", []models.TransactionDetail{taxed(1, 50, 0)}, []int{6}, 6, 56},
+		{"exclusive rounds down below half", []models.TransactionDetail{taxed(1, 40, 0)}, []int{4}, 4, 44},
+		{"exclusive rounds up above half", []models.TransactionDetail{taxed(1, 45, 0)}, []int{5}, 5, 50},
+		{"inclusive", []models.TransactionDetail{taxed(2, 11100, 0)}, []int{1100}, 1100, 11100},
+		{"inclusive rounds the base", []models.TransactionDetail{taxed(2, 1000, 0)}, []int{99}, 99, 1000},
+		{"fractional rate", []models.TransactionDetail{taxed(7, 1000, 0)}, []int{25}, 25, 1025},
+		{"rounded per line", []models.TransactionDetail{taxed(1, 50, 0), taxed(1, 50, 0)}, []int{6, 6}, 12, 112},
+		{"discounted line is taxed on the net subtotal", []models.TransactionDetail{taxed(1, 9000, 1000)}, []int{990}, 990, 9990},
+		{"fully discounted line", []models.TransactionDetail{taxed(1, 0, 5000)}, []int{0}, 0, 0},
+		{"category rate", []models.TransactionDetail{taxed(3, 5000, 0)}, []int{500}, 500, 5500},
+		{"product rate overrides category", []models.TransactionDetail{taxed(5, 10000, 0)}, []int{1100}, 1100, 11100},
+		{"unknown rate is untaxed", []models.TransactionDetail{taxed(6, 10000, 0)}, []int{0}, 0, 10000},
+		{
+			name: "mixed rates",
+			details: []models.TransactionDetail{
+				taxed(1, 10000, 0),
+				taxed(2, 11100, 0),
+				taxed(3, 4500, 500),
+				taxed(4, 2000, 0),
+			},
+			wantTaxes: []int{1100, 1100, 450, 0},
+			wantTax:   2650,
+			wantTotal: 11100 + 11100 + 4950 + 2000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := &models.Transaction{Details: tt.details}
+			applyTax(transaction, products, rates)
+
+			for i, d := range transaction.Details {
+				if d.Tax != tt.wantTaxes[i] {
+					t.Errorf("line %d tax = %d, want %d", i, d.Tax, tt.wantTaxes[i])
+				}
+			}
+			if transaction.Tax != tt.wantTax || transaction.Total != tt.wantTotal {
+				t.Errorf("tax/total = %d/%d, want %d/%d", transaction.Tax, transaction.Total, tt.wantTax, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestApplyTaxResetsLine(t *testing.T) {
+	transaction := &models.Transaction{Details: []models.TransactionDetail{{
+		ProductID: 4, Subtotal: 1000, TaxRateID: intPtr(1), TaxName: "PPN", TaxRate: 11, TaxInclusive: true, Tax: 99,
+	}}}
+	applyTax(transaction, map[int]*models.Product{4: {ID: 4}}, nil)
+
+	d := transaction.Details[0]
+	if d.TaxRateID != nil || d.TaxName != "" || d.TaxRate != 0 || d.TaxInclusive || d.Tax != 0 {
+		t.Errorf("untaxed line keeps tax fields: %+v", d)
+	}
+	if transaction.Total != 1000 {
+		t.Errorf("total = %d, want 1000", transaction.Total)
+	}
+}
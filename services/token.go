@@ -0,0 +1,84 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"go-kasir-api/models"
+	"strings"
+	"time"
+)
+
+var errInvalidToken = errors.New("invalid token")
+
+// TokenService menerbitkan dan memverifikasi JWT HS256.
+type TokenService struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+type tokenClaims struct {
+	Subject   int    `json:"sub"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+func NewTokenService(secret string, ttl time.Duration) *TokenService {
+	return &TokenService{secret: []byte(secret), ttl: ttl}
+}
+
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+func (s *TokenService) Sign(user *models.User, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(s.ttl)
+	payload, err := json.Marshal(tokenClaims{
+		Subject:   user.ID,
+		Username:  user.Username,
+		Role:      user.Role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	unsigned := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + s.signature(unsigned), expiresAt, nil
+}
+
+// Verify memeriksa tanda tangan dan masa berlaku token, lalu mengembalikan ID user.
+func (s *TokenService) Verify(token string, now time.Time) (int, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return 0, errInvalidToken
+	}
+
+	expected := s.signature(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return 0, errInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, errInvalidToken
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return 0, errInvalidToken
+	}
+	if now.Unix() >= claims.ExpiresAt {
+		return 0, errors.New("token expired")
+	}
+
+	return claims.Subject, nil
+}
+
+func (s *TokenService) signature(unsigned string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
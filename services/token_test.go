@@ -0,0 +1,70 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"go-kasir-api/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenServiceVerify(t *testing.T) {
+	tokens := NewTokenService("secret", time.Hour)
+	issued := time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC)
+	token, expiresAt, err := tokens.Sign(&models.User{ID: 7, Username: "kasir", Role: models.RoleCashier}, issued)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(token, ".")
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	// sign menandatangani header dan payload dengan secret sendiri.
+	sign := func(secret, header, payload string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(header + "." + payload))
+		return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	}
+	forged := encode(`{"sub":1,"username":"owner","role":"owner","iat":0,"exp":9999999999}`)
+
+	tests := []struct {
+		name    string
+		token   string
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "valid", token: token, now: issued},
+		{name: "one second before expiry", token: token, now: expiresAt.Add(-time.Second)},
+		{name: "at expiry", token: token, now: expiresAt, wantErr: true},
+		{name: "after expiry", token: token, now: expiresAt.Add(time.Minute), wantErr: true},
+		{name: "tampered payload", token: parts[0] + "." + forged + "." + parts[2], now: issued, wantErr: true},
+		{name: "tampered signature", token: parts[0] + "." + parts[1] + "." + strings.ToUpper(parts[2]), now: issued, wantErr: true},
+		{name: "missing signature", token: parts[0] + "." + parts[1] + ".", now: issued, wantErr: true},
+		{name: "signed with another secret", token: sign("other", parts[0], forged), now: issued, wantErr: true},
+		{name: "alg none", token: encode(`{"alg":"none","typ":"JWT"}`) + "." + forged + ".", now: issued, wantErr: true},
+		{name: "alg none with signature", token: encode(`{"alg":"none","typ":"JWT"}`) + "." + parts[1] + "." + parts[2], now: issued, wantErr: true},
+		{name: "alg HS512 signed with the secret", token: sign("secret", encode(`{"alg":"HS512","typ":"JWT"}`), parts[1]), now: issued, wantErr: true},
+		{name: "header with extra fields", token: sign("secret", encode(`{"alg":"HS256","typ":"JWT","kid":"x"}`), parts[1]), now: issued, wantErr: true},
+		{name: "payload not base64", token: sign("secret", parts[0], "!!"), now: issued, wantErr: true},
+		{name: "payload not json", token: sign("secret", parts[0], encode("kasir")), now: issued, wantErr: true},
+		{name: "too few parts", token: parts[0] + "." + parts[1], now: issued, wantErr: true},
+		{name: "too many parts", token: token + ".x", now: issued, wantErr: true},
+		{name: "empty", token: "", now: issued, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := tokens.Verify(tt.token, tt.now)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Verify = %d, want error", id)
+				}
+				return
+			}
+			if err != nil || id != 7 {
+				t.Errorf("Verify = %d, %v; want 7", id, err)
+			}
+		})
+	}
+}
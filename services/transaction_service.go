@@ -1,29 +1,462 @@
 package services
 
 import (
+	"errors"
+	"fmt"
 	"go-kasir-api/models"
 	"go-kasir-api/repositories"
+	"log"
+	"strings"
 	"time"
 )
 
 type TransactionService struct {
-	repo *repositories.TransactionRepository
+	repo          *repositories.TransactionRepository
+	productRepo   *repositories.ProductRepository
+	modifierRepo  *repositories.ModifierGroupRepository
+	promotionRepo *repositories.PromotionRepository
+	taxRateRepo   *repositories.TaxRateRepository
+	customerRepo  *repositories.CustomerRepository
+	loyalty       LoyaltyRules
+	day           *BusinessDay
+	notifier      Notifier
 }
 
-func NewTransactionService(repo *repositories.TransactionRepository) *TransactionService {
-	return &TransactionService{repo: repo}
+// NewTransactionService membuat service transaksi. notifier boleh nil bila
+// peringatan stok menipis tidak perlu dikirim.
+func NewTransactionService(repo *repositories.TransactionRepository, productRepo *repositories.ProductRepository, modifierRepo *repositories.ModifierGroupRepository, promotionRepo *repositories.PromotionRepository, taxRateRepo *repositories.TaxRateRepository, customerRepo *repositories.CustomerRepository, loyalty LoyaltyRules, day *BusinessDay, notifier Notifier) *TransactionService {
+	return &TransactionService{repo: repo, productRepo: productRepo, modifierRepo: modifierRepo, promotionRepo: promotionRepo, taxRateRepo: taxRateRepo, customerRepo: customerRepo, loyalty: loyalty, day: day, notifier: notifier}
 }
 
+// CreateTransaction memvalidasi payload checkout. Subtotal dan total dihitung
+// ulang dari harga produk di dalam tx checkout, nilai dari client tidak
+// dipakai. Promosi yang berlaku saat ini diterapkan lebih dulu, lalu pajak
+// dihitung dari subtotal setelah diskon; pembayaran harus menutup total akhir.
+// Baris boleh memilih varian lewat variant_id, atau product_id induk bersama
+// options; keduanya diganti menjadi product_id varian sebelum checkout.
+// Modifier yang dipilih menambah harga per unit baris. Unit kosong berarti
+// satuan dasar produk; satuan lain dikonversi ke satuan dasar untuk stok.
+// Dengan customer_id, pelanggan mendapat poin dari total yang tidak dibayar
+// dengan poin dan boleh membayar dengan tender points, atau menagihkan
+// sebagian/seluruh total ke kasbonnya dengan tender credit. Field dokumen
+// (tipe, referensi, alasan, approver, tanggal) selalu diisi server.
 func (s *TransactionService) CreateTransaction(transaction *models.Transaction) error {
-	// Calculate total amount if not provided
-	if transaction.Total == 0 {
-		for _, detail := range transaction.Details {
-			transaction.Total += detail.Subtotal
+	clearServerFields(transaction)
+	if len(transaction.Details) == 0 {
+		return models.NewValidationError("transaction must contain at least one item")
+	}
+	for _, detail := range transaction.Details {
+		if detail.ProductID <= 0 && detail.VariantID == nil {
+			return models.NewValidationError("invalid product_id %d", detail.ProductID)
+		}
+		if detail.Quantity <= 0 {
+			return models.NewValidationError("quantity for product %d must be greater than 0", detail.ProductID)
+		}
+	}
+	if err := s.resolveVariants(transaction.Details); err != nil {
+		return err
+	}
+	productIDs := make([]int, len(transaction.Details))
+	for i, detail := range transaction.Details {
+		productIDs[i] = detail.ProductID
+	}
+	units, err := s.productRepo.GetUnits(productIDs)
+	if err != nil {
+		return err
+	}
+	lineUnits, err := selectUnits(transaction.Details, units)
+	if err != nil {
+		return err
+	}
+	modifierGroups, err := s.modifierRepo.GetForProducts(productIDs)
+	if err != nil {
+		return err
+	}
+	if err := selectModifiers(transaction.Details, modifierGroups); err != nil {
+		return err
+	}
+	if err := validatePayments(transaction.Payments); err != nil {
+		return err
+	}
+	var customer *models.Customer
+	if transaction.CustomerID != nil {
+		customer, err = s.customerRepo.GetByID(*transaction.CustomerID)
+		if errors.Is(err, models.ErrCustomerNotFound) {
+			return models.NewValidationError("customer %d not found", *transaction.CustomerID)
+		}
+		if err != nil {
+			return err
+		}
+		if !customer.Active {
+			return models.NewValidationError("customer %d is inactive", customer.ID)
+		}
+	}
+	pointsAmount, err := s.loyalty.pointsPayment(transaction.Payments, customer)
+	if err != nil {
+		return err
+	}
+	if _, err := creditPayment(transaction.Payments, customer); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	transaction.Date = now
+	promotions, err := s.promotionRepo.GetActive(now)
+	if err != nil {
+		return err
+	}
+	taxRates, err := s.taxRateRepo.GetAll()
+	if err != nil {
+		return err
+	}
+	ratesByID := make(map[int]models.TaxRate, len(taxRates))
+	for _, rate := range taxRates {
+		ratesByID[rate.ID] = rate
+	}
+
+	alerts, err := s.repo.CreateTransaction(transaction, func(products map[int]*models.Product) error {
+		if err := priceLines(transaction, products, lineUnits); err != nil {
+			return err
+		}
+		applyPromotions(transaction, products, promotions, now.In(s.day.Location))
+		applyTax(transaction, products, ratesByID)
+		if err := settlePayments(transaction); err != nil {
+			return err
+		}
+		s.loyalty.applyLoyalty(transaction, customer, pointsAmount)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(alerts) > 0 && s.notifier != nil {
+		go s.notifyLowStock(alerts)
+	}
+	return nil
+}
+
+// clearServerFields membuang nilai dari client untuk field yang hanya boleh
+// diisi server. ReferenceDetailID pada baris penjualan akan ikut dihitung
+// sebagai retur baris penjualan lain, jadi wajib dikosongkan.
+func clearServerFields(transaction *models.Transaction) {
+	transaction.ID = 0
+	transaction.Type = models.TransactionTypeSale
+	transaction.ReferenceID = nil
+	transaction.ShiftID = nil
+	transaction.Reason, transaction.ApprovedBy = "", ""
+	transaction.Date = time.Time{}
+	for i := range transaction.Details {
+		d := &transaction.Details[i]
+		d.ID, d.TransactionID = 0, 0
+		d.ReferenceDetailID = nil
+	}
+}
+
+// resolveVariants mengganti variant_id atau options pada setiap baris dengan
+// product_id varian yang dimaksud.
+func (s *TransactionService) resolveVariants(details []models.TransactionDetail) error {
+	parents := make(map[int]*models.Product)
+	for i := range details {
+		detail := &details[i]
+		switch {
+		case detail.VariantID != nil:
+			variant, err := s.productRepo.GetByID(*detail.VariantID)
+			if errors.Is(err, models.ErrProductNotFound) || (err == nil && variant.ParentID == nil) {
+				return models.NewValidationError("variant %d not found", *detail.VariantID)
+			}
+			if err != nil {
+				return err
+			}
+			if detail.ProductID > 0 && detail.ProductID != *variant.ParentID {
+				return models.NewValidationError("variant %d does not belong to product %d", variant.ID, detail.ProductID)
+			}
+			detail.ProductID = variant.ID
+		case len(detail.Options) > 0:
+			parent, ok := parents[detail.ProductID]
+			if !ok {
+				var err error
+				parent, err = s.productRepo.GetByID(detail.ProductID)
+				if errors.Is(err, models.ErrProductNotFound) {
+					return fmt.Errorf("product %d: %w", detail.ProductID, err)
+				}
+				if err != nil {
+					return err
+				}
+				parents[detail.ProductID] = parent
+			}
+			variant := matchVariant(parent.Variants, detail.Options)
+			if variant == nil {
+				return models.NewValidationError("product %d has no variant with options %s", detail.ProductID, formatOptions(detail.Options))
+			}
+			detail.ProductID = variant.ID
+		}
+		detail.VariantID, detail.Options = nil, nil
+	}
+	return nil
+}
+
+// matchVariant mencari varian yang opsinya sama persis dengan pilihan kasir;
+// nama grup dan nilai dicocokkan tanpa memandang huruf besar/kecil.
+func matchVariant(variants []models.Product, options []models.VariantOption) *models.Product {
+	for i := range variants {
+		v := &variants[i]
+		if len(v.Options) != len(options) {
+			continue
+		}
+		matched := 0
+		for _, have := range v.Options {
+			for _, want := range options {
+				if strings.EqualFold(strings.TrimSpace(want.Group), have.Group) && strings.EqualFold(strings.TrimSpace(want.Value), have.Value) {
+					matched++
+					break
+				}
+			}
+		}
+		if matched == len(options) {
+			return v
+		}
+	}
+	return nil
+}
+
+func formatOptions(options []models.VariantOption) string {
+	parts := make([]string, len(options))
+	for i, o := range options {
+		parts[i] = o.Group + "=" + o.Value
+	}
+	return strings.Join(parts, ", ")
+}
+
+// selectUnits mencari satuan setiap baris dan mengisi Unit serta UnitFactor,
+// supaya stok yang diminta bisa dihitung dalam satuan dasar. Produk yang
+// tidak ada dibiarkan; checkout akan menolaknya saat produk dikunci.
+func selectUnits(details []models.TransactionDetail, unitsByProduct map[int][]models.ProductUnit) ([]models.ProductUnit, error) {
+	lineUnits := make([]models.ProductUnit, len(details))
+	for i := range details {
+		detail := &details[i]
+		units, ok := unitsByProduct[detail.ProductID]
+		u, found := findUnit(units, detail.Unit)
+		if ok && !found {
+			return nil, models.NewValidationError("product %d has no unit %q", detail.ProductID, detail.Unit)
+		}
+		if !ok {
+			u = models.ProductUnit{Name: detail.Unit, Factor: models.Units(1)}
+		}
+		detail.Unit, detail.UnitFactor = u.Name, u.Factor
+		lineUnits[i] = u
+	}
+	return lineUnits, nil
+}
+
+// selectModifiers memeriksa modifier setiap baris terhadap grup yang berlaku
+// untuk produknya, termasuk grup wajib yang tidak dipilih, lalu menyalin nama
+// grup, nama dan harga modifier ke baris.
+func selectModifiers(details []models.TransactionDetail, groupsByProduct map[int][]models.ModifierGroup) error {
+	for i := range details {
+		detail := &details[i]
+		groups := groupsByProduct[detail.ProductID]
+		chosen := make(map[int]bool, len(detail.Modifiers))
+		counts := make(map[int]int, len(groups))
+		for j := range detail.Modifiers {
+			m := &detail.Modifiers[j]
+			if m.ModifierID == nil {
+				return models.NewValidationError("modifier_id is required for modifiers of product %d", detail.ProductID)
+			}
+			if chosen[*m.ModifierID] {
+				return models.NewValidationError("modifier %d is chosen twice for product %d", *m.ModifierID, detail.ProductID)
+			}
+			chosen[*m.ModifierID] = true
+
+			group, modifier := findModifier(groups, *m.ModifierID)
+			if modifier == nil || !modifier.Active {
+				return models.NewValidationError("modifier %d is not available for product %d", *m.ModifierID, detail.ProductID)
+			}
+			m.GroupName, m.Name, m.Price = group.Name, modifier.Name, modifier.Price
+			counts[group.ID]++
 		}
+
+		for _, g := range groups {
+			n := counts[g.ID]
+			if n == 0 && !g.Required {
+				continue
+			}
+			if n < g.MinSelect {
+				return models.NewValidationError("%s needs at least %d choice(s) for product %d", g.Name, g.MinSelect, detail.ProductID)
+			}
+			if g.MaxSelect > 0 && n > g.MaxSelect {
+				return models.NewValidationError("%s allows at most %d choice(s) for product %d", g.Name, g.MaxSelect, detail.ProductID)
+			}
+		}
+	}
+	return nil
+}
+
+func findModifier(groups []models.ModifierGroup, id int) (*models.ModifierGroup, *models.Modifier) {
+	for i := range groups {
+		for j := range groups[i].Modifiers {
+			if groups[i].Modifiers[j].ID == id {
+				return &groups[i], &groups[i].Modifiers[j]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// notifyLowStock dijalankan di luar request checkout supaya webhook atau
+// email yang lambat tidak menahan kasir; kegagalan hanya dicatat di log.
+func (s *TransactionService) notifyLowStock(alerts []models.LowStockAlert) {
+	if err := s.notifier.NotifyLowStock(alerts); err != nil {
+		log.Printf("low stock notification failed: %v", err)
+	}
+}
+
+// priceLines mengisi harga setiap baris dari produk yang sudah dikunci dan
+// satuan barisnya, ditambah harga modifier yang dipilih. Qty dalam satuan
+// dasar harus muat di jumlah desimal produk.
+func priceLines(transaction *models.Transaction, products map[int]*models.Product, units []models.ProductUnit) error {
+	transaction.Total = 0
+	for i := range transaction.Details {
+		detail := &transaction.Details[i]
+		product := products[detail.ProductID]
+		if _, err := baseQuantity(product, detail.Quantity, units[i]); err != nil {
+			return err
+		}
+		detail.ProductName = product.Name
+		detail.ParentProductID = product.ParentID
+		price, ok := unitPrice(product, units[i])
+		if !ok {
+			return models.NewValidationError("price of %s %s is too large", units[i].Name, product.Name)
+		}
+		detail.UnitPrice = price + detail.ModifierPrice()
+		if detail.UnitCost, ok = units[i].Factor.Amount(product.Cost); !ok {
+			return models.NewValidationError("cost of %s %s is too large", units[i].Name, product.Name)
+		}
+		if detail.Subtotal, ok = detail.Quantity.Amount(detail.UnitPrice); !ok {
+			return models.NewValidationError("subtotal of %s %s %s is too large", detail.Quantity, units[i].Name, product.Name)
+		}
+		transaction.Total += detail.Subtotal
 	}
-	return s.repo.CreateTransaction(transaction)
+	return nil
 }
 
-func (s *TransactionService) GetDailyReport(date time.Time) (map[string]interface{}, error) {
-	return s.repo.GetDailyReport(date)
+// List mengembalikan transaksi sesuai filter. StartDate dan EndDate adalah
+// tanggal bisnis inklusif.
+func (s *TransactionService) List(filter models.TransactionFilter) (*models.TransactionList, error) {
+	filter.Page, filter.PageSize = normalizePage(filter.Page, filter.PageSize)
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return nil, models.NewValidationError("start_date must not be after end_date")
+	}
+	switch filter.Type {
+	case "", models.TransactionTypeSale, models.TransactionTypeVoid, models.TransactionTypeReturn:
+	default:
+		return nil, models.NewValidationError("type must be one of sale, void, return")
+	}
+	if filter.MinTotal != nil && filter.MaxTotal != nil && *filter.MinTotal > *filter.MaxTotal {
+		return nil, models.NewValidationError("min_total must not be greater than max_total")
+	}
+
+	// Filter dari handler berisi tanggal bisnis; ubah ke rentang waktu absolut
+	if filter.StartDate != nil {
+		start := s.day.Start(*filter.StartDate)
+		filter.StartDate = &start
+	}
+	if filter.EndDate != nil {
+		end := s.day.Start(filter.EndDate.AddDate(0, 0, 1))
+		filter.EndDate = &end
+	}
+
+	transactions, total, err := s.repo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TransactionList{
+		Data:       transactions,
+		Pagination: newPagination(filter.Page, filter.PageSize, total),
+	}, nil
+}
+
+func (s *TransactionService) GetByID(id int) (*models.Transaction, error) {
+	return s.repo.GetByID(id)
+}
+
+// Void membatalkan seluruh sisa penjualan. Hanya boleh pada hari bisnis yang
+// sama dengan penjualan dan selama shift penjualan belum ditutup; manager yang
+// menjalankannya tercatat sebagai approver.
+func (s *TransactionService) Void(id int, req models.VoidRequest, manager *models.User) (*models.Transaction, error) {
+	refund, err := refundPayment(req.Reason, req.RefundMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !s.day.DateOf(original.Date).Equal(s.day.DateOf(now)) {
+		return nil, models.NewValidationError("transaction %d can only be voided on the business day it was made, use a return instead", id)
+	}
+
+	void := &models.Transaction{
+		Type:        models.TransactionTypeVoid,
+		ReferenceID: &id,
+		CashierID:   &manager.ID,
+		Date:        now,
+		Reason:      req.Reason,
+		ApprovedBy:  manager.Username,
+		Payments:    refund,
+	}
+	if err := s.repo.Reverse(void); err != nil {
+		return nil, err
+	}
+	return void, nil
+}
+
+// Return mengembalikan sebagian item penjualan dan menambah stoknya kembali.
+func (s *TransactionService) Return(id int, req models.ReturnRequest, manager *models.User) (*models.Transaction, error) {
+	refund, err := refundPayment(req.Reason, req.RefundMethod)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Items) == 0 {
+		return nil, models.NewValidationError("return must contain at least one item")
+	}
+
+	ret := &models.Transaction{
+		Type:        models.TransactionTypeReturn,
+		ReferenceID: &id,
+		CashierID:   &manager.ID,
+		Date:        time.Now(),
+		Reason:      req.Reason,
+		ApprovedBy:  manager.Username,
+		Payments:    refund,
+	}
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, models.NewValidationError("quantity for detail %d must be greater than 0", item.DetailID)
+		}
+		detailID := item.DetailID
+		ret.Details = append(ret.Details, models.TransactionDetail{ReferenceDetailID: &detailID, Quantity: item.Quantity})
+	}
+
+	if err := s.repo.Reverse(ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// refundPayment memvalidasi alasan dan menyiapkan tender refund (default tunai).
+func refundPayment(reason, method string) ([]models.Payment, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, models.NewValidationError("reason is required")
+	}
+	if method == "" {
+		method = models.PaymentCash
+	}
+	if !isValidPaymentMethod(method) {
+		return nil, models.NewValidationError("refund_method must be one of cash, card, ewallet, qris")
+	}
+	return []models.Payment{{Method: method}}, nil
 }
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"strings"
+)
+
+// defaultUnit adalah satuan dasar produk yang tidak menyebut satuannya.
+const defaultUnit = "pcs"
+
+// validateUnits merapikan satuan dasar dan satuan lain produk. Qty stok dan
+// isi setiap satuan harus muat di jumlah desimal produk.
+func validateUnits(product *models.Product) error {
+	product.Unit = strings.TrimSpace(product.Unit)
+	if product.Unit == "" {
+		product.Unit = defaultUnit
+	}
+	if len(product.Unit) > 20 {
+		return models.NewValidationError("unit must be at most 20 characters")
+	}
+	if product.Decimals < 0 || product.Decimals > 3 {
+		return models.NewValidationError("decimals must be between 0 and 3")
+	}
+	for _, q := range []models.Quantity{product.Stock, product.MinStock, product.ReorderQuantity} {
+		if !q.Fits(product.Decimals) {
+			return models.NewValidationError("stock, min_stock and reorder_quantity may have at most %d decimals", product.Decimals)
+		}
+	}
+
+	seen := map[string]bool{strings.ToLower(product.Unit): true}
+	for i := range product.Units {
+		u := &product.Units[i]
+		u.Name = strings.TrimSpace(u.Name)
+		if u.Name == "" || len(u.Name) > 20 {
+			return models.NewValidationError("unit %d needs a name of at most 20 characters", i)
+		}
+		key := strings.ToLower(u.Name)
+		if seen[key] {
+			return models.NewValidationError("unit %q is listed twice", u.Name)
+		}
+		seen[key] = true
+		if u.Factor <= 0 || !u.Factor.Fits(product.Decimals) {
+			return models.NewValidationError("factor of unit %q must be positive with at most %d decimals", u.Name, product.Decimals)
+		}
+		if u.Price != nil && *u.Price < 0 {
+			return models.NewValidationError("price of unit %q must not be negative", u.Name)
+		}
+	}
+	return nil
+}
+
+// unitsOf mengembalikan semua satuan produk, satuan dasar (isi 1) lebih dulu.
+func unitsOf(product *models.Product) []models.ProductUnit {
+	return append([]models.ProductUnit{{Name: product.Unit, Factor: models.Units(1)}}, product.Units...)
+}
+
+// findUnit mencari satuan berdasarkan nama tanpa memandang huruf besar/kecil.
+// Nama kosong berarti satuan pertama, yaitu satuan dasar.
+func findUnit(units []models.ProductUnit, name string) (models.ProductUnit, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" && len(units) > 0 {
+		return units[0], true
+	}
+	for _, u := range units {
+		if strings.EqualFold(u.Name, name) {
+			return u, true
+		}
+	}
+	return models.ProductUnit{}, false
+}
+
+// baseQuantity mengonversi qty dalam satuan u ke satuan dasar produk dan
+// memastikan hasilnya muat di jumlah desimal produk.
+func baseQuantity(product *models.Product, qty models.Quantity, u models.ProductUnit) (models.Quantity, error) {
+	base, exact := qty.Mul(u.Factor)
+	if !exact || !base.Fits(product.Decimals) {
+		if product.Decimals == 0 {
+			return 0, models.NewValidationError("%s %s of %s is not a whole number of %s", qty, u.Name, product.Name, product.Unit)
+		}
+		return 0, models.NewValidationError("%s %s of %s needs more than %d decimals in %s", qty, u.Name, product.Name, product.Decimals, product.Unit)
+	}
+	return base, nil
+}
+
+// unitPrice adalah harga jual per satuan u: harga satuan itu sendiri, atau
+// isinya kali harga satuan dasar. ok false bila harganya tidak muat di int64.
+func unitPrice(product *models.Product, u models.ProductUnit) (int, bool) {
+	if u.Price != nil {
+		return *u.Price, true
+	}
+	return u.Factor.Amount(product.Price)
+}
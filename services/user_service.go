@@ -0,0 +1,95 @@
+package services
+
+import (
+	"go-kasir-api/models"
+	"go-kasir-api/repositories"
+	"strings"
+)
+
+const minPasswordLength = 8
+
+type UserService struct {
+	repo *repositories.UserRepository
+}
+
+func NewUserService(repo *repositories.UserRepository) *UserService {
+	return &UserService{repo: repo}
+}
+
+func (s *UserService) GetAll() ([]models.User, error) {
+	return s.repo.GetAll()
+}
+
+func (s *UserService) GetByID(id int) (*models.User, error) {
+	return s.repo.GetByID(id)
+}
+
+func (s *UserService) Create(input models.UserInput) (*models.User, error) {
+	user := &models.User{Active: true}
+	if input.Active != nil {
+		user.Active = *input.Active
+	}
+	if input.Password == "" {
+		return nil, models.NewValidationError("password is required")
+	}
+	if err := applyUserInput(user, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *UserService) Update(id int, input models.UserInput) (*models.User, error) {
+	user, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if input.Active != nil {
+		user.Active = *input.Active
+	}
+	if err := applyUserInput(user, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func applyUserInput(user *models.User, input models.UserInput) error {
+	user.Username = strings.TrimSpace(input.Username)
+	user.Name = strings.TrimSpace(input.Name)
+	user.Role = input.Role
+
+	if user.Username == "" {
+		return models.NewValidationError("username is required")
+	}
+	if !isValidRole(user.Role) {
+		return models.NewValidationError("role must be one of owner, manager, cashier")
+	}
+
+	if input.Password != "" {
+		if len(input.Password) < minPasswordLength {
+			return models.NewValidationError("password must be at least %d characters", minPasswordLength)
+		}
+		hash, err := HashPassword(input.Password)
+		if err != nil {
+			return err
+		}
+		user.PasswordHash = hash
+	}
+	return nil
+}
+
+func isValidRole(role string) bool {
+	for _, r := range models.AllRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}